@@ -3,13 +3,25 @@ package config
 import (
 	"os"
 	"strconv"
+	"strings"
 )
 
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	JWT      JWTConfig
-	LogLevel string
+	Server        ServerConfig
+	Database      DatabaseConfig
+	JWT           JWTConfig
+	Interest      InterestConfig
+	AdminFee      AdminFeeConfig
+	DownPayment   DownPaymentConfig
+	Contract      ContractConfig
+	Payment       PaymentConfig
+	Delinquency   DelinquencyConfig
+	Limit         LimitConfig
+	Affordability AffordabilityConfig
+	Storage       StorageConfig
+	KYC           KYCConfig
+	Partner       PartnerConfig
+	LogLevel      string
 }
 
 type ServerConfig struct {
@@ -30,6 +42,76 @@ type JWTConfig struct {
 	ExpiryTime int
 }
 
+type InterestConfig struct {
+	Method     string
+	AnnualRate float64 // rate of the default product seeded into an empty catalog
+}
+
+type AdminFeeConfig struct {
+	MismatchPolicy string // REJECT or IGNORE a client admin fee that differs from the computed one
+}
+
+type DownPaymentConfig struct {
+	MinPercent []string // ASSET_TYPE:percent pairs
+}
+
+type ContractConfig struct {
+	NumberFormat  string // placeholders {prefix}, {date}, {branch}, {seq}, {check}
+	Prefix        string
+	Branch        string
+	SequenceWidth int
+}
+
+type PaymentConfig struct {
+	AllocationOrder []string
+}
+
+type DelinquencyConfig struct {
+	GracePeriodDays      int
+	LateFeeDailyRate     float64
+	LateFeeCapRate       float64
+	DefaultThresholdDays int
+	RunIntervalHours     int
+}
+
+type LimitConfig struct {
+	NonRevolvingTenors []int
+	TenorMultipliers   []string
+	YoungAge           int
+	YoungFactor        float64
+	SeniorAge          int
+	SeniorFactor       float64
+	RoundTo            float64
+	MaxLimit           float64
+}
+
+type AffordabilityConfig struct {
+	MaxDebtToIncome float64 // share of salary, 0 disables the check
+}
+
+type StorageConfig struct {
+	UploadPath       string
+	MaxUploadSize    int
+	AllowedMIMETypes []string
+	// Supporting documents of limit change requests, e.g. payslip PDFs
+	LimitDocumentMaxSize   int
+	LimitDocumentMIMETypes []string
+	PublicBaseURL          string // prefix of signed file URLs
+	URLSecret              string
+	URLTTLMinutes          int
+}
+
+type KYCConfig struct {
+	NIKRegionFile           string // optional CSV of regency and district codes
+	WatchlistAction         string
+	DuplicateThreshold      float64
+	DuplicateMaxNIKDistance int
+}
+
+type PartnerConfig struct {
+	CredentialGraceHours int // how long rotated-out API credentials keep working
+}
+
 func NewConfig() *Config {
 	return &Config{
 		Server: ServerConfig{
@@ -47,6 +129,64 @@ func NewConfig() *Config {
 			Secret:     getEnv("JWT_SECRET", "cghjyads896yVHuJnK567"),
 			ExpiryTime: getEnvInt("JWT_EXPIRY_HOURS", 24),
 		},
+		Interest: InterestConfig{
+			Method:     getEnv("INTEREST_METHOD", "FLAT"),
+			AnnualRate: getEnvFloat("INTEREST_ANNUAL_RATE", 24),
+		},
+		AdminFee: AdminFeeConfig{
+			MismatchPolicy: getEnv("ADMIN_FEE_MISMATCH_POLICY", "IGNORE"),
+		},
+		DownPayment: DownPaymentConfig{
+			MinPercent: getEnvList("DOWN_PAYMENT_MIN_PERCENT", "MOTOR:10,MOBIL:20"),
+		},
+		Contract: ContractConfig{
+			NumberFormat:  getEnv("CONTRACT_NUMBER_FORMAT", "{prefix}-{date}-{branch}-{seq}-{check}"),
+			Prefix:        getEnv("CONTRACT_NUMBER_PREFIX", "XYZ"),
+			Branch:        getEnv("CONTRACT_NUMBER_BRANCH", "JKT"),
+			SequenceWidth: getEnvInt("CONTRACT_NUMBER_SEQUENCE_WIDTH", 6),
+		},
+		Payment: PaymentConfig{
+			AllocationOrder: getEnvList("PAYMENT_ALLOCATION_ORDER", "PENALTY,INTEREST,ADMIN_FEE,PRINCIPAL"),
+		},
+		Delinquency: DelinquencyConfig{
+			GracePeriodDays:      getEnvInt("DELINQUENCY_GRACE_PERIOD_DAYS", 0),
+			LateFeeDailyRate:     getEnvFloat("LATE_FEE_DAILY_RATE", 0.001),
+			LateFeeCapRate:       getEnvFloat("LATE_FEE_CAP_RATE", 0.1),
+			DefaultThresholdDays: getEnvInt("DEFAULT_THRESHOLD_DAYS", 90),
+			RunIntervalHours:     getEnvInt("DELINQUENCY_RUN_INTERVAL_HOURS", 24),
+		},
+		Limit: LimitConfig{
+			NonRevolvingTenors: getEnvIntList("LIMIT_NON_REVOLVING_TENORS", ""),
+			TenorMultipliers:   getEnvList("LIMIT_TENOR_MULTIPLIERS", "1:1,2:1.5,3:2,4:2.5"),
+			YoungAge:           getEnvInt("LIMIT_YOUNG_AGE", 25),
+			YoungFactor:        getEnvFloat("LIMIT_YOUNG_FACTOR", 0.8),
+			SeniorAge:          getEnvInt("LIMIT_SENIOR_AGE", 55),
+			SeniorFactor:       getEnvFloat("LIMIT_SENIOR_FACTOR", 0.7),
+			RoundTo:            getEnvFloat("LIMIT_ROUND_TO", 100000),
+			MaxLimit:           getEnvFloat("LIMIT_MAX_AMOUNT", 0),
+		},
+		Affordability: AffordabilityConfig{
+			MaxDebtToIncome: getEnvFloat("MAX_DEBT_TO_INCOME_RATIO", 0.3),
+		},
+		Storage: StorageConfig{
+			UploadPath:             getEnv("UPLOAD_PATH", "./uploads"),
+			MaxUploadSize:          getEnvInt("MAX_UPLOAD_SIZE", 5*1024*1024),
+			AllowedMIMETypes:       getEnvList("UPLOAD_ALLOWED_MIME_TYPES", "image/jpeg,image/png"),
+			LimitDocumentMaxSize:   getEnvInt("LIMIT_DOCUMENT_MAX_UPLOAD_SIZE", 5*1024*1024),
+			LimitDocumentMIMETypes: getEnvList("LIMIT_DOCUMENT_ALLOWED_MIME_TYPES", "application/pdf,image/jpeg,image/png"),
+			PublicBaseURL:          getEnv("STORAGE_PUBLIC_BASE_URL", "http://localhost:8080"),
+			URLSecret:              getEnv("STORAGE_URL_SECRET", ""),
+			URLTTLMinutes:          getEnvInt("STORAGE_URL_TTL_MINUTES", 15),
+		},
+		KYC: KYCConfig{
+			NIKRegionFile:           getEnv("NIK_REGION_FILE", ""),
+			WatchlistAction:         getEnv("WATCHLIST_ACTION", "BLOCK"),
+			DuplicateThreshold:      getEnvFloat("DUPLICATE_SCORE_THRESHOLD", 0.75),
+			DuplicateMaxNIKDistance: getEnvInt("DUPLICATE_MAX_NIK_DISTANCE", 2),
+		},
+		Partner: PartnerConfig{
+			CredentialGraceHours: getEnvInt("PARTNER_CREDENTIAL_GRACE_HOURS", 24),
+		},
 		LogLevel: getEnv("LOG_LEVEL", "info"),
 	}
 }
@@ -66,3 +206,32 @@ func getEnvInt(key string, defaultValue int) int {
 	}
 	return defaultValue
 }
+
+func getEnvFloat(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if floatValue, err := strconv.ParseFloat(value, 64); err == nil {
+			return floatValue
+		}
+	}
+	return defaultValue
+}
+
+func getEnvList(key, defaultValue string) []string {
+	var values []string
+	for _, value := range strings.Split(getEnv(key, defaultValue), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+func getEnvIntList(key, defaultValue string) []int {
+	var values []int
+	for _, value := range getEnvList(key, defaultValue) {
+		if intValue, err := strconv.Atoi(value); err == nil {
+			values = append(values, intValue)
+		}
+	}
+	return values
+}
@@ -0,0 +1,73 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+)
+
+// AdminFeeTiered charges by OTR band; only admin fee rules may use it.
+const AdminFeeTiered AdminFeeType = "TIERED"
+
+// AdminFeeTier prices one OTR band of a tiered rule.
+type AdminFeeTier struct {
+	UpToOTR float64      `json:"up_to_otr"` // inclusive upper bound, 0 means no upper bound
+	Type    AdminFeeType `json:"type"`      // FLAT or PERCENTAGE
+	Value   float64      `json:"value"`
+}
+
+// AdminFeeRule computes the admin fee of new contracts on the server. A rule
+// may be limited to one product and/or one transaction source; the most
+// specific active rule wins.
+type AdminFeeRule struct {
+	ID                uint64            `json:"id" gorm:"primaryKey;autoIncrement"`
+	Name              string            `json:"name" gorm:"type:varchar(255);not null"`
+	ProductID         *uint64           `json:"product_id" gorm:"index"`                          // nil applies to every product
+	TransactionSource TransactionSource `json:"transaction_source" gorm:"type:varchar(20);index"` // empty applies to every source
+	Type              AdminFeeType      `json:"type" gorm:"type:varchar(20);not null"`
+	Value             float64           `json:"value" gorm:"type:decimal(15,4);default:0"` // amount for FLAT, percent of OTR for PERCENTAGE
+	Tiers             []AdminFeeTier    `json:"tiers" gorm:"type:text;serializer:json"`
+	MinFee            float64           `json:"min_fee" gorm:"type:decimal(15,2);default:0"`
+	MaxFee            float64           `json:"max_fee" gorm:"type:decimal(15,2);default:0"` // 0 means no cap
+	IsActive          bool              `json:"is_active" gorm:"index"`                      // no column default, or GORM would skip an explicit false on insert
+	CreatedAt         time.Time         `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt         time.Time         `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+func (AdminFeeRule) TableName() string {
+	return "admin_fee_rules"
+}
+
+// Matches reports whether the rule applies to the product and source.
+func (r *AdminFeeRule) Matches(productID uint64, source TransactionSource) bool {
+	if !r.IsActive {
+		return false
+	}
+	if r.ProductID != nil && *r.ProductID != productID {
+		return false
+	}
+	return r.TransactionSource == "" || r.TransactionSource == source
+}
+
+// Specificity ranks matching rules: product and source beat product alone,
+// which beats source alone, which beats a catch-all rule.
+func (r *AdminFeeRule) Specificity() int {
+	specificity := 0
+	if r.ProductID != nil {
+		specificity += 2
+	}
+	if r.TransactionSource != "" {
+		specificity++
+	}
+	return specificity
+}
+
+// AdminFeeMismatchError is returned when a client-supplied admin fee differs
+// from the fee computed on the server and mismatches are rejected.
+type AdminFeeMismatchError struct {
+	Expected float64
+	Provided float64
+}
+
+func (e *AdminFeeMismatchError) Error() string {
+	return fmt.Sprintf("admin fee %.2f does not match the computed admin fee %.2f", e.Provided, e.Expected)
+}
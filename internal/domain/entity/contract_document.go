@@ -0,0 +1,42 @@
+package entity
+
+import (
+	"time"
+)
+
+// ContractTemplate is one version of the credit agreement text, written as a
+// Go text/template. Versions are never edited; the newest active one is used
+// for new documents.
+type ContractTemplate struct {
+	ID        uint64    `json:"id" gorm:"primaryKey;autoIncrement"`
+	Version   int       `json:"version" gorm:"not null;uniqueIndex"`
+	Name      string    `json:"name" gorm:"type:varchar(255);not null"`
+	Body      string    `json:"body" gorm:"type:text;not null"`
+	IsActive  bool      `json:"is_active" gorm:"default:true;index"`
+	CreatedBy uint64    `json:"created_by"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+func (ContractTemplate) TableName() string {
+	return "contract_templates"
+}
+
+// ContractDocument is the rendered agreement of a transaction. The hash is
+// taken over the stored bytes so a download can be checked against it.
+type ContractDocument struct {
+	ID              uint64    `json:"id" gorm:"primaryKey;autoIncrement"`
+	TransactionID   uint64    `json:"transaction_id" gorm:"not null;uniqueIndex"`
+	TemplateID      uint64    `json:"template_id" gorm:"not null;index"`
+	TemplateVersion int       `json:"template_version" gorm:"not null"`
+	FileName        string    `json:"file_name" gorm:"type:varchar(255);not null"`
+	ContentType     string    `json:"content_type" gorm:"type:varchar(100);not null"`
+	Content         []byte    `json:"-" gorm:"type:longblob;not null"`
+	SHA256          string    `json:"sha256" gorm:"type:char(64);not null"`
+	Size            int64     `json:"size" gorm:"not null"`
+	GeneratedAt     time.Time `json:"generated_at" gorm:"not null"`
+	CreatedAt       time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+func (ContractDocument) TableName() string {
+	return "contract_documents"
+}
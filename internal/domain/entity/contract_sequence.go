@@ -0,0 +1,16 @@
+package entity
+
+import (
+	"time"
+)
+
+// ContractSequence holds the last number issued in one contract number scope.
+type ContractSequence struct {
+	Scope     string    `json:"scope" gorm:"primaryKey;type:varchar(100)"`
+	Value     uint64    `json:"value" gorm:"not null;default:0"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+func (ContractSequence) TableName() string {
+	return "contract_sequences"
+}
@@ -36,22 +36,110 @@ func (u *User) IsCustomer() bool {
 }
 
 type Customer struct {
-	ID              uint64     `json:"id" gorm:"primaryKey;autoIncrement"`
-	UserID          uint64     `json:"user_id" gorm:"not null;unique;index"`
-	NIK             string     `json:"nik" gorm:"type:varchar(16);unique;not null;index"`
-	FullName        string     `json:"full_name" gorm:"type:varchar(255);not null;index:idx_full_name,length:100"`
-	LegalName       string     `json:"legal_name" gorm:"type:varchar(255);not null"`
-	BirthPlace      string     `json:"birth_place" gorm:"type:varchar(255);not null"`
-	BirthDate       time.Time  `json:"birth_date" gorm:"type:date;not null"`
-	Salary          float64    `json:"salary" gorm:"type:decimal(15,2);not null"`
-	KTPPhotoPath    string     `json:"ktp_photo_path" gorm:"type:varchar(500)"`
-	SelfiePhotoPath string     `json:"selfie_photo_path" gorm:"type:varchar(500)"`
-	CreatedAt       time.Time  `json:"created_at" gorm:"autoCreateTime"`
-	UpdatedAt       time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
-	DeletedAt       *time.Time `json:"deleted_at" gorm:"index"`
-	User            User       `json:"user" gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	ID               uint64     `json:"id" gorm:"primaryKey;autoIncrement"`
+	UserID           uint64     `json:"user_id" gorm:"not null;unique;index"`
+	NIK              string     `json:"nik" gorm:"type:varchar(16);unique;not null;index"`
+	FullName         string     `json:"full_name" gorm:"type:varchar(255);not null;index:idx_full_name,length:100"`
+	LegalName        string     `json:"legal_name" gorm:"type:varchar(255);not null"`
+	BirthPlace       string     `json:"birth_place" gorm:"type:varchar(255);not null"`
+	BirthDate        time.Time  `json:"birth_date" gorm:"type:date;not null"`
+	Salary           float64    `json:"salary" gorm:"type:decimal(15,2);not null"`
+	Sex              Sex        `json:"sex" gorm:"type:varchar(10);index"` // sex and region codes are decoded from the NIK
+	ProvinceCode     string     `json:"province_code" gorm:"type:char(2);index"`
+	RegencyCode      string     `json:"regency_code" gorm:"type:char(4);index"`
+	DistrictCode     string     `json:"district_code" gorm:"type:char(6)"`
+	KTPPhotoPath     string     `json:"ktp_photo_path" gorm:"type:varchar(500)"`
+	SelfiePhotoPath  string     `json:"selfie_photo_path" gorm:"type:varchar(500)"`
+	KTPObjectKey     string     `json:"ktp_object_key" gorm:"type:varchar(255)"` // key in the file storage
+	KTPChecksum      string     `json:"ktp_checksum" gorm:"type:char(64)"`
+	KTPUploadedAt    *time.Time `json:"ktp_uploaded_at"`
+	SelfieObjectKey  string     `json:"selfie_object_key" gorm:"type:varchar(255)"`
+	SelfieChecksum   string     `json:"selfie_checksum" gorm:"type:char(64)"`
+	SelfieUploadedAt *time.Time `json:"selfie_uploaded_at"`
+	KYCStatus        KYCStatus  `json:"kyc_status" gorm:"type:varchar(20);not null;default:UNVERIFIED;index"`
+	KYCSubmittedAt   *time.Time `json:"kyc_submitted_at"`
+	KYCReviewReason  string     `json:"kyc_review_reason" gorm:"type:varchar(500)"`
+	KYCReviewedBy    *uint64    `json:"kyc_reviewed_by"`
+	KYCReviewedAt    *time.Time `json:"kyc_reviewed_at"`
+	CreditBalance    float64    `json:"credit_balance" gorm:"type:decimal(15,2);default:0"`
+	CreatedAt        time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt        time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+	DeletedAt        *time.Time `json:"deleted_at" gorm:"index"`
+	User             User       `json:"user" gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
 }
 
 func (Customer) TableName() string {
 	return "customers"
 }
+
+// KYCStatus tracks the identity check. Customers start UNVERIFIED, move to
+// PENDING_REVIEW once both KYC images are uploaded and are then approved or
+// rejected by an admin; only VERIFIED customers may take up financing.
+type KYCStatus string
+
+const (
+	KYCUnverified    KYCStatus = "UNVERIFIED"
+	KYCPendingReview KYCStatus = "PENDING_REVIEW"
+	KYCVerified      KYCStatus = "VERIFIED"
+	KYCRejected      KYCStatus = "REJECTED"
+)
+
+func (s KYCStatus) IsValid() bool {
+	switch s {
+	case KYCUnverified, KYCPendingReview, KYCVerified, KYCRejected:
+		return true
+	}
+	return false
+}
+
+func (c *Customer) IsKYCVerified() bool {
+	return c.KYCStatus == KYCVerified
+}
+
+// HasKYCDocuments reports whether both the KTP and the selfie are uploaded.
+func (c *Customer) HasKYCDocuments() bool {
+	return c.KTPObjectKey != "" && c.SelfieObjectKey != ""
+}
+
+type KYCDocumentType string
+
+const (
+	KYCDocumentKTP    KYCDocumentType = "KTP"
+	KYCDocumentSelfie KYCDocumentType = "SELFIE"
+)
+
+func (t KYCDocumentType) IsValid() bool {
+	return t == KYCDocumentKTP || t == KYCDocumentSelfie
+}
+
+// KYCDocument returns the stored object key, checksum and upload time of a
+// KYC image; the key is empty when nothing was uploaded.
+func (c *Customer) KYCDocument(docType KYCDocumentType) (string, string, *time.Time) {
+	switch docType {
+	case KYCDocumentKTP:
+		return c.KTPObjectKey, c.KTPChecksum, c.KTPUploadedAt
+	case KYCDocumentSelfie:
+		return c.SelfieObjectKey, c.SelfieChecksum, c.SelfieUploadedAt
+	}
+	return "", "", nil
+}
+
+// SetKYCDocument records an uploaded KYC image. The photo path follows the
+// object key so older clients reading it keep seeing the current image.
+func (c *Customer) SetKYCDocument(docType KYCDocumentType, key, checksum string, uploadedAt time.Time) {
+	switch docType {
+	case KYCDocumentKTP:
+		c.KTPObjectKey, c.KTPChecksum, c.KTPUploadedAt = key, checksum, &uploadedAt
+		c.KTPPhotoPath = key
+	case KYCDocumentSelfie:
+		c.SelfieObjectKey, c.SelfieChecksum, c.SelfieUploadedAt = key, checksum, &uploadedAt
+		c.SelfiePhotoPath = key
+	}
+}
+
+type Sex string
+
+const (
+	SexMale   Sex = "MALE"
+	SexFemale Sex = "FEMALE"
+)
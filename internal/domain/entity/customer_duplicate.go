@@ -0,0 +1,40 @@
+package entity
+
+import (
+	"time"
+)
+
+type DuplicateStatus string
+
+const (
+	DuplicateOpen      DuplicateStatus = "OPEN"
+	DuplicateConfirmed DuplicateStatus = "CONFIRMED" // the same person registered twice
+	DuplicateDismissed DuplicateStatus = "DISMISSED" // different people
+)
+
+// CustomerDuplicate flags a newly registered customer as likely the same
+// person as an existing one. Open and confirmed flags hold the new customer's
+// KYC approval; dismissing a flag releases it.
+type CustomerDuplicate struct {
+	ID                uint64          `json:"id" gorm:"primaryKey;autoIncrement"`
+	CustomerID        uint64          `json:"customer_id" gorm:"not null;uniqueIndex:idx_duplicate_pair"` // the customer just registered
+	MatchedCustomerID uint64          `json:"matched_customer_id" gorm:"not null;uniqueIndex:idx_duplicate_pair;index"`
+	Score             float64         `json:"score" gorm:"type:decimal(5,4);not null"`
+	NameSimilarity    float64         `json:"name_similarity" gorm:"type:decimal(5,4);not null"`
+	BirthDateMatch    bool            `json:"birth_date_match"`
+	BirthPlaceMatch   bool            `json:"birth_place_match"`
+	NIKDistance       int             `json:"nik_distance"`
+	Reasons           string          `json:"reasons" gorm:"type:varchar(500)"`
+	Status            DuplicateStatus `json:"status" gorm:"type:varchar(20);not null;default:OPEN;index"`
+	ResolutionNote    string          `json:"resolution_note" gorm:"type:varchar(500)"`
+	ResolvedBy        *uint64         `json:"resolved_by"`
+	ResolvedAt        *time.Time      `json:"resolved_at"`
+	CreatedAt         time.Time       `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt         time.Time       `json:"updated_at" gorm:"autoUpdateTime"`
+	Customer          Customer        `json:"customer" gorm:"foreignKey:CustomerID"`
+	MatchedCustomer   Customer        `json:"matched_customer" gorm:"foreignKey:MatchedCustomerID"`
+}
+
+func (CustomerDuplicate) TableName() string {
+	return "customer_duplicates"
+}
@@ -0,0 +1,66 @@
+package entity
+
+import (
+	"time"
+)
+
+type InstallmentStatus string
+
+const (
+	InstallmentUnpaid        InstallmentStatus = "UNPAID"
+	InstallmentPartiallyPaid InstallmentStatus = "PARTIALLY_PAID"
+	InstallmentPaid          InstallmentStatus = "PAID"
+)
+
+type InstallmentSchedule struct {
+	ID                uint64            `json:"id" gorm:"primaryKey;autoIncrement"`
+	TransactionID     uint64            `json:"transaction_id" gorm:"not null;uniqueIndex:idx_transaction_installment"`
+	InstallmentNumber int               `json:"installment_number" gorm:"not null;uniqueIndex:idx_transaction_installment"`
+	DueDate           time.Time         `json:"due_date" gorm:"type:date;not null;index"`
+	PrincipalAmount   float64           `json:"principal_amount" gorm:"type:decimal(15,2);not null"`
+	InterestAmount    float64           `json:"interest_amount" gorm:"type:decimal(15,2);not null"`
+	AdminFeeAmount    float64           `json:"admin_fee_amount" gorm:"type:decimal(15,2);not null"`
+	AmountDue         float64           `json:"amount_due" gorm:"type:decimal(15,2);not null"`
+	PenaltyAmount     float64           `json:"penalty_amount" gorm:"type:decimal(15,2);default:0"`
+	DaysPastDue       int               `json:"days_past_due" gorm:"default:0"`
+	PrincipalPaid     float64           `json:"principal_paid" gorm:"type:decimal(15,2);default:0"`
+	InterestPaid      float64           `json:"interest_paid" gorm:"type:decimal(15,2);default:0"`
+	AdminFeePaid      float64           `json:"admin_fee_paid" gorm:"type:decimal(15,2);default:0"`
+	PenaltyPaid       float64           `json:"penalty_paid" gorm:"type:decimal(15,2);default:0"`
+	Status            InstallmentStatus `json:"status" gorm:"type:varchar(20);not null;default:UNPAID;index"`
+	PaidAt            *time.Time        `json:"paid_at"`
+	CreatedAt         time.Time         `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt         time.Time         `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+func (InstallmentSchedule) TableName() string {
+	return "installment_schedules"
+}
+
+// OutstandingFor returns the unpaid amount of a single component.
+func (i *InstallmentSchedule) OutstandingFor(component PaymentComponent) float64 {
+	switch component {
+	case ComponentPenalty:
+		return i.PenaltyAmount - i.PenaltyPaid
+	case ComponentInterest:
+		return i.InterestAmount - i.InterestPaid
+	case ComponentAdminFee:
+		return i.AdminFeeAmount - i.AdminFeePaid
+	case ComponentPrincipal:
+		return i.PrincipalAmount - i.PrincipalPaid
+	}
+	return 0
+}
+
+// Outstanding returns the total unpaid amount including penalties.
+func (i *InstallmentSchedule) Outstanding() float64 {
+	return i.AmountDue + i.PenaltyAmount - i.TotalPaid()
+}
+
+func (i *InstallmentSchedule) TotalPaid() float64 {
+	return i.PrincipalPaid + i.InterestPaid + i.AdminFeePaid + i.PenaltyPaid
+}
+
+func (i *InstallmentSchedule) IsOpen() bool {
+	return i.Status != InstallmentPaid
+}
@@ -0,0 +1,22 @@
+package entity
+
+import (
+	"time"
+)
+
+// LimitChange records one change of a customer's limit amount for a tenor.
+type LimitChange struct {
+	ID              uint64    `json:"id" gorm:"primaryKey;autoIncrement"`
+	CustomerLimitID uint64    `json:"customer_limit_id" gorm:"not null;index"`
+	CustomerID      uint64    `json:"customer_id" gorm:"not null;index:idx_limit_change_customer_tenor"`
+	TenorMonths     int       `json:"tenor_months" gorm:"not null;index:idx_limit_change_customer_tenor"`
+	OldLimitAmount  float64   `json:"old_limit_amount" gorm:"type:decimal(15,2);not null"`
+	NewLimitAmount  float64   `json:"new_limit_amount" gorm:"type:decimal(15,2);not null"`
+	ChangedBy       uint64    `json:"changed_by" gorm:"not null"`
+	Reason          string    `json:"reason" gorm:"type:varchar(500);not null"`
+	CreatedAt       time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+func (LimitChange) TableName() string {
+	return "limit_changes"
+}
@@ -0,0 +1,39 @@
+package entity
+
+import (
+	"time"
+)
+
+type LimitChangeRequestStatus string
+
+const (
+	LimitRequestPending  LimitChangeRequestStatus = "PENDING"
+	LimitRequestApproved LimitChangeRequestStatus = "APPROVED"
+	LimitRequestRejected LimitChangeRequestStatus = "REJECTED"
+)
+
+// LimitChangeRequest is a customer's request for a higher limit on one tenor,
+// waiting for an admin to approve or reject it.
+type LimitChangeRequest struct {
+	ID                   uint64                   `json:"id" gorm:"primaryKey;autoIncrement"`
+	CustomerID           uint64                   `json:"customer_id" gorm:"not null;index"`
+	TenorMonths          int                      `json:"tenor_months" gorm:"not null"`
+	CurrentLimitAmount   float64                  `json:"current_limit_amount" gorm:"type:decimal(15,2);not null"`
+	RequestedLimitAmount float64                  `json:"requested_limit_amount" gorm:"type:decimal(15,2);not null"`
+	ApprovedLimitAmount  float64                  `json:"approved_limit_amount" gorm:"type:decimal(15,2);default:0"`
+	UpdatedSalary        *float64                 `json:"updated_salary" gorm:"type:decimal(15,2)"`
+	DocumentKey          string                   `json:"document_key" gorm:"type:varchar(255)"`
+	DocumentChecksum     string                   `json:"document_checksum" gorm:"type:varchar(64)"` // SHA-256, hex
+	DocumentUploadedAt   *time.Time               `json:"document_uploaded_at"`
+	CustomerNote         string                   `json:"customer_note" gorm:"type:varchar(500)"`
+	Status               LimitChangeRequestStatus `json:"status" gorm:"type:varchar(20);default:PENDING;index"`
+	ReviewReason         string                   `json:"review_reason" gorm:"type:varchar(500)"`
+	ReviewedBy           *uint64                  `json:"reviewed_by"`
+	ReviewedAt           *time.Time               `json:"reviewed_at"`
+	CreatedAt            time.Time                `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt            time.Time                `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+func (LimitChangeRequest) TableName() string {
+	return "limit_change_requests"
+}
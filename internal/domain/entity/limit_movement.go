@@ -0,0 +1,36 @@
+package entity
+
+import (
+	"time"
+)
+
+type LimitMovementType string
+
+const (
+	MovementReserve          LimitMovementType = "RESERVE"
+	MovementRelease          LimitMovementType = "RELEASE"
+	MovementRepaymentRestore LimitMovementType = "REPAYMENT_RESTORE"
+	MovementManualAdjustment LimitMovementType = "MANUAL_ADJUSTMENT"
+)
+
+// LimitMovement is one append-only entry of the limit usage ledger. Amount is
+// the signed change to the used amount; the sum of all entries for a limit is
+// its used amount.
+type LimitMovement struct {
+	ID              uint64            `json:"id" gorm:"primaryKey;autoIncrement"`
+	CustomerLimitID uint64            `json:"customer_limit_id" gorm:"not null;index"`
+	CustomerID      uint64            `json:"customer_id" gorm:"not null;index:idx_limit_movement_customer_tenor"`
+	TenorMonths     int               `json:"tenor_months" gorm:"not null;index:idx_limit_movement_customer_tenor"`
+	Type            LimitMovementType `json:"type" gorm:"type:varchar(30);not null"`
+	Amount          float64           `json:"amount" gorm:"type:decimal(15,2);not null"`
+	UsedAmountAfter float64           `json:"used_amount_after" gorm:"type:decimal(15,2);not null"`
+	TransactionID   *uint64           `json:"transaction_id" gorm:"index"`
+	PaymentID       *uint64           `json:"payment_id" gorm:"index"`
+	AdminID         *uint64           `json:"admin_id"`
+	Reason          string            `json:"reason" gorm:"type:varchar(500)"`
+	CreatedAt       time.Time         `json:"created_at" gorm:"autoCreateTime"`
+}
+
+func (LimitMovement) TableName() string {
+	return "limit_movements"
+}
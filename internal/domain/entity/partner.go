@@ -0,0 +1,99 @@
+package entity
+
+import (
+	"time"
+)
+
+type PartnerType string
+type PartnerStatus string
+
+const (
+	PartnerDealer   PartnerType = "DEALER"
+	PartnerMerchant PartnerType = "MERCHANT" // marketplace or online merchant
+)
+
+const (
+	PartnerActive     PartnerStatus = "ACTIVE"
+	PartnerSuspended  PartnerStatus = "SUSPENDED"
+	PartnerTerminated PartnerStatus = "TERMINATED"
+)
+
+// Partner is a dealer or merchant that originates contracts and is paid the
+// financed amount into its settlement account.
+type Partner struct {
+	ID                      uint64        `json:"id" gorm:"primaryKey;autoIncrement"`
+	Type                    PartnerType   `json:"type" gorm:"type:varchar(20);not null;index"`
+	Name                    string        `json:"name" gorm:"type:varchar(255);not null"`
+	SettlementBankName      string        `json:"settlement_bank_name" gorm:"type:varchar(100);not null"`
+	SettlementAccountNumber string        `json:"settlement_account_number" gorm:"type:varchar(50);not null"`
+	SettlementAccountName   string        `json:"settlement_account_name" gorm:"type:varchar(255);not null"`
+	Status                  PartnerStatus `json:"status" gorm:"type:varchar(20);not null;default:ACTIVE;index"`
+	CreatedAt               time.Time     `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt               time.Time     `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+func (Partner) TableName() string {
+	return "partners"
+}
+
+func (t PartnerType) IsValid() bool {
+	return t == PartnerDealer || t == PartnerMerchant
+}
+
+// TransactionSource is the source recorded on the contracts the partner
+// originates.
+func (t PartnerType) TransactionSource() TransactionSource {
+	if t == PartnerDealer {
+		return SourceDealer
+	}
+	return SourceEcommerce
+}
+
+func (s PartnerStatus) IsValid() bool {
+	switch s {
+	case PartnerActive, PartnerSuspended, PartnerTerminated:
+		return true
+	}
+	return false
+}
+
+// PartnerCredential is an API key and secret a partner authenticates with.
+// Only a SHA-256 hash of the secret is kept; the secret itself is shown once,
+// when the credential is issued.
+type PartnerCredential struct {
+	ID         uint64     `json:"id" gorm:"primaryKey;autoIncrement"`
+	PartnerID  uint64     `json:"partner_id" gorm:"not null;index"`
+	APIKey     string     `json:"api_key" gorm:"type:varchar(64);unique;not null"`
+	SecretHash string     `json:"-" gorm:"type:varchar(64);not null"`
+	ExpiresAt  *time.Time `json:"expires_at"` // set when the credential is rotated out, nil never expires
+	RevokedAt  *time.Time `json:"revoked_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	CreatedBy  *uint64    `json:"created_by"`
+	CreatedAt  time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	Partner    Partner    `json:"partner,omitempty" gorm:"foreignKey:PartnerID"`
+}
+
+func (PartnerCredential) TableName() string {
+	return "partner_credentials"
+}
+
+// IsActiveAt reports whether the credential is neither revoked nor expired at
+// the given time.
+func (c *PartnerCredential) IsActiveAt(at time.Time) bool {
+	return c.RevokedAt == nil && (c.ExpiresAt == nil || c.ExpiresAt.After(at))
+}
+
+// PartnerCustomer records that a customer consented to a partner originating
+// contracts on their behalf. Partners may only originate for linked customers.
+type PartnerCustomer struct {
+	ID         uint64    `json:"id" gorm:"primaryKey;autoIncrement"`
+	PartnerID  uint64    `json:"partner_id" gorm:"not null;uniqueIndex:idx_partner_customer"`
+	CustomerID uint64    `json:"customer_id" gorm:"not null;uniqueIndex:idx_partner_customer;index"`
+	LinkedBy   uint64    `json:"linked_by"` // the customer's own user, or the admin linking on their behalf
+	CreatedAt  time.Time `json:"created_at" gorm:"autoCreateTime"`
+	Partner    Partner   `json:"partner,omitempty" gorm:"foreignKey:PartnerID"`
+}
+
+func (PartnerCustomer) TableName() string {
+	return "partner_customers"
+}
@@ -0,0 +1,107 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+type PaymentComponent string
+
+const (
+	ComponentPenalty   PaymentComponent = "PENALTY"
+	ComponentInterest  PaymentComponent = "INTEREST"
+	ComponentAdminFee  PaymentComponent = "ADMIN_FEE"
+	ComponentPrincipal PaymentComponent = "PRINCIPAL"
+)
+
+// DefaultAllocationOrder is used when no allocation order is configured.
+var DefaultAllocationOrder = []PaymentComponent{
+	ComponentPenalty,
+	ComponentInterest,
+	ComponentAdminFee,
+	ComponentPrincipal,
+}
+
+type Payment struct {
+	ID                 uint64              `json:"id" gorm:"primaryKey;autoIncrement"`
+	TransactionID      uint64              `json:"transaction_id" gorm:"not null;index"`
+	CustomerID         uint64              `json:"customer_id" gorm:"not null;index"`
+	Amount             float64             `json:"amount" gorm:"type:decimal(15,2);not null"`
+	PaymentDate        time.Time           `json:"payment_date" gorm:"not null;index"`
+	PaymentMethod      string              `json:"payment_method" gorm:"type:varchar(50);not null"`
+	ReferenceNumber    string              `json:"reference_number" gorm:"type:varchar(100);index"`
+	AllocatedPenalty   float64             `json:"allocated_penalty" gorm:"type:decimal(15,2);default:0"`
+	AllocatedInterest  float64             `json:"allocated_interest" gorm:"type:decimal(15,2);default:0"`
+	AllocatedAdminFee  float64             `json:"allocated_admin_fee" gorm:"type:decimal(15,2);default:0"`
+	AllocatedPrincipal float64             `json:"allocated_principal" gorm:"type:decimal(15,2);default:0"`
+	ExcessAmount       float64             `json:"excess_amount" gorm:"type:decimal(15,2);default:0"`
+	LimitRestored      float64             `json:"limit_restored" gorm:"type:decimal(15,2);default:0"`
+	Notes              string              `json:"notes" gorm:"type:varchar(500)"`
+	RecordedBy         uint64              `json:"recorded_by" gorm:"not null"`
+	CreatedAt          time.Time           `json:"created_at" gorm:"autoCreateTime"`
+	Allocations        []PaymentAllocation `json:"allocations" gorm:"foreignKey:PaymentID;constraint:OnDelete:CASCADE"`
+}
+
+func (Payment) TableName() string {
+	return "payments"
+}
+
+type PaymentAllocation struct {
+	ID            uint64    `json:"id" gorm:"primaryKey;autoIncrement"`
+	PaymentID     uint64    `json:"payment_id" gorm:"not null;index"`
+	InstallmentID uint64    `json:"installment_id" gorm:"not null;index"`
+	Penalty       float64   `json:"penalty" gorm:"type:decimal(15,2);default:0"`
+	Interest      float64   `json:"interest" gorm:"type:decimal(15,2);default:0"`
+	AdminFee      float64   `json:"admin_fee" gorm:"type:decimal(15,2);default:0"`
+	Principal     float64   `json:"principal" gorm:"type:decimal(15,2);default:0"`
+	CreatedAt     time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+func (PaymentAllocation) TableName() string {
+	return "payment_allocations"
+}
+
+func (a *PaymentAllocation) Total() float64 {
+	return a.Penalty + a.Interest + a.AdminFee + a.Principal
+}
+
+// ParsePaymentComponents converts a configured allocation order into payment
+// components. Components that are not listed are appended in default order so
+// every outstanding amount can still be settled.
+func ParsePaymentComponents(order []string) ([]PaymentComponent, error) {
+	seen := make(map[PaymentComponent]bool)
+	components := make([]PaymentComponent, 0, len(DefaultAllocationOrder))
+
+	for _, value := range order {
+		component := PaymentComponent(strings.ToUpper(strings.TrimSpace(value)))
+		if component == "" {
+			continue
+		}
+
+		valid := false
+		for _, known := range DefaultAllocationOrder {
+			if component == known {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return nil, fmt.Errorf("invalid payment component %q in allocation order", value)
+		}
+
+		if seen[component] {
+			return nil, fmt.Errorf("duplicate payment component %q in allocation order", value)
+		}
+		seen[component] = true
+		components = append(components, component)
+	}
+
+	for _, component := range DefaultAllocationOrder {
+		if !seen[component] {
+			components = append(components, component)
+		}
+	}
+
+	return components, nil
+}
@@ -0,0 +1,88 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+)
+
+type AdminFeeType string
+
+const (
+	AdminFeeFlat       AdminFeeType = "FLAT"
+	AdminFeePercentage AdminFeeType = "PERCENTAGE"
+)
+
+// EligibilityCode tells API clients why an applicant was refused.
+type EligibilityCode string
+
+const (
+	EligibilityBelowMinAge       EligibilityCode = "AGE_BELOW_MINIMUM"
+	EligibilityAboveMaxAgeAtTerm EligibilityCode = "AGE_ABOVE_MAXIMUM_AT_TENOR_END"
+)
+
+// Product is a financing product from the catalog. It decides which tenors,
+// asset types and OTR amounts may be financed and at what price.
+type Product struct {
+	ID                 uint64       `json:"id" gorm:"primaryKey;autoIncrement"`
+	Code               string       `json:"code" gorm:"type:varchar(50);unique;not null"`
+	Name               string       `json:"name" gorm:"type:varchar(255);not null"`
+	Description        string       `json:"description" gorm:"type:varchar(500)"`
+	AllowedTenors      []int        `json:"allowed_tenors" gorm:"type:varchar(255);serializer:json;not null"`
+	InterestRate       float64      `json:"interest_rate" gorm:"type:decimal(7,4);not null"` // percent per year
+	AdminFeeType       AdminFeeType `json:"admin_fee_type" gorm:"type:varchar(20);not null"`
+	AdminFeeValue      float64      `json:"admin_fee_value" gorm:"type:decimal(15,4);not null"` // amount for FLAT, percent of OTR for PERCENTAGE
+	MinOTR             float64      `json:"min_otr" gorm:"type:decimal(15,2);default:0"`
+	MaxOTR             float64      `json:"max_otr" gorm:"type:decimal(15,2);default:0"` // 0 means no maximum
+	EligibleAssetTypes []AssetType  `json:"eligible_asset_types" gorm:"type:varchar(255);serializer:json;not null"`
+	MinAge             int          `json:"min_age" gorm:"default:0"`              // years at application, 0 means no minimum
+	MaxAgeAtTenorEnd   int          `json:"max_age_at_tenor_end" gorm:"default:0"` // years when the last installment falls due, 0 means no maximum
+	IsActive           bool         `json:"is_active" gorm:"index"`                // no column default, or GORM would skip an explicit false on insert
+	CreatedAt          time.Time    `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt          time.Time    `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+func (Product) TableName() string {
+	return "products"
+}
+
+func (p *Product) AllowsTenor(tenorMonths int) bool {
+	for _, tenor := range p.AllowedTenors {
+		if tenor == tenorMonths {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Product) AllowsAssetType(assetType AssetType) bool {
+	for _, eligible := range p.EligibleAssetTypes {
+		if eligible == assetType {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Product) AllowsOTR(amount float64) bool {
+	if amount < p.MinOTR {
+		return false
+	}
+	return p.MaxOTR <= 0 || amount <= p.MaxOTR
+}
+
+// AgeIneligibleError is returned when an applicant's age falls outside a
+// product's age rules.
+type AgeIneligibleError struct {
+	Code        EligibilityCode
+	ProductCode string
+	TenorMonths int
+	Age         int // at application, or at the end of the tenor for the maximum
+	Limit       int
+}
+
+func (e *AgeIneligibleError) Error() string {
+	if e.Code == EligibilityBelowMinAge {
+		return fmt.Sprintf("%s: applicant is %d, product %s requires at least %d", e.Code, e.Age, e.ProductCode, e.Limit)
+	}
+	return fmt.Sprintf("%s: applicant would be %d at the end of the %d month tenor, product %s allows at most %d", e.Code, e.Age, e.TenorMonths, e.ProductCode, e.Limit)
+}
@@ -0,0 +1,35 @@
+package entity
+
+import (
+	"time"
+)
+
+// RateCard prices one product, tenor and asset type for a period. Cards are
+// never edited once written; re-pricing adds a new card and closes the old one,
+// so every contract can be traced to the card it was priced with.
+type RateCard struct {
+	ID            uint64       `json:"id" gorm:"primaryKey;autoIncrement"`
+	ProductID     uint64       `json:"product_id" gorm:"not null;index:idx_rate_card_lookup"`
+	TenorMonths   int          `json:"tenor_months" gorm:"not null;index:idx_rate_card_lookup"`
+	AssetType     AssetType    `json:"asset_type" gorm:"type:varchar(20);not null;index:idx_rate_card_lookup"`
+	InterestRate  float64      `json:"interest_rate" gorm:"type:decimal(7,4);not null"` // percent per year
+	AdminFeeType  AdminFeeType `json:"admin_fee_type" gorm:"type:varchar(20);not null"`
+	AdminFeeValue float64      `json:"admin_fee_value" gorm:"type:decimal(15,4);not null"`
+	EffectiveFrom time.Time    `json:"effective_from" gorm:"not null;index:idx_rate_card_lookup"`
+	EffectiveTo   *time.Time   `json:"effective_to"` // nil means open-ended
+	CreatedBy     uint64       `json:"created_by"`
+	CreatedAt     time.Time    `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt     time.Time    `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+func (RateCard) TableName() string {
+	return "rate_cards"
+}
+
+// IsEffectiveAt reports whether the card prices contracts created at t.
+func (r *RateCard) IsEffectiveAt(t time.Time) bool {
+	if t.Before(r.EffectiveFrom) {
+		return false
+	}
+	return r.EffectiveTo == nil || t.Before(*r.EffectiveTo)
+}
@@ -1,12 +1,14 @@
 package entity
 
 import (
+	"fmt"
 	"time"
 )
 
 type TransactionStatus string
 type AssetType string
 type TransactionSource string
+type InterestMethod string
 
 const (
 	StatusPending   TransactionStatus = "PENDING"
@@ -29,19 +31,43 @@ const (
 	SourceDealer    TransactionSource = "DEALER"
 )
 
+const (
+	InterestFlat      InterestMethod = "FLAT"
+	InterestEffective InterestMethod = "EFFECTIVE"
+)
+
 type Transaction struct {
 	ID                uint64            `json:"id" gorm:"primaryKey;autoIncrement"`
 	ContractNumber    string            `json:"contract_number" gorm:"type:varchar(50);unique;not null;index"`
 	CustomerID        uint64            `json:"customer_id" gorm:"not null;index"`
+	ProductID         uint64            `json:"product_id" gorm:"index"`
+	RateCardID        *uint64           `json:"rate_card_id" gorm:"index"`
 	TenorMonths       int               `json:"tenor_months" gorm:"not null"`
 	OTRAmount         float64           `json:"otr_amount" gorm:"type:decimal(15,2);not null"`
+	DownPaymentAmount float64           `json:"down_payment_amount" gorm:"type:decimal(15,2);default:0"`
+	FinancedAmount    float64           `json:"financed_amount" gorm:"type:decimal(15,2);default:0"` // OTR minus down payment
 	AdminFee          float64           `json:"admin_fee" gorm:"type:decimal(15,2);not null"`
+	AdminFeeRuleID    *uint64           `json:"admin_fee_rule_id" gorm:"index"`
+	ClientAdminFee    *float64          `json:"client_admin_fee" gorm:"type:decimal(15,2)"` // fee sent by the client, kept for audit
 	InstallmentAmount float64           `json:"installment_amount" gorm:"type:decimal(15,2);not null"`
 	InterestAmount    float64           `json:"interest_amount" gorm:"type:decimal(15,2);not null"`
+	InterestMethod    InterestMethod    `json:"interest_method" gorm:"type:varchar(20)"`
+	InterestRate      float64           `json:"interest_rate" gorm:"type:decimal(7,4)"`
 	AssetName         string            `json:"asset_name" gorm:"type:varchar(255);not null"`
 	AssetType         AssetType         `json:"asset_type" gorm:"type:enum('WHITE_GOODS','MOTOR','MOBIL');not null;index"`
 	Status            TransactionStatus `json:"status" gorm:"type:enum('PENDING','APPROVED','REJECTED','ACTIVE','COMPLETED','DEFAULTED');default:PENDING;index"`
 	TransactionSource TransactionSource `json:"transaction_source" gorm:"type:enum('ECOMMERCE','WEB','DEALER');not null"`
+	PartnerID         *uint64           `json:"partner_id" gorm:"index"` // dealer or merchant that originated the contract, nil for direct applications
+	DaysPastDue       int               `json:"days_past_due" gorm:"default:0;index"`
+	AccruedPenalty    float64           `json:"accrued_penalty" gorm:"type:decimal(15,2);default:0"`
+	LimitRestored     float64           `json:"limit_restored" gorm:"type:decimal(15,2);default:0"`
+	DebtToIncomeRatio float64           `json:"debt_to_income_ratio" gorm:"type:decimal(7,4);default:0"` // installments on PENDING, APPROVED, ACTIVE and DEFAULTED contracts plus this one over salary, at creation
+	StatusReason      string            `json:"status_reason" gorm:"type:varchar(500)"`
+	ApprovedAt        *time.Time        `json:"approved_at"`
+	RejectedAt        *time.Time        `json:"rejected_at"`
+	ActivatedAt       *time.Time        `json:"activated_at"`
+	CompletedAt       *time.Time        `json:"completed_at"`
+	DefaultedAt       *time.Time        `json:"defaulted_at"`
 	CreatedAt         time.Time         `json:"created_at" gorm:"autoCreateTime;index"`
 	UpdatedAt         time.Time         `json:"updated_at" gorm:"autoUpdateTime"`
 	DeletedAt         *time.Time        `json:"deleted_at" gorm:"index"`
@@ -51,3 +77,78 @@ type Transaction struct {
 func (Transaction) TableName() string {
 	return "transactions"
 }
+
+// FinancedPrincipal is the amount lent and charged against the limit.
+// Contracts created before down payments were recorded financed the full OTR.
+func (t *Transaction) FinancedPrincipal() float64 {
+	if t.FinancedAmount > 0 {
+		return t.FinancedAmount
+	}
+	return t.OTRAmount
+}
+
+// HasBeenApproved reports whether the contract was approved and not rejected,
+// which is when a signed agreement exists for it.
+func (t *Transaction) HasBeenApproved() bool {
+	switch t.Status {
+	case StatusApproved, StatusActive, StatusCompleted, StatusDefaulted:
+		return true
+	}
+	return false
+}
+
+// transactionTransitions lists the statuses a contract may move to from each status.
+var transactionTransitions = map[TransactionStatus][]TransactionStatus{
+	StatusPending:   {StatusApproved, StatusRejected},
+	StatusApproved:  {StatusActive, StatusRejected},
+	StatusActive:    {StatusCompleted, StatusDefaulted},
+	StatusDefaulted: {StatusActive, StatusCompleted},
+	StatusRejected:  {},
+	StatusCompleted: {},
+}
+
+func (s TransactionStatus) IsValid() bool {
+	_, ok := transactionTransitions[s]
+	return ok
+}
+
+func (s TransactionStatus) AllowedTransitions() []TransactionStatus {
+	return transactionTransitions[s]
+}
+
+func (s TransactionStatus) CanTransitionTo(next TransactionStatus) bool {
+	for _, allowed := range transactionTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+type InvalidStatusTransitionError struct {
+	From    TransactionStatus
+	To      TransactionStatus
+	Allowed []TransactionStatus
+}
+
+func (e *InvalidStatusTransitionError) Error() string {
+	return fmt.Sprintf("transaction cannot move from %s to %s, allowed next statuses: %v", e.From, e.To, e.Allowed)
+}
+
+// AffordabilityError is returned when the installments a customer would pay
+// take more of their salary than allowed.
+type AffordabilityError struct {
+	Salary               float64
+	ExistingInstallments float64
+	NewInstallment       float64
+	Ratio                float64
+	MaxRatio             float64
+}
+
+func (e *AffordabilityError) Error() string {
+	if e.Salary <= 0 {
+		return "installments cannot be assessed without a salary"
+	}
+	return fmt.Sprintf("debt-to-income ratio %.2f%% exceeds the maximum %.2f%%: installments %.2f existing plus %.2f new on a salary of %.2f",
+		e.Ratio*100, e.MaxRatio*100, e.ExistingInstallments, e.NewInstallment, e.Salary)
+}
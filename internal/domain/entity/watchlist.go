@@ -0,0 +1,80 @@
+package entity
+
+import (
+	"time"
+)
+
+// Watchlist lists a NIK that must not be onboarded or financed without a
+// check, e.g. a known fraudster or a customer written off before.
+type Watchlist struct {
+	ID        uint64     `json:"id" gorm:"primaryKey;autoIncrement"`
+	NIK       string     `json:"nik" gorm:"type:varchar(16);not null;index"`
+	Name      string     `json:"name" gorm:"type:varchar(255)"`
+	Reason    string     `json:"reason" gorm:"type:varchar(500);not null"`
+	Source    string     `json:"source" gorm:"type:varchar(100);not null"` // e.g. INTERNAL_WRITE_OFF, SLIK, POLICE_REPORT
+	ExpiresAt *time.Time `json:"expires_at" gorm:"index"`                  // nil never expires
+	CreatedBy *uint64    `json:"created_by"`
+	CreatedAt time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+func (Watchlist) TableName() string {
+	return "watchlists"
+}
+
+// IsActiveAt reports whether the entry has not expired at the given time.
+func (w *Watchlist) IsActiveAt(at time.Time) bool {
+	return w.ExpiresAt == nil || w.ExpiresAt.After(at)
+}
+
+type ScreeningStage string
+
+const (
+	ScreeningRegistration     ScreeningStage = "REGISTRATION"
+	ScreeningCustomerCreation ScreeningStage = "CUSTOMER_CREATION"
+	ScreeningTransaction      ScreeningStage = "TRANSACTION"
+)
+
+type WatchlistAction string
+
+const (
+	WatchlistBlock  WatchlistAction = "BLOCK"
+	WatchlistReview WatchlistAction = "REVIEW"
+)
+
+type WatchlistHitStatus string
+
+const (
+	WatchlistHitBlocked   WatchlistHitStatus = "BLOCKED" // the application was refused, nothing to review
+	WatchlistHitOpen      WatchlistHitStatus = "OPEN"
+	WatchlistHitCleared   WatchlistHitStatus = "CLEARED"
+	WatchlistHitConfirmed WatchlistHitStatus = "CONFIRMED"
+)
+
+// WatchlistHit records every time screening matched a watchlist entry. Hits
+// sent to manual review hold the customer's KYC approval and the
+// transaction's approval until an admin clears them.
+type WatchlistHit struct {
+	ID             uint64             `json:"id" gorm:"primaryKey;autoIncrement"`
+	WatchlistID    uint64             `json:"watchlist_id" gorm:"not null;index"`
+	NIK            string             `json:"nik" gorm:"type:varchar(16);not null;index"`
+	Stage          ScreeningStage     `json:"stage" gorm:"type:varchar(30);not null"`
+	Action         WatchlistAction    `json:"action" gorm:"type:varchar(10);not null"`
+	CustomerID     *uint64            `json:"customer_id" gorm:"index"`
+	TransactionID  *uint64            `json:"transaction_id" gorm:"index"`
+	Status         WatchlistHitStatus `json:"status" gorm:"type:varchar(20);not null;index"`
+	ResolutionNote string             `json:"resolution_note" gorm:"type:varchar(500)"`
+	ResolvedBy     *uint64            `json:"resolved_by"`
+	ResolvedAt     *time.Time         `json:"resolved_at"`
+	CreatedAt      time.Time          `json:"created_at" gorm:"autoCreateTime"`
+	Watchlist      Watchlist          `json:"watchlist" gorm:"foreignKey:WatchlistID"`
+}
+
+func (WatchlistHit) TableName() string {
+	return "watchlist_hits"
+}
+
+// Holds reports whether the hit still blocks approval.
+func (h *WatchlistHit) Holds() bool {
+	return h.Status == WatchlistHitOpen || h.Status == WatchlistHitConfirmed
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+	"pt-xyz-multifinance/internal/domain/entity"
+)
+
+type AdminFeeRuleRepository interface {
+	Create(ctx context.Context, rule *entity.AdminFeeRule) error
+	GetByID(ctx context.Context, id uint64) (*entity.AdminFeeRule, error)
+	GetAll(ctx context.Context) ([]*entity.AdminFeeRule, error)
+	GetActive(ctx context.Context) ([]*entity.AdminFeeRule, error)
+	// Supersede deactivates the rule previousID and creates rule in its place,
+	// leaving the old rule intact for the contracts priced by it.
+	Supersede(ctx context.Context, previousID uint64, rule *entity.AdminFeeRule) error
+	Deactivate(ctx context.Context, id uint64) error
+}
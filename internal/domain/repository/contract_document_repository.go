@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+	"pt-xyz-multifinance/internal/domain/entity"
+)
+
+type ContractTemplateRepository interface {
+	Create(ctx context.Context, template *entity.ContractTemplate) error
+	GetByID(ctx context.Context, id uint64) (*entity.ContractTemplate, error)
+	GetAll(ctx context.Context) ([]*entity.ContractTemplate, error)
+	// GetLatestActive returns nil, nil when no template is active
+	GetLatestActive(ctx context.Context) (*entity.ContractTemplate, error)
+	Update(ctx context.Context, template *entity.ContractTemplate) error
+}
+
+type ContractDocumentRepository interface {
+	Create(ctx context.Context, document *entity.ContractDocument) error
+	// GetByTransactionID returns nil, nil when no document was generated yet
+	GetByTransactionID(ctx context.Context, transactionID uint64) (*entity.ContractDocument, error)
+}
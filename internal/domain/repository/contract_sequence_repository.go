@@ -0,0 +1,10 @@
+package repository
+
+import (
+	"context"
+)
+
+type ContractSequenceRepository interface {
+	// Next increments the sequence of the scope and returns the new value.
+	Next(ctx context.Context, scope string) (uint64, error)
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+	"pt-xyz-multifinance/internal/domain/entity"
+)
+
+type CustomerDuplicateRepository interface {
+	Create(ctx context.Context, duplicate *entity.CustomerDuplicate) error
+	GetByID(ctx context.Context, id uint64) (*entity.CustomerDuplicate, error)
+	GetByPair(ctx context.Context, customerID, matchedCustomerID uint64) (*entity.CustomerDuplicate, error)
+	GetByStatus(ctx context.Context, status entity.DuplicateStatus) ([]*entity.CustomerDuplicate, error)
+	CountHoldsByCustomer(ctx context.Context, customerID uint64) (int64, error)
+	Update(ctx context.Context, duplicate *entity.CustomerDuplicate) error
+}
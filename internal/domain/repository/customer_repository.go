@@ -8,9 +8,13 @@ import (
 type CustomerRepository interface {
 	Create(ctx context.Context, customer *entity.Customer) error
 	GetByID(ctx context.Context, id uint64) (*entity.Customer, error)
+	GetByIDForUpdate(ctx context.Context, id uint64) (*entity.Customer, error)
 	GetByUserID(ctx context.Context, userID uint64) (*entity.Customer, error)
 	GetByNIK(ctx context.Context, nik string) (*entity.Customer, error)
 	Update(ctx context.Context, customer *entity.Customer) error
 	Delete(ctx context.Context, id uint64) error
 	GetAll(ctx context.Context, limit, offset int) ([]*entity.Customer, error)
+	AddCreditBalance(ctx context.Context, id uint64, amount float64) error
+	GetByKYCStatus(ctx context.Context, status entity.KYCStatus, limit, offset int) ([]*entity.Customer, error)
+	GetDuplicateCandidates(ctx context.Context, customer *entity.Customer, limit int) ([]*entity.Customer, error)
 }
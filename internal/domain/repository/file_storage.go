@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// FileStorage keeps uploaded files under opaque object keys and hands them out
+// through signed, time-limited URLs.
+type FileStorage interface {
+	Save(ctx context.Context, key string, content io.Reader) (int64, error)
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+	SignedURL(key string, ttl time.Duration) (string, time.Time, error)
+	VerifySignature(key string, expires int64, signature string) error
+}
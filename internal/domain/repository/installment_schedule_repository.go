@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+	"pt-xyz-multifinance/internal/domain/entity"
+)
+
+type InstallmentScheduleRepository interface {
+	CreateBatch(ctx context.Context, installments []*entity.InstallmentSchedule) error
+	GetByTransactionID(ctx context.Context, transactionID uint64) ([]*entity.InstallmentSchedule, error)
+	GetByTransactionIDForUpdate(ctx context.Context, transactionID uint64) ([]*entity.InstallmentSchedule, error)
+	Update(ctx context.Context, installment *entity.InstallmentSchedule) error
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+	"pt-xyz-multifinance/internal/domain/entity"
+)
+
+type LimitChangeRequestRepository interface {
+	Create(ctx context.Context, request *entity.LimitChangeRequest) error
+	GetByID(ctx context.Context, id uint64) (*entity.LimitChangeRequest, error)
+	GetByIDForUpdate(ctx context.Context, id uint64) (*entity.LimitChangeRequest, error)
+	GetByCustomerID(ctx context.Context, customerID uint64) ([]*entity.LimitChangeRequest, error)
+	GetByStatus(ctx context.Context, status entity.LimitChangeRequestStatus, limit, offset int) ([]*entity.LimitChangeRequest, error)
+	Update(ctx context.Context, request *entity.LimitChangeRequest) error
+}
@@ -8,8 +8,16 @@ import (
 type LimitRepository interface {
 	Create(ctx context.Context, limit *entity.CustomerLimit) error
 	GetByCustomerAndTenor(ctx context.Context, customerID uint64, tenorMonths int) (*entity.CustomerLimit, error)
+	GetByCustomerAndTenorForUpdate(ctx context.Context, customerID uint64, tenorMonths int) (*entity.CustomerLimit, error)
 	GetByCustomerID(ctx context.Context, customerID uint64) ([]*entity.CustomerLimit, error)
 	Update(ctx context.Context, limit *entity.CustomerLimit) error
-	UpdateUsedAmount(ctx context.Context, customerID uint64, tenorMonths int, amount float64) error
+	// UpdateUsedAmount applies movement.Amount to the used amount of the
+	// movement's customer and tenor and appends the movement to the ledger.
+	UpdateUsedAmount(ctx context.Context, movement *entity.LimitMovement) error
+	GetMovements(ctx context.Context, customerID uint64, tenorMonths int) ([]*entity.LimitMovement, error)
+	// UpdateLimitAmount sets the limit amount to change.NewLimitAmount, filling
+	// in the old amount, and stores the change in the limit history.
+	UpdateLimitAmount(ctx context.Context, change *entity.LimitChange) error
+	GetLimitChanges(ctx context.Context, customerID uint64, tenorMonths int) ([]*entity.LimitChange, error)
 	Delete(ctx context.Context, id uint64) error
 }
@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+	"pt-xyz-multifinance/internal/domain/entity"
+	"time"
+)
+
+type PartnerRepository interface {
+	Create(ctx context.Context, partner *entity.Partner) error
+	GetByID(ctx context.Context, id uint64) (*entity.Partner, error)
+	GetAll(ctx context.Context, limit, offset int) ([]*entity.Partner, error)
+	Update(ctx context.Context, partner *entity.Partner) error
+	// LinkCustomer is a no-op when the customer is already linked.
+	LinkCustomer(ctx context.Context, link *entity.PartnerCustomer) error
+	UnlinkCustomer(ctx context.Context, partnerID, customerID uint64) error
+	IsCustomerLinked(ctx context.Context, partnerID, customerID uint64) (bool, error)
+	GetCustomerLinks(ctx context.Context, customerID uint64) ([]*entity.PartnerCustomer, error)
+}
+
+type PartnerCredentialRepository interface {
+	Create(ctx context.Context, credential *entity.PartnerCredential) error
+	GetByID(ctx context.Context, id uint64) (*entity.PartnerCredential, error)
+	GetByAPIKey(ctx context.Context, apiKey string) (*entity.PartnerCredential, error)
+	GetByPartnerID(ctx context.Context, partnerID uint64) ([]*entity.PartnerCredential, error)
+	ExpireActive(ctx context.Context, partnerID uint64, expiresAt time.Time) error
+	Update(ctx context.Context, credential *entity.PartnerCredential) error
+	TouchLastUsed(ctx context.Context, id uint64, at time.Time) error
+}
@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"context"
+	"pt-xyz-multifinance/internal/domain/entity"
+)
+
+type PaymentRepository interface {
+	Create(ctx context.Context, payment *entity.Payment) error
+	GetByID(ctx context.Context, id uint64) (*entity.Payment, error)
+	GetByTransactionID(ctx context.Context, transactionID uint64) ([]*entity.Payment, error)
+}
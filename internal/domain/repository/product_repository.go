@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"pt-xyz-multifinance/internal/domain/entity"
+)
+
+type ProductRepository interface {
+	Create(ctx context.Context, product *entity.Product) error
+	GetByID(ctx context.Context, id uint64) (*entity.Product, error)
+	GetByCode(ctx context.Context, code string) (*entity.Product, error)
+	GetAll(ctx context.Context) ([]*entity.Product, error)
+	GetActive(ctx context.Context) ([]*entity.Product, error)
+	Update(ctx context.Context, product *entity.Product) error
+	Delete(ctx context.Context, id uint64) error
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"pt-xyz-multifinance/internal/domain/entity"
+	"time"
+)
+
+type RateCardRepository interface {
+	// CreateVersion adds a card, closing the open-ended card it supersedes.
+	CreateVersion(ctx context.Context, card *entity.RateCard) error
+	GetByID(ctx context.Context, id uint64) (*entity.RateCard, error)
+	GetByProductID(ctx context.Context, productID uint64) ([]*entity.RateCard, error)
+	// GetEffective returns the card in force at the given time, or nil if none is.
+	GetEffective(ctx context.Context, productID uint64, tenorMonths int, assetType entity.AssetType, at time.Time) (*entity.RateCard, error)
+}
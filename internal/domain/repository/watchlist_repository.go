@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+	"pt-xyz-multifinance/internal/domain/entity"
+	"time"
+)
+
+type WatchlistRepository interface {
+	Create(ctx context.Context, entry *entity.Watchlist) error
+	GetByID(ctx context.Context, id uint64) (*entity.Watchlist, error)
+	GetAll(ctx context.Context, limit, offset int) ([]*entity.Watchlist, error)
+	GetByNIKAndSource(ctx context.Context, nik, source string) (*entity.Watchlist, error)
+	GetActiveByNIK(ctx context.Context, nik string, at time.Time) ([]*entity.Watchlist, error)
+	Update(ctx context.Context, entry *entity.Watchlist) error
+	Delete(ctx context.Context, id uint64) error
+}
+
+type WatchlistHitRepository interface {
+	Create(ctx context.Context, hit *entity.WatchlistHit) error
+	GetByID(ctx context.Context, id uint64) (*entity.WatchlistHit, error)
+	GetByStatus(ctx context.Context, status entity.WatchlistHitStatus, limit, offset int) ([]*entity.WatchlistHit, error)
+	CountHoldsByCustomer(ctx context.Context, customerID uint64) (int64, error)
+	CountHoldsByTransaction(ctx context.Context, transactionID uint64) (int64, error)
+	Update(ctx context.Context, hit *entity.WatchlistHit) error
+}
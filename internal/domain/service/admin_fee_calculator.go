@@ -0,0 +1,64 @@
+package service
+
+import (
+	"fmt"
+	"pt-xyz-multifinance/internal/domain/entity"
+	"sort"
+)
+
+// CalculateAdminFee applies an admin fee rule to an OTR amount, then clamps
+// the result to the rule's minimum and maximum.
+func CalculateAdminFee(rule *entity.AdminFeeRule, otrAmount float64) (float64, error) {
+	feeType, value := rule.Type, rule.Value
+
+	if rule.Type == entity.AdminFeeTiered {
+		tier, err := tierFor(rule.Tiers, otrAmount)
+		if err != nil {
+			return 0, err
+		}
+		feeType, value = tier.Type, tier.Value
+	}
+
+	fee, err := feeFor(feeType, value, otrAmount)
+	if err != nil {
+		return 0, err
+	}
+
+	if fee < rule.MinFee {
+		fee = rule.MinFee
+	}
+	if rule.MaxFee > 0 && fee > rule.MaxFee {
+		fee = rule.MaxFee
+	}
+
+	return roundCurrency(fee), nil
+}
+
+func feeFor(feeType entity.AdminFeeType, value, otrAmount float64) (float64, error) {
+	switch feeType {
+	case entity.AdminFeeFlat:
+		return value, nil
+	case entity.AdminFeePercentage:
+		return otrAmount * value / 100, nil
+	}
+	return 0, fmt.Errorf("unsupported admin fee type: %s", feeType)
+}
+
+// tierFor returns the band with the lowest upper bound that covers the amount.
+func tierFor(tiers []entity.AdminFeeTier, otrAmount float64) (entity.AdminFeeTier, error) {
+	sorted := make([]entity.AdminFeeTier, len(tiers))
+	copy(sorted, tiers)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].UpToOTR == 0 || sorted[j].UpToOTR == 0 {
+			return sorted[j].UpToOTR == 0 && sorted[i].UpToOTR != 0
+		}
+		return sorted[i].UpToOTR < sorted[j].UpToOTR
+	})
+
+	for _, tier := range sorted {
+		if tier.UpToOTR == 0 || otrAmount <= tier.UpToOTR {
+			return tier, nil
+		}
+	}
+	return entity.AdminFeeTier{}, fmt.Errorf("no admin fee tier covers OTR %.2f", otrAmount)
+}
@@ -0,0 +1,38 @@
+package service
+
+import (
+	"pt-xyz-multifinance/internal/domain/entity"
+	"time"
+)
+
+// CheckAgeEligibility applies the product's age rules to an applicant born on
+// birthDate applying on asOf for the tenor: the age on asOf must reach
+// MinAge, and the age when the last installment falls due must not exceed
+// MaxAgeAtTenorEnd. Ages are whole years; a zero limit is not checked.
+func CheckAgeEligibility(product *entity.Product, birthDate, asOf time.Time, tenorMonths int) error {
+	if product.MinAge > 0 {
+		if age := AgeAt(birthDate, asOf); age < product.MinAge {
+			return &entity.AgeIneligibleError{
+				Code:        entity.EligibilityBelowMinAge,
+				ProductCode: product.Code,
+				TenorMonths: tenorMonths,
+				Age:         age,
+				Limit:       product.MinAge,
+			}
+		}
+	}
+
+	if product.MaxAgeAtTenorEnd > 0 {
+		if age := AgeAt(birthDate, asOf.AddDate(0, tenorMonths, 0)); age > product.MaxAgeAtTenorEnd {
+			return &entity.AgeIneligibleError{
+				Code:        entity.EligibilityAboveMaxAgeAtTerm,
+				ProductCode: product.Code,
+				TenorMonths: tenorMonths,
+				Age:         age,
+				Limit:       product.MaxAgeAtTenorEnd,
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Placeholders understood in a contract number template.
+const (
+	ContractPlaceholderPrefix = "{prefix}"
+	ContractPlaceholderDate   = "{date}"
+	ContractPlaceholderBranch = "{branch}"
+	ContractPlaceholderSeq    = "{seq}"
+	ContractPlaceholderCheck  = "{check}"
+)
+
+// ContractNumberFormat renders contract numbers such as
+// XYZ-20240131-JKT-000042-7 from a template.
+type ContractNumberFormat struct {
+	Template      string // must contain {seq} and end with {check}
+	Prefix        string
+	Branch        string
+	SequenceWidth int // sequence is zero-padded to this many digits
+}
+
+func (f ContractNumberFormat) Validate() error {
+	if !strings.Contains(f.Template, ContractPlaceholderSeq) {
+		return fmt.Errorf("contract number template %q must contain %s", f.Template, ContractPlaceholderSeq)
+	}
+	if !strings.HasSuffix(f.Template, ContractPlaceholderCheck) || strings.Count(f.Template, ContractPlaceholderCheck) != 1 {
+		return fmt.Errorf("contract number template %q must end with a single %s", f.Template, ContractPlaceholderCheck)
+	}
+	if f.SequenceWidth < 1 {
+		return fmt.Errorf("contract sequence width must be at least 1")
+	}
+	return nil
+}
+
+// SequenceScope names the sequence a number on the given date draws from.
+// Only the parts that appear in the template split the sequence, so numbers
+// stay unique whichever parts the template leaves out.
+func (f ContractNumberFormat) SequenceScope(date time.Time) string {
+	parts := []string{"contract"}
+	if strings.Contains(f.Template, ContractPlaceholderPrefix) {
+		parts = append(parts, f.Prefix)
+	}
+	if strings.Contains(f.Template, ContractPlaceholderDate) {
+		parts = append(parts, date.Format("20060102"))
+	}
+	if strings.Contains(f.Template, ContractPlaceholderBranch) {
+		parts = append(parts, f.Branch)
+	}
+	return strings.Join(parts, ":")
+}
+
+func (f ContractNumberFormat) Format(date time.Time, sequence uint64) string {
+	body := strings.NewReplacer(
+		ContractPlaceholderPrefix, f.Prefix,
+		ContractPlaceholderDate, date.Format("20060102"),
+		ContractPlaceholderBranch, f.Branch,
+		ContractPlaceholderSeq, fmt.Sprintf("%0*d", f.SequenceWidth, sequence),
+		ContractPlaceholderCheck, "",
+	).Replace(f.Template)
+
+	return body + string(ContractCheckDigit(body))
+}
+
+// ContractCheckDigit computes a Luhn check digit over the letters and digits
+// of a contract number body. Letters count as two digits (A=10 ... Z=35).
+func ContractCheckDigit(body string) byte {
+	digits := contractDigits(body)
+
+	sum := 0
+	double := true
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := digits[i]
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+
+	return byte('0' + (10-sum%10)%10)
+}
+
+// ValidContractCheckDigit reports whether the last character of a contract
+// number is the check digit of the rest.
+func ValidContractCheckDigit(number string) bool {
+	if len(number) < 2 {
+		return false
+	}
+	body, check := number[:len(number)-1], number[len(number)-1]
+	return ContractCheckDigit(body) == check
+}
+
+func contractDigits(body string) []int {
+	var digits []int
+	for _, r := range strings.ToUpper(body) {
+		switch {
+		case unicode.IsDigit(r):
+			digits = append(digits, int(r-'0'))
+		case r >= 'A' && r <= 'Z':
+			for _, c := range strconv.Itoa(int(r-'A') + 10) {
+				digits = append(digits, int(c-'0'))
+			}
+		}
+	}
+	return digits
+}
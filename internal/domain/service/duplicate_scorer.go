@@ -0,0 +1,161 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"pt-xyz-multifinance/internal/domain/entity"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Weights of each signal in a duplicate score; they add up to 1.
+const (
+	duplicateNameWeight       = 0.45
+	duplicateBirthDateWeight  = 0.20
+	duplicateBirthPlaceWeight = 0.10
+	duplicateNIKWeight        = 0.25
+)
+
+// nameHonorifics are dropped before names are compared.
+var nameHonorifics = map[string]bool{
+	"H": true, "HJ": true, "DR": true, "DRS": true, "DRA": true, "IR": true, "PROF": true,
+}
+
+// DuplicateScorePolicy sets when two customers are reported as likely the
+// same person.
+type DuplicateScorePolicy struct {
+	Threshold      float64 // scores at or above this are likely duplicates
+	MaxNIKDistance int     // NIKs further apart than this add nothing to the score
+}
+
+// DuplicateScore is how alike two customers are, from 0 to 1, with the
+// signals it was built from.
+type DuplicateScore struct {
+	Total           float64
+	NameSimilarity  float64
+	BirthDateMatch  bool
+	BirthPlaceMatch bool
+	NIKDistance     int
+	Reasons         []string
+}
+
+// DuplicateScorer compares customers on name, birth date, birth place and NIK.
+type DuplicateScorer struct {
+	policy DuplicateScorePolicy
+}
+
+func NewDuplicateScorer(policy DuplicateScorePolicy) *DuplicateScorer {
+	return &DuplicateScorer{policy: policy}
+}
+
+// Score compares a and b. The name similarity is the best match among their
+// full and legal names; birth date and birth place must match exactly (birth
+// place after normalization); the NIK counts in proportion to how few digits
+// separate the two.
+func (s *DuplicateScorer) Score(a, b *entity.Customer) DuplicateScore {
+	score := DuplicateScore{
+		NameSimilarity: bestNameSimilarity(
+			[]string{a.FullName, a.LegalName},
+			[]string{b.FullName, b.LegalName},
+		),
+		BirthDateMatch:  a.BirthDate.Format("2006-01-02") == b.BirthDate.Format("2006-01-02"),
+		BirthPlaceMatch: NormalizeName(a.BirthPlace) != "" && NormalizeName(a.BirthPlace) == NormalizeName(b.BirthPlace),
+		NIKDistance:     EditDistance(a.NIK, b.NIK),
+	}
+
+	score.Total = score.NameSimilarity * duplicateNameWeight
+	score.Reasons = append(score.Reasons, fmt.Sprintf("names %.0f%% similar", score.NameSimilarity*100))
+
+	if score.BirthDateMatch {
+		score.Total += duplicateBirthDateWeight
+		score.Reasons = append(score.Reasons, "same birth date")
+	}
+	if score.BirthPlaceMatch {
+		score.Total += duplicateBirthPlaceWeight
+		score.Reasons = append(score.Reasons, "same birth place")
+	}
+	if score.NIKDistance <= s.policy.MaxNIKDistance {
+		score.Total += duplicateNIKWeight * (1 - float64(score.NIKDistance)/float64(s.policy.MaxNIKDistance+1))
+		score.Reasons = append(score.Reasons, fmt.Sprintf("NIK differs by %d digit(s)", score.NIKDistance))
+	}
+
+	score.Total = math.Round(score.Total*10000) / 10000
+	return score
+}
+
+// IsLikelyDuplicate reports whether the score reaches the policy threshold.
+func (s *DuplicateScorer) IsLikelyDuplicate(score DuplicateScore) bool {
+	return score.Total >= s.policy.Threshold
+}
+
+// NormalizeName upper-cases a name, drops punctuation and honorifics such as
+// "H." or "Dr." and sorts the remaining words, so "Doe, John" and "JOHN DOE"
+// compare equal.
+func NormalizeName(name string) string {
+	cleaned := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToUpper(r)
+		}
+		return ' '
+	}, name)
+
+	var words []string
+	for _, word := range strings.Fields(cleaned) {
+		if !nameHonorifics[word] {
+			words = append(words, word)
+		}
+	}
+	sort.Strings(words)
+	return strings.Join(words, " ")
+}
+
+// NameSimilarity is 1 minus the edit distance of the normalized names over
+// the length of the longer one.
+func NameSimilarity(a, b string) float64 {
+	a, b = NormalizeName(a), NormalizeName(b)
+	if a == "" || b == "" {
+		return 0
+	}
+
+	longest := len([]rune(a))
+	if n := len([]rune(b)); n > longest {
+		longest = n
+	}
+	return 1 - float64(EditDistance(a, b))/float64(longest)
+}
+
+// EditDistance is the Levenshtein distance between a and b.
+func EditDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(rb)]
+}
+
+func bestNameSimilarity(a, b []string) float64 {
+	best := 0.0
+	for _, left := range a {
+		for _, right := range b {
+			if similarity := NameSimilarity(left, right); similarity > best {
+				best = similarity
+			}
+		}
+	}
+	return best
+}
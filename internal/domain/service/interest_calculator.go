@@ -0,0 +1,127 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"pt-xyz-multifinance/internal/domain/entity"
+)
+
+// InterestPeriod is the principal and interest portion of one monthly installment.
+type InterestPeriod struct {
+	Principal float64
+	Interest  float64
+}
+
+type InterestResult struct {
+	TotalInterest     float64
+	InstallmentAmount float64 // principal + interest of a regular month, excluding admin fee
+	Periods           []InterestPeriod
+}
+
+// InterestCalculator computes the interest of a loan from an annual rate in percent.
+type InterestCalculator interface {
+	Method() entity.InterestMethod
+	Calculate(principal, annualRate float64, tenorMonths int) (*InterestResult, error)
+}
+
+func NewInterestCalculator(method entity.InterestMethod) (InterestCalculator, error) {
+	switch method {
+	case entity.InterestFlat:
+		return &flatInterestCalculator{}, nil
+	case entity.InterestEffective:
+		return &effectiveInterestCalculator{}, nil
+	}
+	return nil, fmt.Errorf("unsupported interest method: %s", method)
+}
+
+// flatInterestCalculator charges interest on the original principal for the whole tenor.
+type flatInterestCalculator struct{}
+
+func (c *flatInterestCalculator) Method() entity.InterestMethod {
+	return entity.InterestFlat
+}
+
+func (c *flatInterestCalculator) Calculate(principal, annualRate float64, tenorMonths int) (*InterestResult, error) {
+	if err := validateInterestInput(principal, annualRate, tenorMonths); err != nil {
+		return nil, err
+	}
+
+	totalInterest := roundCurrency(principal * annualRate / 100 * float64(tenorMonths) / 12)
+	principalPart := roundCurrency(principal / float64(tenorMonths))
+	interestPart := roundCurrency(totalInterest / float64(tenorMonths))
+
+	periods := make([]InterestPeriod, tenorMonths)
+	for i := range periods {
+		periods[i] = InterestPeriod{Principal: principalPart, Interest: interestPart}
+	}
+
+	// The last period absorbs rounding so totals match exactly
+	last := tenorMonths - 1
+	periods[last].Principal = roundCurrency(principal - principalPart*float64(last))
+	periods[last].Interest = roundCurrency(totalInterest - interestPart*float64(last))
+
+	return &InterestResult{
+		TotalInterest:     totalInterest,
+		InstallmentAmount: roundCurrency(principalPart + interestPart),
+		Periods:           periods,
+	}, nil
+}
+
+// effectiveInterestCalculator charges interest on the declining balance with a
+// constant (annuity) monthly installment.
+type effectiveInterestCalculator struct{}
+
+func (c *effectiveInterestCalculator) Method() entity.InterestMethod {
+	return entity.InterestEffective
+}
+
+func (c *effectiveInterestCalculator) Calculate(principal, annualRate float64, tenorMonths int) (*InterestResult, error) {
+	if err := validateInterestInput(principal, annualRate, tenorMonths); err != nil {
+		return nil, err
+	}
+
+	if annualRate == 0 {
+		return (&flatInterestCalculator{}).Calculate(principal, 0, tenorMonths)
+	}
+
+	monthlyRate := annualRate / 100 / 12
+	installment := roundCurrency(principal * monthlyRate / (1 - math.Pow(1+monthlyRate, -float64(tenorMonths))))
+
+	periods := make([]InterestPeriod, tenorMonths)
+	balance := principal
+	totalInterest := 0.0
+	for i := range periods {
+		interest := roundCurrency(balance * monthlyRate)
+		principalPart := roundCurrency(installment - interest)
+		if i == tenorMonths-1 {
+			principalPart = roundCurrency(balance)
+		}
+
+		periods[i] = InterestPeriod{Principal: principalPart, Interest: interest}
+		balance = roundCurrency(balance - principalPart)
+		totalInterest += interest
+	}
+
+	return &InterestResult{
+		TotalInterest:     roundCurrency(totalInterest),
+		InstallmentAmount: installment,
+		Periods:           periods,
+	}, nil
+}
+
+func validateInterestInput(principal, annualRate float64, tenorMonths int) error {
+	if tenorMonths <= 0 {
+		return fmt.Errorf("tenor must be greater than 0 months")
+	}
+	if principal < 0 {
+		return fmt.Errorf("principal cannot be negative")
+	}
+	if annualRate < 0 {
+		return fmt.Errorf("interest rate cannot be negative")
+	}
+	return nil
+}
+
+func roundCurrency(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
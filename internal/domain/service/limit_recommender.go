@@ -0,0 +1,196 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LimitRecommendationPolicy sets how salary and age translate into tenor limits.
+type LimitRecommendationPolicy struct {
+	TenorMultipliers map[int]float64 // limit as a multiple of monthly salary, per tenor
+	Tenors           func() []int    // tenors to recommend for, e.g. the catalog's; nil uses those in TenorMultipliers
+	YoungAge         int             // customers below this age get YoungFactor
+	YoungFactor      float64
+	SeniorAge        int // customers at or above this age get SeniorFactor
+	SeniorFactor     float64
+	RoundTo          float64 // limits are rounded down to a multiple of this amount, but never below it
+	MaxLimit         float64 // 0 means no cap
+}
+
+type TenorLimitRecommendation struct {
+	TenorMonths int     `json:"tenor_months"`
+	Multiplier  float64 `json:"multiplier"`
+	AgeFactor   float64 `json:"age_factor"`
+	LimitAmount float64 `json:"limit_amount"`
+	Reasoning   string  `json:"reasoning"`
+}
+
+type LimitRecommendation struct {
+	Salary    float64                    `json:"salary"`
+	Age       int                        `json:"age"`
+	AgeFactor float64                    `json:"age_factor"`
+	Limits    []TenorLimitRecommendation `json:"limits"`
+	Reasoning []string                   `json:"reasoning"`
+}
+
+// LimitRecommender proposes per-tenor limits from a customer's salary and age.
+type LimitRecommender interface {
+	Recommend(salary float64, birthDate, asOf time.Time) (*LimitRecommendation, error)
+}
+
+type limitRecommender struct {
+	policy LimitRecommendationPolicy
+}
+
+func NewLimitRecommender(policy LimitRecommendationPolicy) LimitRecommender {
+	return &limitRecommender{policy: policy}
+}
+
+func (r *limitRecommender) Recommend(salary float64, birthDate, asOf time.Time) (*LimitRecommendation, error) {
+	if salary <= 0 {
+		return nil, fmt.Errorf("salary must be greater than 0 to recommend limits")
+	}
+	if len(r.policy.TenorMultipliers) == 0 {
+		return nil, fmt.Errorf("no tenor multipliers configured for limit recommendation")
+	}
+
+	age := AgeAt(birthDate, asOf)
+	ageFactor, ageReason := r.ageFactor(age)
+
+	recommendation := &LimitRecommendation{
+		Salary:    salary,
+		Age:       age,
+		AgeFactor: ageFactor,
+		Reasoning: []string{
+			fmt.Sprintf("monthly salary %.2f", salary),
+			ageReason,
+		},
+	}
+
+	for _, tenor := range r.tenors() {
+		multiplier, basis := r.multiplier(tenor)
+		amount := salary * multiplier * ageFactor
+		reasoning := fmt.Sprintf("%.2f salary x %.2f multiplier x %.2f age factor = %.2f", salary, multiplier, ageFactor, amount)
+		if basis != tenor {
+			reasoning = fmt.Sprintf("no multiplier for %d months, using the %d month one: ", tenor, basis) + reasoning
+		}
+
+		if r.policy.MaxLimit > 0 && amount > r.policy.MaxLimit {
+			amount = r.policy.MaxLimit
+			reasoning += fmt.Sprintf(", capped at %.2f", r.policy.MaxLimit)
+		}
+		if r.policy.RoundTo > 0 {
+			amount = math.Floor(amount/r.policy.RoundTo) * r.policy.RoundTo
+			reasoning += fmt.Sprintf(", rounded down to a multiple of %.0f", r.policy.RoundTo)
+
+			// A low salary would otherwise round to a limit of nothing
+			if amount <= 0 {
+				amount = r.policy.RoundTo
+				if r.policy.MaxLimit > 0 && amount > r.policy.MaxLimit {
+					amount = r.policy.MaxLimit
+				}
+				reasoning += fmt.Sprintf(", raised to the minimum of %.0f", amount)
+			}
+		}
+		amount = roundCurrency(amount)
+
+		recommendation.Limits = append(recommendation.Limits, TenorLimitRecommendation{
+			TenorMonths: tenor,
+			Multiplier:  multiplier,
+			AgeFactor:   ageFactor,
+			LimitAmount: amount,
+			Reasoning:   reasoning,
+		})
+	}
+
+	return recommendation, nil
+}
+
+func (r *limitRecommender) tenors() []int {
+	var tenors []int
+	if r.policy.Tenors != nil {
+		tenors = append(tenors, r.policy.Tenors()...)
+	}
+	if len(tenors) == 0 {
+		for tenor := range r.policy.TenorMultipliers {
+			tenors = append(tenors, tenor)
+		}
+	}
+	sort.Ints(tenors)
+	return tenors
+}
+
+// multiplier returns the tenor's configured multiplier. A tenor without one
+// takes that of the longest configured tenor below it, or of the shortest
+// when none is below, and the tenor it was taken from is returned with it.
+func (r *limitRecommender) multiplier(tenor int) (float64, int) {
+	if multiplier, ok := r.policy.TenorMultipliers[tenor]; ok {
+		return multiplier, tenor
+	}
+
+	below, shortest := 0, 0
+	for configured := range r.policy.TenorMultipliers {
+		if configured < tenor && configured > below {
+			below = configured
+		}
+		if shortest == 0 || configured < shortest {
+			shortest = configured
+		}
+	}
+
+	basis := below
+	if basis == 0 {
+		basis = shortest
+	}
+	return r.policy.TenorMultipliers[basis], basis
+}
+
+func (r *limitRecommender) ageFactor(age int) (float64, string) {
+	switch {
+	case r.policy.YoungAge > 0 && age < r.policy.YoungAge:
+		return r.policy.YoungFactor, fmt.Sprintf("age %d is below %d, limits scaled by %.2f", age, r.policy.YoungAge, r.policy.YoungFactor)
+	case r.policy.SeniorAge > 0 && age >= r.policy.SeniorAge:
+		return r.policy.SeniorFactor, fmt.Sprintf("age %d is %d or above, limits scaled by %.2f", age, r.policy.SeniorAge, r.policy.SeniorFactor)
+	}
+	return 1, fmt.Sprintf("age %d needs no adjustment", age)
+}
+
+// AgeAt returns the age in whole years on the given date.
+func AgeAt(birthDate, asOf time.Time) int {
+	age := asOf.Year() - birthDate.Year()
+	if asOf.Month() < birthDate.Month() || (asOf.Month() == birthDate.Month() && asOf.Day() < birthDate.Day()) {
+		age--
+	}
+	if age < 0 {
+		return 0
+	}
+	return age
+}
+
+// ParseTenorMultipliers reads "tenor:multiplier" pairs such as "1:1,2:1.5".
+func ParseTenorMultipliers(pairs []string) (map[int]float64, error) {
+	multipliers := make(map[int]float64, len(pairs))
+	for _, pair := range pairs {
+		parts := strings.SplitN(pair, ":", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid tenor multiplier %q, expected tenor:multiplier", pair)
+		}
+
+		tenor, err := strconv.Atoi(strings.TrimSpace(parts[0]))
+		if err != nil || tenor <= 0 {
+			return nil, fmt.Errorf("invalid tenor in multiplier %q", pair)
+		}
+
+		multiplier, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+		if err != nil || multiplier <= 0 {
+			return nil, fmt.Errorf("invalid multiplier in %q", pair)
+		}
+
+		multipliers[tenor] = multiplier
+	}
+	return multipliers, nil
+}
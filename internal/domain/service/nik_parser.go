@@ -0,0 +1,199 @@
+package service
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"pt-xyz-multifinance/internal/domain/entity"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Provinces by the two-digit Kemendagri code that opens every NIK.
+var Provinces = map[string]string{
+	"11": "Aceh",
+	"12": "Sumatera Utara",
+	"13": "Sumatera Barat",
+	"14": "Riau",
+	"15": "Jambi",
+	"16": "Sumatera Selatan",
+	"17": "Bengkulu",
+	"18": "Lampung",
+	"19": "Kepulauan Bangka Belitung",
+	"21": "Kepulauan Riau",
+	"31": "DKI Jakarta",
+	"32": "Jawa Barat",
+	"33": "Jawa Tengah",
+	"34": "DI Yogyakarta",
+	"35": "Jawa Timur",
+	"36": "Banten",
+	"51": "Bali",
+	"52": "Nusa Tenggara Barat",
+	"53": "Nusa Tenggara Timur",
+	"61": "Kalimantan Barat",
+	"62": "Kalimantan Tengah",
+	"63": "Kalimantan Selatan",
+	"64": "Kalimantan Timur",
+	"65": "Kalimantan Utara",
+	"71": "Sulawesi Utara",
+	"72": "Sulawesi Tengah",
+	"73": "Sulawesi Selatan",
+	"74": "Sulawesi Tenggara",
+	"75": "Gorontalo",
+	"76": "Sulawesi Barat",
+	"81": "Maluku",
+	"82": "Maluku Utara",
+	"91": "Papua",
+	"92": "Papua Barat",
+	"93": "Papua Selatan",
+	"94": "Papua Tengah",
+	"95": "Papua Pegunungan",
+	"96": "Papua Barat Daya",
+}
+
+var nikPattern = regexp.MustCompile(`^\d{16}$`)
+
+// NIKInfo is what a NIK encodes: PPRRDD DDMMYY SSSS, where women have 40
+// added to the day of birth.
+type NIKInfo struct {
+	ProvinceCode string
+	Province     string
+	RegencyCode  string // province + regency, e.g. 3171
+	DistrictCode string // regency + district, e.g. 317101
+	BirthDate    time.Time
+	Sex          entity.Sex
+	Serial       string
+}
+
+// MatchesBirthDate compares the day, month and two-digit year; the century
+// is not part of the NIK.
+func (i *NIKInfo) MatchesBirthDate(birthDate time.Time) bool {
+	return i.BirthDate.Day() == birthDate.Day() &&
+		i.BirthDate.Month() == birthDate.Month() &&
+		i.BirthDate.Year()%100 == birthDate.Year()%100
+}
+
+// NIKParser decodes NIKs. Province codes are always checked; regency and
+// district codes only when a region directory is loaded.
+type NIKParser struct {
+	regions map[string]string
+}
+
+// NewNIKParser takes the regency and district codes to accept, keyed by code
+// (e.g. 3171 and 317101). With nil only the province is checked.
+func NewNIKParser(regions map[string]string) *NIKParser {
+	return &NIKParser{regions: regions}
+}
+
+// Parse decodes a NIK and rejects one whose region or birth date cannot exist.
+func (p *NIKParser) Parse(nik string) (*NIKInfo, error) {
+	if !nikPattern.MatchString(nik) {
+		return nil, fmt.Errorf("NIK must be exactly 16 digits")
+	}
+
+	info := &NIKInfo{
+		ProvinceCode: nik[0:2],
+		RegencyCode:  nik[0:4],
+		DistrictCode: nik[0:6],
+		Serial:       nik[12:16],
+	}
+
+	province, ok := Provinces[info.ProvinceCode]
+	if !ok {
+		return nil, fmt.Errorf("NIK province code %s is not a valid province", info.ProvinceCode)
+	}
+	info.Province = province
+
+	if nik[2:4] == "00" || nik[4:6] == "00" {
+		return nil, fmt.Errorf("NIK region code %s is not a valid regency and district", info.DistrictCode)
+	}
+	if p.regions != nil {
+		if _, ok := p.regions[info.RegencyCode]; !ok {
+			return nil, fmt.Errorf("NIK regency code %s is unknown", info.RegencyCode)
+		}
+		if _, ok := p.regions[info.DistrictCode]; !ok {
+			return nil, fmt.Errorf("NIK district code %s is unknown", info.DistrictCode)
+		}
+	}
+
+	day := atoi2(nik[6:8])
+	month := atoi2(nik[8:10])
+	year := atoi2(nik[10:12])
+
+	info.Sex = entity.SexMale
+	if day > 40 {
+		info.Sex = entity.SexFemale
+		day -= 40
+	}
+
+	// Two-digit years up to the current one are this century
+	if year <= time.Now().Year()%100 {
+		year += 2000
+	} else {
+		year += 1900
+	}
+
+	birthDate := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	if day < 1 || month < 1 || month > 12 || birthDate.Day() != day {
+		return nil, fmt.Errorf("NIK encodes an invalid birth date %s", nik[6:12])
+	}
+	info.BirthDate = birthDate
+
+	return info, nil
+}
+
+// Validate decodes the NIK and checks it against the stated birth date.
+func (p *NIKParser) Validate(nik string, birthDate time.Time) (*NIKInfo, error) {
+	info, err := p.Parse(nik)
+	if err != nil {
+		return nil, err
+	}
+
+	if !info.MatchesBirthDate(birthDate) {
+		return nil, fmt.Errorf("NIK birth date %s does not match birth date %s",
+			info.BirthDate.Format("02-01-06"), birthDate.Format("2006-01-02"))
+	}
+	return info, nil
+}
+
+// ParseRegionCSV reads code,name rows of regencies and districts as published
+// by Kemendagri. Codes may be dotted (31.71.01) or plain (317101).
+func ParseRegionCSV(r io.Reader) (map[string]string, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	regions := make(map[string]string)
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid region file at line %d: %w", line, err)
+		}
+		if len(record) == 0 {
+			continue
+		}
+
+		code := strings.ReplaceAll(strings.TrimSpace(record[0]), ".", "")
+		if len(code) != 4 && len(code) != 6 {
+			// Province rows and headers carry no regency or district code
+			continue
+		}
+		name := ""
+		if len(record) > 1 {
+			name = strings.TrimSpace(record[1])
+		}
+		regions[code] = name
+	}
+
+	if len(regions) == 0 {
+		return nil, fmt.Errorf("region file has no regency or district codes")
+	}
+	return regions, nil
+}
+
+func atoi2(digits string) int {
+	return int(digits[0]-'0')*10 + int(digits[1]-'0')
+}
@@ -54,7 +54,25 @@ func autoMigrate(db *gorm.DB) error {
 		&entity.User{},
 		&entity.Customer{},
 		&entity.CustomerLimit{},
+		&entity.LimitMovement{},
+		&entity.LimitChange{},
+		&entity.LimitChangeRequest{},
+		&entity.Product{},
+		&entity.RateCard{},
+		&entity.AdminFeeRule{},
+		&entity.ContractSequence{},
+		&entity.ContractTemplate{},
 		&entity.Transaction{},
+		&entity.InstallmentSchedule{},
+		&entity.Payment{},
+		&entity.PaymentAllocation{},
+		&entity.ContractDocument{},
+		&entity.Watchlist{},
+		&entity.WatchlistHit{},
+		&entity.CustomerDuplicate{},
+		&entity.Partner{},
+		&entity.PartnerCredential{},
+		&entity.PartnerCustomer{},
 	)
 }
 
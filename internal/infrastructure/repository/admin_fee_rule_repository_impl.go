@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"pt-xyz-multifinance/internal/domain/entity"
+	"pt-xyz-multifinance/internal/domain/repository"
+	"pt-xyz-multifinance/internal/infrastructure/database"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type adminFeeRuleRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewAdminFeeRuleRepository(db *gorm.DB) repository.AdminFeeRuleRepository {
+	return &adminFeeRuleRepositoryImpl{db: db}
+}
+
+func (r *adminFeeRuleRepositoryImpl) Create(ctx context.Context, rule *entity.AdminFeeRule) error {
+	if err := database.Conn(ctx, r.db).Create(rule).Error; err != nil {
+		return fmt.Errorf("failed to create admin fee rule: %w", err)
+	}
+	return nil
+}
+
+func (r *adminFeeRuleRepositoryImpl) GetByID(ctx context.Context, id uint64) (*entity.AdminFeeRule, error) {
+	var rule entity.AdminFeeRule
+	if err := database.Conn(ctx, r.db).First(&rule, id).Error; err != nil {
+		return nil, fmt.Errorf("failed to get admin fee rule by ID: %w", err)
+	}
+	return &rule, nil
+}
+
+func (r *adminFeeRuleRepositoryImpl) GetAll(ctx context.Context) ([]*entity.AdminFeeRule, error) {
+	var rules []*entity.AdminFeeRule
+	if err := database.Conn(ctx, r.db).Order("id ASC").Find(&rules).Error; err != nil {
+		return nil, fmt.Errorf("failed to get admin fee rules: %w", err)
+	}
+	return rules, nil
+}
+
+func (r *adminFeeRuleRepositoryImpl) GetActive(ctx context.Context) ([]*entity.AdminFeeRule, error) {
+	var rules []*entity.AdminFeeRule
+	if err := database.Conn(ctx, r.db).Where("is_active = ?", true).Order("id ASC").Find(&rules).Error; err != nil {
+		return nil, fmt.Errorf("failed to get active admin fee rules: %w", err)
+	}
+	return rules, nil
+}
+
+func (r *adminFeeRuleRepositoryImpl) Supersede(ctx context.Context, previousID uint64, rule *entity.AdminFeeRule) error {
+	return database.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		var previous entity.AdminFeeRule
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&previous, previousID).Error; err != nil {
+			return fmt.Errorf("failed to get admin fee rule for update: %w", err)
+		}
+
+		if err := tx.Model(&previous).Update("is_active", false).Error; err != nil {
+			return fmt.Errorf("failed to deactivate admin fee rule %d: %w", previousID, err)
+		}
+
+		if err := tx.Create(rule).Error; err != nil {
+			return fmt.Errorf("failed to create admin fee rule: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func (r *adminFeeRuleRepositoryImpl) Deactivate(ctx context.Context, id uint64) error {
+	result := database.Conn(ctx, r.db).Model(&entity.AdminFeeRule{}).Where("id = ?", id).Update("is_active", false)
+	if result.Error != nil {
+		return fmt.Errorf("failed to deactivate admin fee rule: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("failed to deactivate admin fee rule: %w", gorm.ErrRecordNotFound)
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"pt-xyz-multifinance/internal/domain/entity"
+	"pt-xyz-multifinance/internal/domain/repository"
+	"pt-xyz-multifinance/internal/infrastructure/database"
+
+	"gorm.io/gorm"
+)
+
+type contractTemplateRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewContractTemplateRepository(db *gorm.DB) repository.ContractTemplateRepository {
+	return &contractTemplateRepositoryImpl{db: db}
+}
+
+// Create stores the template as the next version. The unique index on the
+// version rejects a concurrent create that picked the same number.
+func (r *contractTemplateRepositoryImpl) Create(ctx context.Context, template *entity.ContractTemplate) error {
+	return database.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		var latest int
+		if err := tx.Model(&entity.ContractTemplate{}).Select("COALESCE(MAX(version), 0)").Scan(&latest).Error; err != nil {
+			return fmt.Errorf("failed to get latest contract template version: %w", err)
+		}
+
+		template.Version = latest + 1
+		if err := tx.Create(template).Error; err != nil {
+			return fmt.Errorf("failed to create contract template: %w", err)
+		}
+		return nil
+	})
+}
+
+func (r *contractTemplateRepositoryImpl) GetByID(ctx context.Context, id uint64) (*entity.ContractTemplate, error) {
+	var template entity.ContractTemplate
+	if err := database.Conn(ctx, r.db).First(&template, id).Error; err != nil {
+		return nil, fmt.Errorf("failed to get contract template by ID: %w", err)
+	}
+	return &template, nil
+}
+
+func (r *contractTemplateRepositoryImpl) GetAll(ctx context.Context) ([]*entity.ContractTemplate, error) {
+	var templates []*entity.ContractTemplate
+	if err := database.Conn(ctx, r.db).Order("version DESC").Find(&templates).Error; err != nil {
+		return nil, fmt.Errorf("failed to get contract templates: %w", err)
+	}
+	return templates, nil
+}
+
+func (r *contractTemplateRepositoryImpl) GetLatestActive(ctx context.Context) (*entity.ContractTemplate, error) {
+	var templates []*entity.ContractTemplate
+	if err := database.Conn(ctx, r.db).
+		Where("is_active = ?", true).
+		Order("version DESC").
+		Limit(1).
+		Find(&templates).Error; err != nil {
+		return nil, fmt.Errorf("failed to get active contract template: %w", err)
+	}
+
+	if len(templates) == 0 {
+		return nil, nil
+	}
+	return templates[0], nil
+}
+
+func (r *contractTemplateRepositoryImpl) Update(ctx context.Context, template *entity.ContractTemplate) error {
+	if err := database.Conn(ctx, r.db).Save(template).Error; err != nil {
+		return fmt.Errorf("failed to update contract template: %w", err)
+	}
+	return nil
+}
+
+type contractDocumentRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewContractDocumentRepository(db *gorm.DB) repository.ContractDocumentRepository {
+	return &contractDocumentRepositoryImpl{db: db}
+}
+
+func (r *contractDocumentRepositoryImpl) Create(ctx context.Context, document *entity.ContractDocument) error {
+	if err := database.Conn(ctx, r.db).Create(document).Error; err != nil {
+		return fmt.Errorf("failed to create contract document: %w", err)
+	}
+	return nil
+}
+
+func (r *contractDocumentRepositoryImpl) GetByTransactionID(ctx context.Context, transactionID uint64) (*entity.ContractDocument, error) {
+	var documents []*entity.ContractDocument
+	if err := database.Conn(ctx, r.db).Where("transaction_id = ?", transactionID).Limit(1).Find(&documents).Error; err != nil {
+		return nil, fmt.Errorf("failed to get contract document: %w", err)
+	}
+
+	if len(documents) == 0 {
+		return nil, nil
+	}
+	return documents[0], nil
+}
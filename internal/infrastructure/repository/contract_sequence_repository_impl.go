@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"pt-xyz-multifinance/internal/domain/entity"
+	"pt-xyz-multifinance/internal/domain/repository"
+	"pt-xyz-multifinance/internal/infrastructure/database"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type contractSequenceRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewContractSequenceRepository(db *gorm.DB) repository.ContractSequenceRepository {
+	return &contractSequenceRepositoryImpl{db: db}
+}
+
+func (r *contractSequenceRepositoryImpl) Next(ctx context.Context, scope string) (uint64, error) {
+	var value uint64
+
+	err := database.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		// The first number of a scope creates its row; concurrent creators
+		// fall through to the locked read below.
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&entity.ContractSequence{Scope: scope}).Error; err != nil {
+			return fmt.Errorf("failed to create contract sequence: %w", err)
+		}
+
+		var sequence entity.ContractSequence
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("scope = ?", scope).
+			First(&sequence).Error; err != nil {
+			return fmt.Errorf("failed to get contract sequence for update: %w", err)
+		}
+
+		sequence.Value++
+		if err := tx.Save(&sequence).Error; err != nil {
+			return fmt.Errorf("failed to update contract sequence: %w", err)
+		}
+
+		value = sequence.Value
+		return nil
+	})
+
+	return value, err
+}
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"pt-xyz-multifinance/internal/domain/entity"
+	"pt-xyz-multifinance/internal/domain/repository"
+	"pt-xyz-multifinance/internal/infrastructure/database"
+
+	"gorm.io/gorm"
+)
+
+type customerDuplicateRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewCustomerDuplicateRepository(db *gorm.DB) repository.CustomerDuplicateRepository {
+	return &customerDuplicateRepositoryImpl{db: db}
+}
+
+func (r *customerDuplicateRepositoryImpl) Create(ctx context.Context, duplicate *entity.CustomerDuplicate) error {
+	if err := database.Conn(ctx, r.db).Omit("Customer", "MatchedCustomer").Create(duplicate).Error; err != nil {
+		return fmt.Errorf("failed to flag duplicate customer: %w", err)
+	}
+	return nil
+}
+
+func (r *customerDuplicateRepositoryImpl) GetByID(ctx context.Context, id uint64) (*entity.CustomerDuplicate, error) {
+	var duplicate entity.CustomerDuplicate
+	if err := database.Conn(ctx, r.db).
+		Preload("Customer").
+		Preload("MatchedCustomer").
+		First(&duplicate, id).Error; err != nil {
+		return nil, fmt.Errorf("failed to get duplicate flag by ID: %w", err)
+	}
+	return &duplicate, nil
+}
+
+func (r *customerDuplicateRepositoryImpl) GetByPair(ctx context.Context, customerID, matchedCustomerID uint64) (*entity.CustomerDuplicate, error) {
+	var duplicates []*entity.CustomerDuplicate
+	if err := database.Conn(ctx, r.db).
+		Where("customer_id = ? AND matched_customer_id = ?", customerID, matchedCustomerID).
+		Limit(1).
+		Find(&duplicates).Error; err != nil {
+		return nil, fmt.Errorf("failed to get duplicate flag by customers: %w", err)
+	}
+
+	if len(duplicates) == 0 {
+		return nil, nil
+	}
+	return duplicates[0], nil
+}
+
+// GetByStatus returns every flag in the status with both customers loaded,
+// so they can be grouped into clusters.
+func (r *customerDuplicateRepositoryImpl) GetByStatus(ctx context.Context, status entity.DuplicateStatus) ([]*entity.CustomerDuplicate, error) {
+	var duplicates []*entity.CustomerDuplicate
+	if err := database.Conn(ctx, r.db).
+		Preload("Customer").
+		Preload("MatchedCustomer").
+		Where("status = ?", status).
+		Order("id ASC").
+		Find(&duplicates).Error; err != nil {
+		return nil, fmt.Errorf("failed to get duplicate flags: %w", err)
+	}
+	return duplicates, nil
+}
+
+// CountHoldsByCustomer counts the open and confirmed flags raised on the
+// customer's registration.
+func (r *customerDuplicateRepositoryImpl) CountHoldsByCustomer(ctx context.Context, customerID uint64) (int64, error) {
+	var count int64
+	if err := database.Conn(ctx, r.db).
+		Model(&entity.CustomerDuplicate{}).
+		Where("customer_id = ? AND status IN ?", customerID, []entity.DuplicateStatus{entity.DuplicateOpen, entity.DuplicateConfirmed}).
+		Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count duplicate holds: %w", err)
+	}
+	return count, nil
+}
+
+func (r *customerDuplicateRepositoryImpl) Update(ctx context.Context, duplicate *entity.CustomerDuplicate) error {
+	if err := database.Conn(ctx, r.db).Omit("Customer", "MatchedCustomer").Save(duplicate).Error; err != nil {
+		return fmt.Errorf("failed to update duplicate flag: %w", err)
+	}
+	return nil
+}
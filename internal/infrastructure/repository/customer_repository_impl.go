@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"pt-xyz-multifinance/internal/domain/entity"
 	"pt-xyz-multifinance/internal/domain/repository"
+	"pt-xyz-multifinance/internal/infrastructure/database"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type customerRepositoryImpl struct {
@@ -18,7 +20,7 @@ func NewCustomerRepository(db *gorm.DB) repository.CustomerRepository {
 }
 
 func (r *customerRepositoryImpl) Create(ctx context.Context, customer *entity.Customer) error {
-	if err := r.db.WithContext(ctx).Create(customer).Error; err != nil {
+	if err := database.Conn(ctx, r.db).Create(customer).Error; err != nil {
 		return fmt.Errorf("failed to create customer: %w", err)
 	}
 	return nil
@@ -27,7 +29,7 @@ func (r *customerRepositoryImpl) Create(ctx context.Context, customer *entity.Cu
 func (r *customerRepositoryImpl) GetByID(ctx context.Context, id uint64) (*entity.Customer, error) {
 	var customer entity.Customer
 
-	if err := r.db.WithContext(ctx).
+	if err := database.Conn(ctx, r.db).
 		Preload("User").
 		First(&customer, id).Error; err != nil {
 		return nil, fmt.Errorf("failed to get customer by ID: %w", err)
@@ -35,7 +37,7 @@ func (r *customerRepositoryImpl) GetByID(ctx context.Context, id uint64) (*entit
 
 	if customer.UserID != 0 && customer.User.ID == 0 {
 		var user entity.User
-		if err := r.db.WithContext(ctx).First(&user, customer.UserID).Error; err == nil {
+		if err := database.Conn(ctx, r.db).First(&user, customer.UserID).Error; err == nil {
 			customer.User = user
 		}
 	}
@@ -43,10 +45,20 @@ func (r *customerRepositoryImpl) GetByID(ctx context.Context, id uint64) (*entit
 	return &customer, nil
 }
 
+// GetByIDForUpdate locks the customer row until the database transaction
+// carried by ctx ends.
+func (r *customerRepositoryImpl) GetByIDForUpdate(ctx context.Context, id uint64) (*entity.Customer, error) {
+	var customer entity.Customer
+	if err := database.Conn(ctx, r.db).Clauses(clause.Locking{Strength: "UPDATE"}).First(&customer, id).Error; err != nil {
+		return nil, fmt.Errorf("failed to get customer for update: %w", err)
+	}
+	return &customer, nil
+}
+
 func (r *customerRepositoryImpl) GetByUserID(ctx context.Context, userID uint64) (*entity.Customer, error) {
 	var customer entity.Customer
 
-	if err := r.db.WithContext(ctx).
+	if err := database.Conn(ctx, r.db).
 		Preload("User").
 		Where("user_id = ?", userID).
 		First(&customer).Error; err != nil {
@@ -55,7 +67,7 @@ func (r *customerRepositoryImpl) GetByUserID(ctx context.Context, userID uint64)
 
 	if customer.UserID != 0 && customer.User.ID == 0 {
 		var user entity.User
-		if err := r.db.WithContext(ctx).First(&user, customer.UserID).Error; err == nil {
+		if err := database.Conn(ctx, r.db).First(&user, customer.UserID).Error; err == nil {
 			customer.User = user
 		}
 	}
@@ -66,7 +78,7 @@ func (r *customerRepositoryImpl) GetByUserID(ctx context.Context, userID uint64)
 func (r *customerRepositoryImpl) GetByNIK(ctx context.Context, nik string) (*entity.Customer, error) {
 	var customer entity.Customer
 
-	if err := r.db.WithContext(ctx).
+	if err := database.Conn(ctx, r.db).
 		Preload("User").
 		Where("nik = ?", nik).
 		First(&customer).Error; err != nil {
@@ -75,7 +87,7 @@ func (r *customerRepositoryImpl) GetByNIK(ctx context.Context, nik string) (*ent
 
 	if customer.UserID != 0 && customer.User.ID == 0 {
 		var user entity.User
-		if err := r.db.WithContext(ctx).First(&user, customer.UserID).Error; err == nil {
+		if err := database.Conn(ctx, r.db).First(&user, customer.UserID).Error; err == nil {
 			customer.User = user
 		}
 	}
@@ -84,14 +96,14 @@ func (r *customerRepositoryImpl) GetByNIK(ctx context.Context, nik string) (*ent
 }
 
 func (r *customerRepositoryImpl) Update(ctx context.Context, customer *entity.Customer) error {
-	if err := r.db.WithContext(ctx).Save(customer).Error; err != nil {
+	if err := database.Conn(ctx, r.db).Save(customer).Error; err != nil {
 		return fmt.Errorf("failed to update customer: %w", err)
 	}
 	return nil
 }
 
 func (r *customerRepositoryImpl) Delete(ctx context.Context, id uint64) error {
-	if err := r.db.WithContext(ctx).Delete(&entity.Customer{}, id).Error; err != nil {
+	if err := database.Conn(ctx, r.db).Delete(&entity.Customer{}, id).Error; err != nil {
 		return fmt.Errorf("failed to delete customer: %w", err)
 	}
 	return nil
@@ -100,7 +112,7 @@ func (r *customerRepositoryImpl) Delete(ctx context.Context, id uint64) error {
 func (r *customerRepositoryImpl) GetAll(ctx context.Context, limit, offset int) ([]*entity.Customer, error) {
 	var customers []*entity.Customer
 
-	if err := r.db.WithContext(ctx).
+	if err := database.Conn(ctx, r.db).
 		Preload("User").
 		Limit(limit).Offset(offset).
 		Find(&customers).Error; err != nil {
@@ -110,7 +122,7 @@ func (r *customerRepositoryImpl) GetAll(ctx context.Context, limit, offset int)
 	for i, customer := range customers {
 		if customer.UserID != 0 && customer.User.ID == 0 {
 			var user entity.User
-			if err := r.db.WithContext(ctx).First(&user, customer.UserID).Error; err == nil {
+			if err := database.Conn(ctx, r.db).First(&user, customer.UserID).Error; err == nil {
 				customers[i].User = user
 			}
 		}
@@ -118,3 +130,60 @@ func (r *customerRepositoryImpl) GetAll(ctx context.Context, limit, offset int)
 
 	return customers, nil
 }
+
+func (r *customerRepositoryImpl) AddCreditBalance(ctx context.Context, id uint64, amount float64) error {
+	if err := database.Conn(ctx, r.db).
+		Model(&entity.Customer{}).
+		Where("id = ?", id).
+		Update("credit_balance", gorm.Expr("credit_balance + ?", amount)).Error; err != nil {
+		return fmt.Errorf("failed to update customer credit balance: %w", err)
+	}
+	return nil
+}
+
+// GetByKYCStatus lists customers in a KYC status, longest waiting first.
+func (r *customerRepositoryImpl) GetByKYCStatus(ctx context.Context, status entity.KYCStatus, limit, offset int) ([]*entity.Customer, error) {
+	var customers []*entity.Customer
+
+	if err := database.Conn(ctx, r.db).
+		Preload("User").
+		Where("kyc_status = ?", status).
+		Order("kyc_submitted_at ASC, id ASC").
+		Limit(limit).Offset(offset).
+		Find(&customers).Error; err != nil {
+		return nil, fmt.Errorf("failed to get customers by KYC status: %w", err)
+	}
+
+	return customers, nil
+}
+
+// GetDuplicateCandidates returns the other customers sharing the customer's
+// birth date or the district part (first six digits) of the NIK; only these
+// are scored for duplicates. Birth date matches are taken first, so a crowded
+// district cannot push them past the limit.
+func (r *customerRepositoryImpl) GetDuplicateCandidates(ctx context.Context, customer *entity.Customer, limit int) ([]*entity.Customer, error) {
+	var customers []*entity.Customer
+
+	if err := database.Conn(ctx, r.db).
+		Where("id <> ? AND birth_date = ?", customer.ID, customer.BirthDate).
+		Order("id ASC").
+		Limit(limit).
+		Find(&customers).Error; err != nil {
+		return nil, fmt.Errorf("failed to get duplicate candidates by birth date: %w", err)
+	}
+
+	if len(customer.NIK) < 6 || len(customers) >= limit {
+		return customers, nil
+	}
+
+	var sameDistrict []*entity.Customer
+	if err := database.Conn(ctx, r.db).
+		Where("id <> ? AND birth_date <> ? AND nik LIKE ?", customer.ID, customer.BirthDate, customer.NIK[:6]+"%").
+		Order("id ASC").
+		Limit(limit - len(customers)).
+		Find(&sameDistrict).Error; err != nil {
+		return nil, fmt.Errorf("failed to get duplicate candidates by district: %w", err)
+	}
+
+	return append(customers, sameDistrict...), nil
+}
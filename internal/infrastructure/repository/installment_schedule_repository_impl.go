@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"pt-xyz-multifinance/internal/domain/entity"
+	"pt-xyz-multifinance/internal/domain/repository"
+	"pt-xyz-multifinance/internal/infrastructure/database"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type installmentScheduleRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewInstallmentScheduleRepository(db *gorm.DB) repository.InstallmentScheduleRepository {
+	return &installmentScheduleRepositoryImpl{db: db}
+}
+
+func (r *installmentScheduleRepositoryImpl) CreateBatch(ctx context.Context, installments []*entity.InstallmentSchedule) error {
+	if len(installments) == 0 {
+		return nil
+	}
+	if err := database.Conn(ctx, r.db).Create(&installments).Error; err != nil {
+		return fmt.Errorf("failed to create installment schedule: %w", err)
+	}
+	return nil
+}
+
+func (r *installmentScheduleRepositoryImpl) GetByTransactionID(ctx context.Context, transactionID uint64) ([]*entity.InstallmentSchedule, error) {
+	var installments []*entity.InstallmentSchedule
+	if err := database.Conn(ctx, r.db).
+		Where("transaction_id = ?", transactionID).
+		Order("installment_number ASC").
+		Find(&installments).Error; err != nil {
+		return nil, fmt.Errorf("failed to get installment schedule: %w", err)
+	}
+	return installments, nil
+}
+
+// GetByTransactionIDForUpdate locks the installments until the database
+// transaction carried by ctx ends.
+func (r *installmentScheduleRepositoryImpl) GetByTransactionIDForUpdate(ctx context.Context, transactionID uint64) ([]*entity.InstallmentSchedule, error) {
+	var installments []*entity.InstallmentSchedule
+	if err := database.Conn(ctx, r.db).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("transaction_id = ?", transactionID).
+		Order("installment_number ASC").
+		Find(&installments).Error; err != nil {
+		return nil, fmt.Errorf("failed to get installment schedule for update: %w", err)
+	}
+	return installments, nil
+}
+
+func (r *installmentScheduleRepositoryImpl) Update(ctx context.Context, installment *entity.InstallmentSchedule) error {
+	if err := database.Conn(ctx, r.db).Save(installment).Error; err != nil {
+		return fmt.Errorf("failed to update installment: %w", err)
+	}
+	return nil
+}
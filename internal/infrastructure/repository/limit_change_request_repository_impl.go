@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"pt-xyz-multifinance/internal/domain/entity"
+	"pt-xyz-multifinance/internal/domain/repository"
+	"pt-xyz-multifinance/internal/infrastructure/database"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type limitChangeRequestRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewLimitChangeRequestRepository(db *gorm.DB) repository.LimitChangeRequestRepository {
+	return &limitChangeRequestRepositoryImpl{db: db}
+}
+
+func (r *limitChangeRequestRepositoryImpl) Create(ctx context.Context, request *entity.LimitChangeRequest) error {
+	if err := database.Conn(ctx, r.db).Create(request).Error; err != nil {
+		return fmt.Errorf("failed to create limit change request: %w", err)
+	}
+	return nil
+}
+
+func (r *limitChangeRequestRepositoryImpl) GetByID(ctx context.Context, id uint64) (*entity.LimitChangeRequest, error) {
+	var request entity.LimitChangeRequest
+	if err := database.Conn(ctx, r.db).First(&request, id).Error; err != nil {
+		return nil, fmt.Errorf("failed to get limit change request by ID: %w", err)
+	}
+	return &request, nil
+}
+
+// GetByIDForUpdate locks the request row until the database transaction
+// carried by ctx ends.
+func (r *limitChangeRequestRepositoryImpl) GetByIDForUpdate(ctx context.Context, id uint64) (*entity.LimitChangeRequest, error) {
+	var request entity.LimitChangeRequest
+	if err := database.Conn(ctx, r.db).Clauses(clause.Locking{Strength: "UPDATE"}).First(&request, id).Error; err != nil {
+		return nil, fmt.Errorf("failed to get limit change request for update: %w", err)
+	}
+	return &request, nil
+}
+
+func (r *limitChangeRequestRepositoryImpl) GetByCustomerID(ctx context.Context, customerID uint64) ([]*entity.LimitChangeRequest, error) {
+	var requests []*entity.LimitChangeRequest
+	if err := database.Conn(ctx, r.db).
+		Where("customer_id = ?", customerID).
+		Order("created_at DESC, id DESC").
+		Find(&requests).Error; err != nil {
+		return nil, fmt.Errorf("failed to get limit change requests by customer ID: %w", err)
+	}
+	return requests, nil
+}
+
+func (r *limitChangeRequestRepositoryImpl) GetByStatus(ctx context.Context, status entity.LimitChangeRequestStatus, limit, offset int) ([]*entity.LimitChangeRequest, error) {
+	var requests []*entity.LimitChangeRequest
+	if err := database.Conn(ctx, r.db).
+		Where("status = ?", status).
+		Order("created_at ASC, id ASC").
+		Limit(limit).
+		Offset(offset).
+		Find(&requests).Error; err != nil {
+		return nil, fmt.Errorf("failed to get limit change requests by status: %w", err)
+	}
+	return requests, nil
+}
+
+func (r *limitChangeRequestRepositoryImpl) Update(ctx context.Context, request *entity.LimitChangeRequest) error {
+	if err := database.Conn(ctx, r.db).Save(request).Error; err != nil {
+		return fmt.Errorf("failed to update limit change request: %w", err)
+	}
+	return nil
+}
@@ -2,11 +2,15 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math"
 	"pt-xyz-multifinance/internal/domain/entity"
 	"pt-xyz-multifinance/internal/domain/repository"
+	"pt-xyz-multifinance/internal/infrastructure/database"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type limitRepositoryImpl struct {
@@ -18,7 +22,7 @@ func NewLimitRepository(db *gorm.DB) repository.LimitRepository {
 }
 
 func (r *limitRepositoryImpl) Create(ctx context.Context, limit *entity.CustomerLimit) error {
-	if err := r.db.WithContext(ctx).Create(limit).Error; err != nil {
+	if err := database.Conn(ctx, r.db).Create(limit).Error; err != nil {
 		return fmt.Errorf("failed to create customer limit: %w", err)
 	}
 	return nil
@@ -26,45 +30,61 @@ func (r *limitRepositoryImpl) Create(ctx context.Context, limit *entity.Customer
 
 func (r *limitRepositoryImpl) GetByCustomerAndTenor(ctx context.Context, customerID uint64, tenorMonths int) (*entity.CustomerLimit, error) {
 	var limit entity.CustomerLimit
-	if err := r.db.WithContext(ctx).Where("customer_id = ? AND tenor_months = ?", customerID, tenorMonths).First(&limit).Error; err != nil {
+	if err := database.Conn(ctx, r.db).Where("customer_id = ? AND tenor_months = ?", customerID, tenorMonths).First(&limit).Error; err != nil {
 		return nil, fmt.Errorf("failed to get customer limit: %w", err)
 	}
 	return &limit, nil
 }
 
+// GetByCustomerAndTenorForUpdate locks the limit row until the database
+// transaction carried by ctx ends.
+func (r *limitRepositoryImpl) GetByCustomerAndTenorForUpdate(ctx context.Context, customerID uint64, tenorMonths int) (*entity.CustomerLimit, error) {
+	var limit entity.CustomerLimit
+	if err := database.Conn(ctx, r.db).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("customer_id = ? AND tenor_months = ?", customerID, tenorMonths).
+		First(&limit).Error; err != nil {
+		return nil, fmt.Errorf("failed to get customer limit for update: %w", err)
+	}
+	return &limit, nil
+}
+
 func (r *limitRepositoryImpl) GetByCustomerID(ctx context.Context, customerID uint64) ([]*entity.CustomerLimit, error) {
 	var limits []*entity.CustomerLimit
-	if err := r.db.WithContext(ctx).Where("customer_id = ?", customerID).Find(&limits).Error; err != nil {
+	if err := database.Conn(ctx, r.db).Where("customer_id = ?", customerID).Find(&limits).Error; err != nil {
 		return nil, fmt.Errorf("failed to get customer limits: %w", err)
 	}
 	return limits, nil
 }
 
 func (r *limitRepositoryImpl) Update(ctx context.Context, limit *entity.CustomerLimit) error {
-	if err := r.db.WithContext(ctx).Save(limit).Error; err != nil {
+	if err := database.Conn(ctx, r.db).Save(limit).Error; err != nil {
 		return fmt.Errorf("failed to update customer limit: %w", err)
 	}
 	return nil
 }
 
-func (r *limitRepositoryImpl) UpdateUsedAmount(ctx context.Context, customerID uint64, tenorMonths int, amount float64) error {
+func (r *limitRepositoryImpl) UpdateUsedAmount(ctx context.Context, movement *entity.LimitMovement) error {
 
-	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	return database.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
 		var limit entity.CustomerLimit
 
-		if err := tx.Set("gorm:query_option", "FOR UPDATE").
-			Where("customer_id = ? AND tenor_months = ?", customerID, tenorMonths).
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("customer_id = ? AND tenor_months = ?", movement.CustomerID, movement.TenorMonths).
 			First(&limit).Error; err != nil {
 			return fmt.Errorf("failed to get customer limit for update: %w", err)
 		}
 
-		newUsedAmount := limit.UsedAmount + amount
+		if err := r.reconcileLedger(tx, &limit); err != nil {
+			return err
+		}
+
+		newUsedAmount := roundAmount(limit.UsedAmount + movement.Amount)
 
 		if newUsedAmount < 0 {
-			newUsedAmount = 0
+			return fmt.Errorf("used amount %.2f would drop below zero by %.2f", limit.UsedAmount, -movement.Amount)
 		}
 
-		if newUsedAmount > limit.LimitAmount {
+		if movement.Amount > 0 && newUsedAmount > limit.LimitAmount {
 			return fmt.Errorf("used amount %.2f would exceed limit amount %.2f", newUsedAmount, limit.LimitAmount)
 		}
 
@@ -74,13 +94,122 @@ func (r *limitRepositoryImpl) UpdateUsedAmount(ctx context.Context, customerID u
 			return fmt.Errorf("failed to update used amount: %w", err)
 		}
 
+		movement.CustomerLimitID = limit.ID
+		movement.UsedAmountAfter = newUsedAmount
+		if err := tx.Create(movement).Error; err != nil {
+			return fmt.Errorf("failed to record limit movement: %w", err)
+		}
+
 		return nil
 	})
 }
 
+// reconcileLedger checks the stored used amount against the sum of its ledger.
+// Limits that predate the ledger get an opening entry for their current usage.
+func (r *limitRepositoryImpl) reconcileLedger(tx *gorm.DB, limit *entity.CustomerLimit) error {
+	var ledger struct {
+		Entries int64
+		Total   float64
+	}
+	if err := tx.Model(&entity.LimitMovement{}).
+		Select("COUNT(*) AS entries, COALESCE(SUM(amount), 0) AS total").
+		Where("customer_limit_id = ?", limit.ID).
+		Scan(&ledger).Error; err != nil {
+		return fmt.Errorf("failed to sum limit movements: %w", err)
+	}
+
+	if ledger.Entries == 0 {
+		if limit.UsedAmount == 0 {
+			return nil
+		}
+		opening := &entity.LimitMovement{
+			CustomerLimitID: limit.ID,
+			CustomerID:      limit.CustomerID,
+			TenorMonths:     limit.TenorMonths,
+			Type:            entity.MovementManualAdjustment,
+			Amount:          limit.UsedAmount,
+			UsedAmountAfter: limit.UsedAmount,
+			Reason:          "opening balance carried over from used amount",
+		}
+		if err := tx.Create(opening).Error; err != nil {
+			return fmt.Errorf("failed to record opening limit movement: %w", err)
+		}
+		return nil
+	}
+
+	if roundAmount(ledger.Total) != roundAmount(limit.UsedAmount) {
+		return fmt.Errorf("limit ledger out of sync for customer %d tenor %d: used amount %.2f, ledger total %.2f",
+			limit.CustomerID, limit.TenorMonths, limit.UsedAmount, ledger.Total)
+	}
+
+	return nil
+}
+
+func (r *limitRepositoryImpl) GetMovements(ctx context.Context, customerID uint64, tenorMonths int) ([]*entity.LimitMovement, error) {
+	var movements []*entity.LimitMovement
+	if err := database.Conn(ctx, r.db).
+		Where("customer_id = ? AND tenor_months = ?", customerID, tenorMonths).
+		Order("created_at ASC, id ASC").
+		Find(&movements).Error; err != nil {
+		return nil, fmt.Errorf("failed to get limit movements: %w", err)
+	}
+	return movements, nil
+}
+
+func (r *limitRepositoryImpl) UpdateLimitAmount(ctx context.Context, change *entity.LimitChange) error {
+
+	return database.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		var limit entity.CustomerLimit
+
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("customer_id = ? AND tenor_months = ?", change.CustomerID, change.TenorMonths).
+			First(&limit).Error
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			// A tenor added to the catalog after the customer registered
+			limit = entity.CustomerLimit{CustomerID: change.CustomerID, TenorMonths: change.TenorMonths}
+		case err != nil:
+			return fmt.Errorf("failed to get customer limit for update: %w", err)
+		}
+
+		if change.NewLimitAmount < limit.UsedAmount {
+			return fmt.Errorf("new limit amount %.2f is below used amount %.2f", change.NewLimitAmount, limit.UsedAmount)
+		}
+
+		change.OldLimitAmount = limit.LimitAmount
+		limit.LimitAmount = change.NewLimitAmount
+
+		if err := tx.Save(&limit).Error; err != nil {
+			return fmt.Errorf("failed to update limit amount: %w", err)
+		}
+		change.CustomerLimitID = limit.ID
+
+		if err := tx.Create(change).Error; err != nil {
+			return fmt.Errorf("failed to record limit change: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func (r *limitRepositoryImpl) GetLimitChanges(ctx context.Context, customerID uint64, tenorMonths int) ([]*entity.LimitChange, error) {
+	var changes []*entity.LimitChange
+	if err := database.Conn(ctx, r.db).
+		Where("customer_id = ? AND tenor_months = ?", customerID, tenorMonths).
+		Order("created_at DESC, id DESC").
+		Find(&changes).Error; err != nil {
+		return nil, fmt.Errorf("failed to get limit changes: %w", err)
+	}
+	return changes, nil
+}
+
 func (r *limitRepositoryImpl) Delete(ctx context.Context, id uint64) error {
-	if err := r.db.WithContext(ctx).Delete(&entity.CustomerLimit{}, id).Error; err != nil {
+	if err := database.Conn(ctx, r.db).Delete(&entity.CustomerLimit{}, id).Error; err != nil {
 		return fmt.Errorf("failed to delete customer limit: %w", err)
 	}
 	return nil
 }
+
+func roundAmount(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -0,0 +1,176 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"pt-xyz-multifinance/internal/domain/entity"
+	"pt-xyz-multifinance/internal/domain/repository"
+	"pt-xyz-multifinance/internal/infrastructure/database"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type partnerRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewPartnerRepository(db *gorm.DB) repository.PartnerRepository {
+	return &partnerRepositoryImpl{db: db}
+}
+
+func (r *partnerRepositoryImpl) Create(ctx context.Context, partner *entity.Partner) error {
+	if err := database.Conn(ctx, r.db).Create(partner).Error; err != nil {
+		return fmt.Errorf("failed to create partner: %w", err)
+	}
+	return nil
+}
+
+func (r *partnerRepositoryImpl) GetByID(ctx context.Context, id uint64) (*entity.Partner, error) {
+	var partner entity.Partner
+	if err := database.Conn(ctx, r.db).First(&partner, id).Error; err != nil {
+		return nil, fmt.Errorf("failed to get partner by ID: %w", err)
+	}
+	return &partner, nil
+}
+
+func (r *partnerRepositoryImpl) GetAll(ctx context.Context, limit, offset int) ([]*entity.Partner, error) {
+	var partners []*entity.Partner
+	if err := database.Conn(ctx, r.db).
+		Order("id DESC").
+		Limit(limit).Offset(offset).
+		Find(&partners).Error; err != nil {
+		return nil, fmt.Errorf("failed to get partners: %w", err)
+	}
+	return partners, nil
+}
+
+func (r *partnerRepositoryImpl) Update(ctx context.Context, partner *entity.Partner) error {
+	if err := database.Conn(ctx, r.db).Save(partner).Error; err != nil {
+		return fmt.Errorf("failed to update partner: %w", err)
+	}
+	return nil
+}
+
+func (r *partnerRepositoryImpl) LinkCustomer(ctx context.Context, link *entity.PartnerCustomer) error {
+	if err := database.Conn(ctx, r.db).
+		Omit("Partner").
+		Where("partner_id = ? AND customer_id = ?", link.PartnerID, link.CustomerID).
+		FirstOrCreate(link).Error; err != nil {
+		return fmt.Errorf("failed to link customer to partner: %w", err)
+	}
+	return nil
+}
+
+func (r *partnerRepositoryImpl) UnlinkCustomer(ctx context.Context, partnerID, customerID uint64) error {
+	if err := database.Conn(ctx, r.db).
+		Where("partner_id = ? AND customer_id = ?", partnerID, customerID).
+		Delete(&entity.PartnerCustomer{}).Error; err != nil {
+		return fmt.Errorf("failed to unlink customer from partner: %w", err)
+	}
+	return nil
+}
+
+func (r *partnerRepositoryImpl) IsCustomerLinked(ctx context.Context, partnerID, customerID uint64) (bool, error) {
+	var count int64
+	if err := database.Conn(ctx, r.db).
+		Model(&entity.PartnerCustomer{}).
+		Where("partner_id = ? AND customer_id = ?", partnerID, customerID).
+		Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to check partner customer link: %w", err)
+	}
+	return count > 0, nil
+}
+
+func (r *partnerRepositoryImpl) GetCustomerLinks(ctx context.Context, customerID uint64) ([]*entity.PartnerCustomer, error) {
+	var links []*entity.PartnerCustomer
+	if err := database.Conn(ctx, r.db).
+		Preload("Partner").
+		Where("customer_id = ?", customerID).
+		Order("id DESC").
+		Find(&links).Error; err != nil {
+		return nil, fmt.Errorf("failed to get partner links of customer: %w", err)
+	}
+	return links, nil
+}
+
+type partnerCredentialRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewPartnerCredentialRepository(db *gorm.DB) repository.PartnerCredentialRepository {
+	return &partnerCredentialRepositoryImpl{db: db}
+}
+
+func (r *partnerCredentialRepositoryImpl) Create(ctx context.Context, credential *entity.PartnerCredential) error {
+	if err := database.Conn(ctx, r.db).Create(credential).Error; err != nil {
+		return fmt.Errorf("failed to create partner credential: %w", err)
+	}
+	return nil
+}
+
+func (r *partnerCredentialRepositoryImpl) GetByID(ctx context.Context, id uint64) (*entity.PartnerCredential, error) {
+	var credential entity.PartnerCredential
+	if err := database.Conn(ctx, r.db).First(&credential, id).Error; err != nil {
+		return nil, fmt.Errorf("failed to get partner credential by ID: %w", err)
+	}
+	return &credential, nil
+}
+
+// GetByAPIKey returns the credential with its partner, or nil when no
+// credential has the key.
+func (r *partnerCredentialRepositoryImpl) GetByAPIKey(ctx context.Context, apiKey string) (*entity.PartnerCredential, error) {
+	var credentials []*entity.PartnerCredential
+	if err := database.Conn(ctx, r.db).
+		Preload("Partner").
+		Where("api_key = ?", apiKey).
+		Limit(1).
+		Find(&credentials).Error; err != nil {
+		return nil, fmt.Errorf("failed to get partner credential by API key: %w", err)
+	}
+
+	if len(credentials) == 0 {
+		return nil, nil
+	}
+	return credentials[0], nil
+}
+
+func (r *partnerCredentialRepositoryImpl) GetByPartnerID(ctx context.Context, partnerID uint64) ([]*entity.PartnerCredential, error) {
+	var credentials []*entity.PartnerCredential
+	if err := database.Conn(ctx, r.db).
+		Where("partner_id = ?", partnerID).
+		Order("id DESC").
+		Find(&credentials).Error; err != nil {
+		return nil, fmt.Errorf("failed to get partner credentials: %w", err)
+	}
+	return credentials, nil
+}
+
+// ExpireActive moves the expiry of the partner's unrevoked credentials to
+// expiresAt, leaving those already expiring sooner alone.
+func (r *partnerCredentialRepositoryImpl) ExpireActive(ctx context.Context, partnerID uint64, expiresAt time.Time) error {
+	if err := database.Conn(ctx, r.db).
+		Model(&entity.PartnerCredential{}).
+		Where("partner_id = ? AND revoked_at IS NULL AND (expires_at IS NULL OR expires_at > ?)", partnerID, expiresAt).
+		Update("expires_at", expiresAt).Error; err != nil {
+		return fmt.Errorf("failed to expire partner credentials: %w", err)
+	}
+	return nil
+}
+
+func (r *partnerCredentialRepositoryImpl) Update(ctx context.Context, credential *entity.PartnerCredential) error {
+	if err := database.Conn(ctx, r.db).Omit("Partner").Save(credential).Error; err != nil {
+		return fmt.Errorf("failed to update partner credential: %w", err)
+	}
+	return nil
+}
+
+func (r *partnerCredentialRepositoryImpl) TouchLastUsed(ctx context.Context, id uint64, at time.Time) error {
+	if err := database.Conn(ctx, r.db).
+		Model(&entity.PartnerCredential{}).
+		Where("id = ?", id).
+		Update("last_used_at", at).Error; err != nil {
+		return fmt.Errorf("failed to record partner credential use: %w", err)
+	}
+	return nil
+}
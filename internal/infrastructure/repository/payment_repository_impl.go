@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"pt-xyz-multifinance/internal/domain/entity"
+	"pt-xyz-multifinance/internal/domain/repository"
+	"pt-xyz-multifinance/internal/infrastructure/database"
+
+	"gorm.io/gorm"
+)
+
+type paymentRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewPaymentRepository(db *gorm.DB) repository.PaymentRepository {
+	return &paymentRepositoryImpl{db: db}
+}
+
+func (r *paymentRepositoryImpl) Create(ctx context.Context, payment *entity.Payment) error {
+	if err := database.Conn(ctx, r.db).Create(payment).Error; err != nil {
+		return fmt.Errorf("failed to create payment: %w", err)
+	}
+	return nil
+}
+
+func (r *paymentRepositoryImpl) GetByID(ctx context.Context, id uint64) (*entity.Payment, error) {
+	var payment entity.Payment
+	if err := database.Conn(ctx, r.db).Preload("Allocations").First(&payment, id).Error; err != nil {
+		return nil, fmt.Errorf("failed to get payment by ID: %w", err)
+	}
+	return &payment, nil
+}
+
+func (r *paymentRepositoryImpl) GetByTransactionID(ctx context.Context, transactionID uint64) ([]*entity.Payment, error) {
+	var payments []*entity.Payment
+	if err := database.Conn(ctx, r.db).
+		Preload("Allocations").
+		Where("transaction_id = ?", transactionID).
+		Order("payment_date ASC, id ASC").
+		Find(&payments).Error; err != nil {
+		return nil, fmt.Errorf("failed to get payments by transaction ID: %w", err)
+	}
+	return payments, nil
+}
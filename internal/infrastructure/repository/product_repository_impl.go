@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"pt-xyz-multifinance/internal/domain/entity"
+	"pt-xyz-multifinance/internal/domain/repository"
+	"pt-xyz-multifinance/internal/infrastructure/database"
+
+	"gorm.io/gorm"
+)
+
+type productRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewProductRepository(db *gorm.DB) repository.ProductRepository {
+	return &productRepositoryImpl{db: db}
+}
+
+func (r *productRepositoryImpl) Create(ctx context.Context, product *entity.Product) error {
+	if err := database.Conn(ctx, r.db).Create(product).Error; err != nil {
+		return fmt.Errorf("failed to create product: %w", err)
+	}
+	return nil
+}
+
+func (r *productRepositoryImpl) GetByID(ctx context.Context, id uint64) (*entity.Product, error) {
+	var product entity.Product
+	if err := database.Conn(ctx, r.db).First(&product, id).Error; err != nil {
+		return nil, fmt.Errorf("failed to get product by ID: %w", err)
+	}
+	return &product, nil
+}
+
+func (r *productRepositoryImpl) GetByCode(ctx context.Context, code string) (*entity.Product, error) {
+	var product entity.Product
+	if err := database.Conn(ctx, r.db).Where("code = ?", code).First(&product).Error; err != nil {
+		return nil, fmt.Errorf("failed to get product by code: %w", err)
+	}
+	return &product, nil
+}
+
+func (r *productRepositoryImpl) GetAll(ctx context.Context) ([]*entity.Product, error) {
+	var products []*entity.Product
+	if err := database.Conn(ctx, r.db).Order("id ASC").Find(&products).Error; err != nil {
+		return nil, fmt.Errorf("failed to get products: %w", err)
+	}
+	return products, nil
+}
+
+func (r *productRepositoryImpl) GetActive(ctx context.Context) ([]*entity.Product, error) {
+	var products []*entity.Product
+	if err := database.Conn(ctx, r.db).Where("is_active = ?", true).Order("id ASC").Find(&products).Error; err != nil {
+		return nil, fmt.Errorf("failed to get active products: %w", err)
+	}
+	return products, nil
+}
+
+func (r *productRepositoryImpl) Update(ctx context.Context, product *entity.Product) error {
+	if err := database.Conn(ctx, r.db).Save(product).Error; err != nil {
+		return fmt.Errorf("failed to update product: %w", err)
+	}
+	return nil
+}
+
+func (r *productRepositoryImpl) Delete(ctx context.Context, id uint64) error {
+	if err := database.Conn(ctx, r.db).Delete(&entity.Product{}, id).Error; err != nil {
+		return fmt.Errorf("failed to delete product: %w", err)
+	}
+	return nil
+}
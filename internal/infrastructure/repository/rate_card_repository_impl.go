@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"pt-xyz-multifinance/internal/domain/entity"
+	"pt-xyz-multifinance/internal/domain/repository"
+	"pt-xyz-multifinance/internal/infrastructure/database"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type rateCardRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewRateCardRepository(db *gorm.DB) repository.RateCardRepository {
+	return &rateCardRepositoryImpl{db: db}
+}
+
+func (r *rateCardRepositoryImpl) CreateVersion(ctx context.Context, card *entity.RateCard) error {
+	return database.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		var existing []*entity.RateCard
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("product_id = ? AND tenor_months = ? AND asset_type = ?", card.ProductID, card.TenorMonths, card.AssetType).
+			Find(&existing).Error; err != nil {
+			return fmt.Errorf("failed to get rate cards for update: %w", err)
+		}
+
+		for _, other := range existing {
+			if !ratePeriodsOverlap(other, card) {
+				continue
+			}
+
+			// The card in force until now is closed when the new one takes over
+			if other.EffectiveTo == nil && other.EffectiveFrom.Before(card.EffectiveFrom) {
+				effectiveTo := card.EffectiveFrom
+				other.EffectiveTo = &effectiveTo
+				if err := tx.Save(other).Error; err != nil {
+					return fmt.Errorf("failed to close rate card %d: %w", other.ID, err)
+				}
+				continue
+			}
+
+			return fmt.Errorf("rate card overlaps rate card %d effective from %s", other.ID, other.EffectiveFrom.Format(time.RFC3339))
+		}
+
+		if err := tx.Create(card).Error; err != nil {
+			return fmt.Errorf("failed to create rate card: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func (r *rateCardRepositoryImpl) GetByID(ctx context.Context, id uint64) (*entity.RateCard, error) {
+	var card entity.RateCard
+	if err := database.Conn(ctx, r.db).First(&card, id).Error; err != nil {
+		return nil, fmt.Errorf("failed to get rate card by ID: %w", err)
+	}
+	return &card, nil
+}
+
+func (r *rateCardRepositoryImpl) GetByProductID(ctx context.Context, productID uint64) ([]*entity.RateCard, error) {
+	var cards []*entity.RateCard
+	if err := database.Conn(ctx, r.db).
+		Where("product_id = ?", productID).
+		Order("tenor_months ASC, asset_type ASC, effective_from DESC").
+		Find(&cards).Error; err != nil {
+		return nil, fmt.Errorf("failed to get rate cards: %w", err)
+	}
+	return cards, nil
+}
+
+func (r *rateCardRepositoryImpl) GetEffective(ctx context.Context, productID uint64, tenorMonths int, assetType entity.AssetType, at time.Time) (*entity.RateCard, error) {
+	var cards []*entity.RateCard
+	if err := database.Conn(ctx, r.db).
+		Where("product_id = ? AND tenor_months = ? AND asset_type = ?", productID, tenorMonths, assetType).
+		Where("effective_from <= ? AND (effective_to IS NULL OR effective_to > ?)", at, at).
+		Order("effective_from DESC").
+		Limit(1).
+		Find(&cards).Error; err != nil {
+		return nil, fmt.Errorf("failed to get effective rate card: %w", err)
+	}
+
+	if len(cards) == 0 {
+		return nil, nil
+	}
+	return cards[0], nil
+}
+
+func ratePeriodsOverlap(a, b *entity.RateCard) bool {
+	aEndsAfterBStarts := a.EffectiveTo == nil || a.EffectiveTo.After(b.EffectiveFrom)
+	bEndsAfterAStarts := b.EffectiveTo == nil || b.EffectiveTo.After(a.EffectiveFrom)
+	return aEndsAfterBStarts && bEndsAfterAStarts
+}
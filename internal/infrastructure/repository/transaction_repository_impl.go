@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"pt-xyz-multifinance/internal/domain/entity"
 	"pt-xyz-multifinance/internal/domain/repository"
+	"pt-xyz-multifinance/internal/infrastructure/database"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type transactionRepositoryImpl struct {
@@ -18,7 +20,7 @@ func NewTransactionRepository(db *gorm.DB) repository.TransactionRepository {
 }
 
 func (r *transactionRepositoryImpl) Create(ctx context.Context, transaction *entity.Transaction) error {
-	if err := r.db.WithContext(ctx).Create(transaction).Error; err != nil {
+	if err := database.Conn(ctx, r.db).Create(transaction).Error; err != nil {
 		return fmt.Errorf("failed to create transaction: %w", err)
 	}
 	return nil
@@ -26,15 +28,25 @@ func (r *transactionRepositoryImpl) Create(ctx context.Context, transaction *ent
 
 func (r *transactionRepositoryImpl) GetByID(ctx context.Context, id uint64) (*entity.Transaction, error) {
 	var transaction entity.Transaction
-	if err := r.db.WithContext(ctx).Preload("Customer").First(&transaction, id).Error; err != nil {
+	if err := database.Conn(ctx, r.db).Preload("Customer").First(&transaction, id).Error; err != nil {
 		return nil, fmt.Errorf("failed to get transaction by ID: %w", err)
 	}
 	return &transaction, nil
 }
 
+// GetByIDForUpdate locks the transaction row until the database transaction
+// carried by ctx ends.
+func (r *transactionRepositoryImpl) GetByIDForUpdate(ctx context.Context, id uint64) (*entity.Transaction, error) {
+	var transaction entity.Transaction
+	if err := database.Conn(ctx, r.db).Clauses(clause.Locking{Strength: "UPDATE"}).Preload("Customer").First(&transaction, id).Error; err != nil {
+		return nil, fmt.Errorf("failed to get transaction for update: %w", err)
+	}
+	return &transaction, nil
+}
+
 func (r *transactionRepositoryImpl) GetByContractNumber(ctx context.Context, contractNumber string) (*entity.Transaction, error) {
 	var transaction entity.Transaction
-	if err := r.db.WithContext(ctx).Preload("Customer").Where("contract_number = ?", contractNumber).First(&transaction).Error; err != nil {
+	if err := database.Conn(ctx, r.db).Preload("Customer").Where("contract_number = ?", contractNumber).First(&transaction).Error; err != nil {
 		return nil, fmt.Errorf("failed to get transaction by contract number: %w", err)
 	}
 	return &transaction, nil
@@ -42,20 +54,20 @@ func (r *transactionRepositoryImpl) GetByContractNumber(ctx context.Context, con
 
 func (r *transactionRepositoryImpl) GetByCustomerID(ctx context.Context, customerID uint64) ([]*entity.Transaction, error) {
 	var transactions []*entity.Transaction
-	if err := r.db.WithContext(ctx).Preload("Customer").Where("customer_id = ?", customerID).Find(&transactions).Error; err != nil {
+	if err := database.Conn(ctx, r.db).Preload("Customer").Where("customer_id = ?", customerID).Find(&transactions).Error; err != nil {
 		return nil, fmt.Errorf("failed to get transactions by customer ID: %w", err)
 	}
 	return transactions, nil
 }
 func (r *transactionRepositoryImpl) Update(ctx context.Context, transaction *entity.Transaction) error {
-	if err := r.db.WithContext(ctx).Save(transaction).Error; err != nil {
+	if err := database.Conn(ctx, r.db).Save(transaction).Error; err != nil {
 		return fmt.Errorf("failed to update transaction: %w", err)
 	}
 	return nil
 }
 
 func (r *transactionRepositoryImpl) Delete(ctx context.Context, id uint64) error {
-	if err := r.db.WithContext(ctx).Delete(&entity.Transaction{}, id).Error; err != nil {
+	if err := database.Conn(ctx, r.db).Delete(&entity.Transaction{}, id).Error; err != nil {
 		return fmt.Errorf("failed to delete transaction: %w", err)
 	}
 	return nil
@@ -63,8 +75,16 @@ func (r *transactionRepositoryImpl) Delete(ctx context.Context, id uint64) error
 
 func (r *transactionRepositoryImpl) GetAll(ctx context.Context, limit, offset int) ([]*entity.Transaction, error) {
 	var transactions []*entity.Transaction
-	if err := r.db.WithContext(ctx).Preload("Customer").Limit(limit).Offset(offset).Find(&transactions).Error; err != nil {
+	if err := database.Conn(ctx, r.db).Preload("Customer").Limit(limit).Offset(offset).Find(&transactions).Error; err != nil {
 		return nil, fmt.Errorf("failed to get all transactions: %w", err)
 	}
 	return transactions, nil
 }
+
+func (r *transactionRepositoryImpl) GetByStatuses(ctx context.Context, statuses []entity.TransactionStatus) ([]*entity.Transaction, error) {
+	var transactions []*entity.Transaction
+	if err := database.Conn(ctx, r.db).Where("status IN ?", statuses).Find(&transactions).Error; err != nil {
+		return nil, fmt.Errorf("failed to get transactions by status: %w", err)
+	}
+	return transactions, nil
+}
@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"pt-xyz-multifinance/internal/domain/entity"
+	"pt-xyz-multifinance/internal/domain/repository"
+	"pt-xyz-multifinance/internal/infrastructure/database"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type watchlistRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewWatchlistRepository(db *gorm.DB) repository.WatchlistRepository {
+	return &watchlistRepositoryImpl{db: db}
+}
+
+func (r *watchlistRepositoryImpl) Create(ctx context.Context, entry *entity.Watchlist) error {
+	if err := database.Conn(ctx, r.db).Create(entry).Error; err != nil {
+		return fmt.Errorf("failed to create watchlist entry: %w", err)
+	}
+	return nil
+}
+
+func (r *watchlistRepositoryImpl) GetByID(ctx context.Context, id uint64) (*entity.Watchlist, error) {
+	var entry entity.Watchlist
+	if err := database.Conn(ctx, r.db).First(&entry, id).Error; err != nil {
+		return nil, fmt.Errorf("failed to get watchlist entry by ID: %w", err)
+	}
+	return &entry, nil
+}
+
+func (r *watchlistRepositoryImpl) GetAll(ctx context.Context, limit, offset int) ([]*entity.Watchlist, error) {
+	var entries []*entity.Watchlist
+	if err := database.Conn(ctx, r.db).
+		Order("id DESC").
+		Limit(limit).Offset(offset).
+		Find(&entries).Error; err != nil {
+		return nil, fmt.Errorf("failed to get watchlist entries: %w", err)
+	}
+	return entries, nil
+}
+
+func (r *watchlistRepositoryImpl) GetByNIKAndSource(ctx context.Context, nik, source string) (*entity.Watchlist, error) {
+	var entries []*entity.Watchlist
+	if err := database.Conn(ctx, r.db).
+		Where("nik = ? AND source = ?", nik, source).
+		Limit(1).
+		Find(&entries).Error; err != nil {
+		return nil, fmt.Errorf("failed to get watchlist entry by NIK and source: %w", err)
+	}
+
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	return entries[0], nil
+}
+
+// GetActiveByNIK returns the entries for the NIK that have not expired.
+func (r *watchlistRepositoryImpl) GetActiveByNIK(ctx context.Context, nik string, at time.Time) ([]*entity.Watchlist, error) {
+	var entries []*entity.Watchlist
+	if err := database.Conn(ctx, r.db).
+		Where("nik = ? AND (expires_at IS NULL OR expires_at > ?)", nik, at).
+		Order("id ASC").
+		Find(&entries).Error; err != nil {
+		return nil, fmt.Errorf("failed to screen NIK against the watchlist: %w", err)
+	}
+	return entries, nil
+}
+
+func (r *watchlistRepositoryImpl) Update(ctx context.Context, entry *entity.Watchlist) error {
+	if err := database.Conn(ctx, r.db).Save(entry).Error; err != nil {
+		return fmt.Errorf("failed to update watchlist entry: %w", err)
+	}
+	return nil
+}
+
+func (r *watchlistRepositoryImpl) Delete(ctx context.Context, id uint64) error {
+	if err := database.Conn(ctx, r.db).Delete(&entity.Watchlist{}, id).Error; err != nil {
+		return fmt.Errorf("failed to delete watchlist entry: %w", err)
+	}
+	return nil
+}
+
+type watchlistHitRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewWatchlistHitRepository(db *gorm.DB) repository.WatchlistHitRepository {
+	return &watchlistHitRepositoryImpl{db: db}
+}
+
+func (r *watchlistHitRepositoryImpl) Create(ctx context.Context, hit *entity.WatchlistHit) error {
+	if err := database.Conn(ctx, r.db).Omit("Watchlist").Create(hit).Error; err != nil {
+		return fmt.Errorf("failed to record watchlist hit: %w", err)
+	}
+	return nil
+}
+
+func (r *watchlistHitRepositoryImpl) GetByID(ctx context.Context, id uint64) (*entity.WatchlistHit, error) {
+	var hit entity.WatchlistHit
+	if err := database.Conn(ctx, r.db).Preload("Watchlist").First(&hit, id).Error; err != nil {
+		return nil, fmt.Errorf("failed to get watchlist hit by ID: %w", err)
+	}
+	return &hit, nil
+}
+
+// GetByStatus lists hits newest first; an empty status lists every hit.
+func (r *watchlistHitRepositoryImpl) GetByStatus(ctx context.Context, status entity.WatchlistHitStatus, limit, offset int) ([]*entity.WatchlistHit, error) {
+	query := database.Conn(ctx, r.db).Preload("Watchlist")
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var hits []*entity.WatchlistHit
+	if err := query.
+		Order("created_at DESC, id DESC").
+		Limit(limit).Offset(offset).
+		Find(&hits).Error; err != nil {
+		return nil, fmt.Errorf("failed to get watchlist hits: %w", err)
+	}
+	return hits, nil
+}
+
+func (r *watchlistHitRepositoryImpl) CountHoldsByCustomer(ctx context.Context, customerID uint64) (int64, error) {
+	return r.countHolds(ctx, "customer_id = ?", customerID)
+}
+
+func (r *watchlistHitRepositoryImpl) CountHoldsByTransaction(ctx context.Context, transactionID uint64) (int64, error) {
+	return r.countHolds(ctx, "transaction_id = ?", transactionID)
+}
+
+func (r *watchlistHitRepositoryImpl) countHolds(ctx context.Context, condition string, id uint64) (int64, error) {
+	var count int64
+	if err := database.Conn(ctx, r.db).
+		Model(&entity.WatchlistHit{}).
+		Where(condition, id).
+		Where("status IN ?", []entity.WatchlistHitStatus{entity.WatchlistHitOpen, entity.WatchlistHitConfirmed}).
+		Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count watchlist holds: %w", err)
+	}
+	return count, nil
+}
+
+func (r *watchlistHitRepositoryImpl) Update(ctx context.Context, hit *entity.WatchlistHit) error {
+	if err := database.Conn(ctx, r.db).Omit("Watchlist").Save(hit).Error; err != nil {
+		return fmt.Errorf("failed to update watchlist hit: %w", err)
+	}
+	return nil
+}
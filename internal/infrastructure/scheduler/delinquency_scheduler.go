@@ -0,0 +1,58 @@
+package scheduler
+
+import (
+	"context"
+	"pt-xyz-multifinance/internal/usecase"
+	"pt-xyz-multifinance/pkg/logger"
+	"time"
+)
+
+// DelinquencyScheduler periodically recomputes days-past-due and late penalties.
+type DelinquencyScheduler struct {
+	delinquencyUseCase usecase.DelinquencyUseCase
+	interval           time.Duration
+	stop               chan struct{}
+}
+
+func NewDelinquencyScheduler(delinquencyUseCase usecase.DelinquencyUseCase, interval time.Duration) *DelinquencyScheduler {
+	return &DelinquencyScheduler{
+		delinquencyUseCase: delinquencyUseCase,
+		interval:           interval,
+		stop:               make(chan struct{}),
+	}
+}
+
+func (s *DelinquencyScheduler) Start() {
+	if s.interval <= 0 {
+		logger.Info("Delinquency scheduler disabled")
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		s.run()
+		for {
+			select {
+			case <-ticker.C:
+				s.run()
+			case <-s.stop:
+				return
+			}
+		}
+	}()
+}
+
+func (s *DelinquencyScheduler) Stop() {
+	close(s.stop)
+}
+
+func (s *DelinquencyScheduler) run() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	if _, err := s.delinquencyUseCase.RunAssessment(ctx, time.Now()); err != nil {
+		logger.Error("Delinquency assessment failed", "error", err)
+	}
+}
@@ -0,0 +1,153 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"pt-xyz-multifinance/internal/domain/repository"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SignedFilePath is the route prefix signed URLs point at.
+const SignedFilePath = "/api/v1/files/"
+
+type localFileStorage struct {
+	root    string
+	baseURL string
+	secret  []byte
+}
+
+// NewLocalFileStorage stores objects as files below root. Signed URLs are
+// built on baseURL and signed with secret.
+func NewLocalFileStorage(root, baseURL, secret string) (repository.FileStorage, error) {
+	if secret == "" {
+		return nil, fmt.Errorf("a signing secret is required for file URLs")
+	}
+
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return nil, fmt.Errorf("invalid upload path %s: %w", root, err)
+	}
+	if err := os.MkdirAll(absRoot, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create upload path %s: %w", absRoot, err)
+	}
+
+	return &localFileStorage{
+		root:    absRoot,
+		baseURL: strings.TrimRight(baseURL, "/"),
+		secret:  []byte(secret),
+	}, nil
+}
+
+// Save writes the object to a temporary file first, so a failed upload never
+// leaves a partial file under the key.
+func (s *localFileStorage) Save(ctx context.Context, key string, content io.Reader) (int64, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return 0, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return 0, fmt.Errorf("failed to create directory for %s: %w", key, err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return 0, fmt.Errorf("failed to create file for %s: %w", key, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	written, err := io.Copy(tmp, content)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to write %s: %w", key, err)
+	}
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return 0, fmt.Errorf("failed to store %s: %w", key, err)
+	}
+	return written, nil
+}
+
+func (s *localFileStorage) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", key, err)
+	}
+	return file, nil
+}
+
+func (s *localFileStorage) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete %s: %w", key, err)
+	}
+	return nil
+}
+
+func (s *localFileStorage) SignedURL(key string, ttl time.Duration) (string, time.Time, error) {
+	if _, err := s.path(key); err != nil {
+		return "", time.Time{}, err
+	}
+	if ttl <= 0 {
+		return "", time.Time{}, fmt.Errorf("signed URL lifetime must be positive")
+	}
+
+	expiresAt := time.Now().Add(ttl).Truncate(time.Second)
+	query := url.Values{}
+	query.Set("expires", strconv.FormatInt(expiresAt.Unix(), 10))
+	query.Set("signature", s.sign(key, expiresAt.Unix()))
+
+	return s.baseURL + SignedFilePath + key + "?" + query.Encode(), expiresAt, nil
+}
+
+func (s *localFileStorage) VerifySignature(key string, expires int64, signature string) error {
+	if time.Now().Unix() > expires {
+		return fmt.Errorf("link has expired")
+	}
+	if !hmac.Equal([]byte(signature), []byte(s.sign(key, expires))) {
+		return fmt.Errorf("invalid link signature")
+	}
+	return nil
+}
+
+func (s *localFileStorage) sign(key string, expires int64) string {
+	mac := hmac.New(sha256.New, s.secret)
+	fmt.Fprintf(mac, "%s\n%d", key, expires)
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// path maps a key to a file below the root and refuses keys that would
+// escape it.
+func (s *localFileStorage) path(key string) (string, error) {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return "", fmt.Errorf("invalid object key %q", key)
+	}
+	for _, part := range strings.Split(key, "/") {
+		if part == "" || part == "." || part == ".." {
+			return "", fmt.Errorf("invalid object key %q", key)
+		}
+	}
+	return filepath.Join(s.root, filepath.FromSlash(key)), nil
+}
@@ -0,0 +1,153 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"pt-xyz-multifinance/internal/domain/entity"
+	"pt-xyz-multifinance/internal/interfaces/dto"
+	"pt-xyz-multifinance/internal/usecase"
+	"pt-xyz-multifinance/pkg/response"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AdminFeeRuleHandler struct {
+	ruleUseCase usecase.AdminFeeRuleUseCase
+}
+
+func NewAdminFeeRuleHandler(ruleUseCase usecase.AdminFeeRuleUseCase) *AdminFeeRuleHandler {
+	return &AdminFeeRuleHandler{
+		ruleUseCase: ruleUseCase,
+	}
+}
+
+func (h *AdminFeeRuleHandler) CreateRule(c *gin.Context) {
+	var req dto.AdminFeeRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid request", err.Error())
+		return
+	}
+
+	rule := &entity.AdminFeeRule{IsActive: true}
+	applyAdminFeeRuleRequest(rule, &req)
+
+	if err := h.ruleUseCase.CreateRule(c.Request.Context(), rule); err != nil {
+		response.Error(c, http.StatusBadRequest, "Failed to create admin fee rule", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusCreated, "Admin fee rule created successfully", toAdminFeeRuleResponse(rule))
+}
+
+func (h *AdminFeeRuleHandler) GetAllRules(c *gin.Context) {
+	rules, err := h.ruleUseCase.GetAllRules(c.Request.Context())
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to retrieve admin fee rules", err.Error())
+		return
+	}
+
+	ruleResponses := make([]dto.AdminFeeRuleResponse, 0, len(rules))
+	for _, rule := range rules {
+		ruleResponses = append(ruleResponses, *toAdminFeeRuleResponse(rule))
+	}
+
+	response.Success(c, http.StatusOK, fmt.Sprintf("Found %d admin fee rules", len(ruleResponses)), ruleResponses)
+}
+
+func (h *AdminFeeRuleHandler) GetRuleByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid admin fee rule ID", err.Error())
+		return
+	}
+
+	rule, err := h.ruleUseCase.GetRuleByID(c.Request.Context(), id)
+	if err != nil {
+		response.Error(c, http.StatusNotFound, "Admin fee rule not found", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Admin fee rule retrieved successfully", toAdminFeeRuleResponse(rule))
+}
+
+func (h *AdminFeeRuleHandler) UpdateRule(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid admin fee rule ID", err.Error())
+		return
+	}
+
+	var req dto.AdminFeeRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid request", err.Error())
+		return
+	}
+
+	rule, err := h.ruleUseCase.GetRuleByID(c.Request.Context(), id)
+	if err != nil {
+		response.Error(c, http.StatusNotFound, "Admin fee rule not found", err.Error())
+		return
+	}
+	applyAdminFeeRuleRequest(rule, &req)
+
+	if err := h.ruleUseCase.UpdateRule(c.Request.Context(), rule); err != nil {
+		response.Error(c, http.StatusBadRequest, "Failed to update admin fee rule", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Admin fee rule updated successfully", toAdminFeeRuleResponse(rule))
+}
+
+func (h *AdminFeeRuleHandler) DeleteRule(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid admin fee rule ID", err.Error())
+		return
+	}
+
+	if err := h.ruleUseCase.DeleteRule(c.Request.Context(), id); err != nil {
+		response.Error(c, http.StatusBadRequest, "Failed to deactivate admin fee rule", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Admin fee rule deactivated successfully", nil)
+}
+
+func applyAdminFeeRuleRequest(rule *entity.AdminFeeRule, req *dto.AdminFeeRuleRequest) {
+	rule.Name = req.Name
+	rule.ProductID = req.ProductID
+	rule.TransactionSource = req.TransactionSource
+	rule.Type = req.Type
+	rule.Value = req.Value
+	rule.MinFee = req.MinFee
+	rule.MaxFee = req.MaxFee
+	rule.Tiers = make([]entity.AdminFeeTier, 0, len(req.Tiers))
+	for _, tier := range req.Tiers {
+		rule.Tiers = append(rule.Tiers, entity.AdminFeeTier{
+			UpToOTR: tier.UpToOTR,
+			Type:    tier.Type,
+			Value:   tier.Value,
+		})
+	}
+	if req.IsActive != nil {
+		rule.IsActive = *req.IsActive
+	}
+}
+
+func toAdminFeeRuleResponse(rule *entity.AdminFeeRule) *dto.AdminFeeRuleResponse {
+	return &dto.AdminFeeRuleResponse{
+		ID:                rule.ID,
+		Name:              rule.Name,
+		ProductID:         rule.ProductID,
+		TransactionSource: rule.TransactionSource,
+		Type:              rule.Type,
+		Value:             rule.Value,
+		Tiers:             rule.Tiers,
+		MinFee:            rule.MinFee,
+		MaxFee:            rule.MaxFee,
+		IsActive:          rule.IsActive,
+		CreatedAt:         rule.CreatedAt,
+		UpdatedAt:         rule.UpdatedAt,
+	}
+}
@@ -1,11 +1,14 @@
 package handler
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
+	"pt-xyz-multifinance/internal/domain/entity"
 	"pt-xyz-multifinance/internal/interfaces/dto"
 	"pt-xyz-multifinance/internal/usecase"
 	"pt-xyz-multifinance/pkg/response"
+	"pt-xyz-multifinance/pkg/utils"
 	"strings"
 	"time"
 
@@ -38,8 +41,6 @@ func (h *AuthHandler) Register(c *gin.Context) {
 	fmt.Printf("DEBUG Register Request: %+v\n", req)
 	if req.CustomerData != nil {
 		fmt.Printf("DEBUG Customer Data: %+v\n", *req.CustomerData)
-		fmt.Printf("DEBUG KTP Photo Path: %s\n", req.CustomerData.KTPPhotoPath)
-		fmt.Printf("DEBUG Selfie Photo Path: %s\n", req.CustomerData.SelfiePhotoPath)
 	}
 
 	if validationErr := h.validateRegisterRequest(&req); validationErr != "" {
@@ -49,6 +50,11 @@ func (h *AuthHandler) Register(c *gin.Context) {
 
 	user, customer, err := h.authUseCase.Register(c.Request.Context(), &req)
 	if err != nil {
+		var ageErr *entity.AgeIneligibleError
+		if errors.As(err, &ageErr) {
+			response.ErrorWithData(c, http.StatusUnprocessableEntity, "Applicant is not eligible", err.Error(), toAgeIneligibleResponse(ageErr))
+			return
+		}
 		response.Error(c, http.StatusBadRequest, "Registration failed", err.Error())
 		return
 	}
@@ -79,8 +85,13 @@ func (h *AuthHandler) Register(c *gin.Context) {
 			BirthPlace:      customer.BirthPlace,
 			BirthDate:       customer.BirthDate,
 			Salary:          customer.Salary,
+			Sex:             customer.Sex,
+			ProvinceCode:    customer.ProvinceCode,
+			RegencyCode:     customer.RegencyCode,
+			DistrictCode:    customer.DistrictCode,
 			KTPPhotoPath:    customer.KTPPhotoPath,
 			SelfiePhotoPath: customer.SelfiePhotoPath,
+			CreditBalance:   customer.CreditBalance,
 			CreatedAt:       customer.CreatedAt,
 			UpdatedAt:       customer.UpdatedAt,
 		}
@@ -100,29 +111,17 @@ func (h *AuthHandler) validateRegisterRequest(req *dto.RegisterRequest) string {
 			return "Customer data is required for CUSTOMER role"
 		}
 
-		if len(req.CustomerData.Limits) != 4 {
-			return "Customer must have exactly 4 tenor limits (1, 2, 3, 4 months)"
+		// Omitted limits are filled in from the salary-based recommendation
+		if len(req.CustomerData.Limits) == 0 {
+			return ""
 		}
 
-		tenorMap := make(map[int]bool)
+		tenorLimits := make([]utils.TenorLimit, 0, len(req.CustomerData.Limits))
 		for _, limit := range req.CustomerData.Limits {
-			if limit.TenorMonths < 1 || limit.TenorMonths > 4 {
-				return "Invalid tenor months. Only 1, 2, 3, 4 months allowed"
-			}
-			if tenorMap[limit.TenorMonths] {
-				return "Duplicate tenor detected. Each tenor (1,2,3,4) must appear exactly once"
-			}
-			tenorMap[limit.TenorMonths] = true
-			if limit.LimitAmount <= 0 {
-				return "Limit amount must be greater than 0"
-			}
+			tenorLimits = append(tenorLimits, utils.TenorLimit{Tenor: limit.TenorMonths, LimitAmount: limit.LimitAmount})
 		}
-
-		requiredTenors := []int{1, 2, 3, 4}
-		for _, required := range requiredTenors {
-			if !tenorMap[required] {
-				return "Missing required tenor: " + string(rune(required)) + " months"
-			}
+		if err := utils.ValidateTenorLimits(tenorLimits); err != nil {
+			return err.Error()
 		}
 	}
 
@@ -167,6 +166,7 @@ func (h *AuthHandler) Login(c *gin.Context) {
 			Salary:          customer.Salary,
 			KTPPhotoPath:    customer.KTPPhotoPath,
 			SelfiePhotoPath: customer.SelfiePhotoPath,
+			CreditBalance:   customer.CreditBalance,
 			CreatedAt:       customer.CreatedAt,
 			UpdatedAt:       customer.UpdatedAt,
 		}
@@ -188,6 +188,8 @@ func (h *AuthHandler) getValidationErrorMessage(validationErrors validator.Valid
 			messages = append(messages, err.Field()+" must be at least "+err.Param()+" characters")
 		case "max":
 			messages = append(messages, err.Field()+" cannot exceed "+err.Param()+" characters")
+		case "tenor":
+			messages = append(messages, "Tenor months must be one of: "+utils.ValidTenorsString()+" months")
 		case "oneof":
 			if err.Field() == "Role" {
 				messages = append(messages, "Role must be either ADMIN or CUSTOMER")
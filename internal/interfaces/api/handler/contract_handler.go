@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"pt-xyz-multifinance/internal/domain/entity"
+	"pt-xyz-multifinance/internal/interfaces/dto"
+	"pt-xyz-multifinance/internal/usecase"
+	"pt-xyz-multifinance/pkg/response"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ContractHandler struct {
+	contractUseCase    usecase.ContractDocumentUseCase
+	transactionUseCase usecase.TransactionUseCase
+}
+
+func NewContractHandler(contractUseCase usecase.ContractDocumentUseCase, transactionUseCase usecase.TransactionUseCase) *ContractHandler {
+	return &ContractHandler{
+		contractUseCase:    contractUseCase,
+		transactionUseCase: transactionUseCase,
+	}
+}
+
+func (h *ContractHandler) DownloadContract(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid transaction ID", "Transaction ID must be a valid number")
+		return
+	}
+
+	transaction, err := h.transactionUseCase.GetTransactionByID(c.Request.Context(), id)
+	if err != nil {
+		response.Error(c, http.StatusNotFound, "Transaction not found", err.Error())
+		return
+	}
+
+	role, _ := c.Get("role")
+	if role.(string) == string(entity.RoleCustomer) {
+		customerID, exists := c.Get("customer_id")
+		if !exists || transaction.CustomerID != customerID.(uint64) {
+			response.Error(c, http.StatusForbidden, "Access denied", "You can only access your own transactions")
+			return
+		}
+	}
+
+	if !transaction.HasBeenApproved() {
+		response.Error(c, http.StatusConflict, "Contract not available", fmt.Sprintf("Transaction is %s; contracts are issued once it is approved", transaction.Status))
+		return
+	}
+
+	document, err := h.contractUseCase.GetContractDocument(c.Request.Context(), id)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to retrieve contract", err.Error())
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", document.FileName))
+	c.Header("X-Content-SHA256", document.SHA256)
+	c.Header("X-Contract-Template-Version", strconv.Itoa(document.TemplateVersion))
+	c.Data(http.StatusOK, document.ContentType, document.Content)
+}
+
+func (h *ContractHandler) CreateTemplate(c *gin.Context) {
+	var req dto.CreateContractTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid request", err.Error())
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+
+	template := &entity.ContractTemplate{
+		Name:      req.Name,
+		Body:      req.Body,
+		CreatedBy: userID.(uint64),
+	}
+
+	if err := h.contractUseCase.CreateTemplate(c.Request.Context(), template); err != nil {
+		response.Error(c, http.StatusBadRequest, "Failed to create contract template", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusCreated, "Contract template created successfully", toContractTemplateResponse(template))
+}
+
+func (h *ContractHandler) GetTemplates(c *gin.Context) {
+	templates, err := h.contractUseCase.GetTemplates(c.Request.Context())
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to retrieve contract templates", err.Error())
+		return
+	}
+
+	templateResponses := make([]dto.ContractTemplateResponse, 0, len(templates))
+	for _, template := range templates {
+		templateResponses = append(templateResponses, toContractTemplateResponse(template))
+	}
+
+	response.Success(c, http.StatusOK, fmt.Sprintf("Found %d contract templates", len(templateResponses)), templateResponses)
+}
+
+func (h *ContractHandler) UpdateTemplateStatus(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid template ID", "Template ID must be a valid number")
+		return
+	}
+
+	var req dto.UpdateContractTemplateStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid request", err.Error())
+		return
+	}
+
+	template, err := h.contractUseCase.SetTemplateActive(c.Request.Context(), id, *req.IsActive)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Failed to update contract template", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Contract template updated successfully", toContractTemplateResponse(template))
+}
+
+func toContractTemplateResponse(template *entity.ContractTemplate) dto.ContractTemplateResponse {
+	return dto.ContractTemplateResponse{
+		ID:        template.ID,
+		Version:   template.Version,
+		Name:      template.Name,
+		Body:      template.Body,
+		IsActive:  template.IsActive,
+		CreatedBy: template.CreatedBy,
+		CreatedAt: template.CreatedAt,
+	}
+}
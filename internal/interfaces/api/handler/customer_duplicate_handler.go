@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"pt-xyz-multifinance/internal/domain/entity"
+	"pt-xyz-multifinance/internal/interfaces/dto"
+	"pt-xyz-multifinance/internal/usecase"
+	"pt-xyz-multifinance/pkg/response"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CustomerDuplicateHandler struct {
+	duplicateUseCase usecase.CustomerDuplicateUseCase
+}
+
+func NewCustomerDuplicateHandler(duplicateUseCase usecase.CustomerDuplicateUseCase) *CustomerDuplicateHandler {
+	return &CustomerDuplicateHandler{
+		duplicateUseCase: duplicateUseCase,
+	}
+}
+
+// GetClusters lists suspected duplicate clusters built from the flags in the
+// requested status, OPEN by default.
+func (h *CustomerDuplicateHandler) GetClusters(c *gin.Context) {
+	status := entity.DuplicateStatus(strings.ToUpper(c.DefaultQuery("status", string(entity.DuplicateOpen))))
+	switch status {
+	case entity.DuplicateOpen, entity.DuplicateConfirmed, entity.DuplicateDismissed:
+	default:
+		response.Error(c, http.StatusBadRequest, "Invalid status parameter", "Status must be OPEN, CONFIRMED or DISMISSED")
+		return
+	}
+
+	clusters, err := h.duplicateUseCase.GetClusters(c.Request.Context(), status)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to retrieve duplicate clusters", err.Error())
+		return
+	}
+
+	clusterResponses := make([]dto.DuplicateClusterResponse, 0, len(clusters))
+	for _, cluster := range clusters {
+		clusterResponse := dto.DuplicateClusterResponse{
+			MaxScore:  cluster.MaxScore,
+			Customers: make([]dto.DuplicateCustomerResponse, 0, len(cluster.Customers)),
+			Flags:     make([]dto.CustomerDuplicateResponse, 0, len(cluster.Flags)),
+		}
+		for _, customer := range cluster.Customers {
+			clusterResponse.Customers = append(clusterResponse.Customers, dto.DuplicateCustomerResponse{
+				ID:         customer.ID,
+				NIK:        customer.NIK,
+				FullName:   customer.FullName,
+				LegalName:  customer.LegalName,
+				BirthPlace: customer.BirthPlace,
+				BirthDate:  customer.BirthDate,
+				KYCStatus:  customer.KYCStatus,
+				CreatedAt:  customer.CreatedAt,
+			})
+		}
+		for _, flag := range cluster.Flags {
+			clusterResponse.Flags = append(clusterResponse.Flags, toCustomerDuplicateResponse(flag))
+		}
+		clusterResponses = append(clusterResponses, clusterResponse)
+	}
+
+	response.Success(c, http.StatusOK, fmt.Sprintf("Found %d duplicate clusters", len(clusterResponses)), clusterResponses)
+}
+
+func (h *CustomerDuplicateHandler) ResolveDuplicate(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid duplicate flag ID", err.Error())
+		return
+	}
+
+	var req dto.ResolveDuplicateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid request", err.Error())
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+
+	duplicate, err := h.duplicateUseCase.ResolveDuplicate(c.Request.Context(), id, userID.(uint64), req.Status, req.Note)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Failed to resolve duplicate flag", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Duplicate flag resolved", toCustomerDuplicateResponse(duplicate))
+}
+
+func toCustomerDuplicateResponse(duplicate *entity.CustomerDuplicate) dto.CustomerDuplicateResponse {
+	return dto.CustomerDuplicateResponse{
+		ID:                duplicate.ID,
+		CustomerID:        duplicate.CustomerID,
+		MatchedCustomerID: duplicate.MatchedCustomerID,
+		Score:             duplicate.Score,
+		NameSimilarity:    duplicate.NameSimilarity,
+		BirthDateMatch:    duplicate.BirthDateMatch,
+		BirthPlaceMatch:   duplicate.BirthPlaceMatch,
+		NIKDistance:       duplicate.NIKDistance,
+		Reasons:           duplicate.Reasons,
+		Status:            duplicate.Status,
+		ResolutionNote:    duplicate.ResolutionNote,
+		ResolvedBy:        duplicate.ResolvedBy,
+		ResolvedAt:        duplicate.ResolvedAt,
+		CreatedAt:         duplicate.CreatedAt,
+	}
+}
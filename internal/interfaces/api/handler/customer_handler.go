@@ -1,12 +1,15 @@
 package handler
 
 import (
+	"errors"
 	"fmt"
+	"math"
 	"net/http"
 	"pt-xyz-multifinance/internal/domain/entity"
 	"pt-xyz-multifinance/internal/interfaces/dto"
 	"pt-xyz-multifinance/internal/usecase"
 	"pt-xyz-multifinance/pkg/response"
+	"pt-xyz-multifinance/pkg/utils"
 	"strconv"
 	"strings"
 	"time"
@@ -16,12 +19,14 @@ import (
 )
 
 type CustomerHandler struct {
-	customerUseCase usecase.CustomerUseCase
+	customerUseCase     usecase.CustomerUseCase
+	limitRequestUseCase usecase.LimitChangeRequestUseCase
 }
 
-func NewCustomerHandler(customerUseCase usecase.CustomerUseCase) *CustomerHandler {
+func NewCustomerHandler(customerUseCase usecase.CustomerUseCase, limitRequestUseCase usecase.LimitChangeRequestUseCase) *CustomerHandler {
 	return &CustomerHandler{
-		customerUseCase: customerUseCase,
+		customerUseCase:     customerUseCase,
+		limitRequestUseCase: limitRequestUseCase,
 	}
 }
 
@@ -55,14 +60,12 @@ func (h *CustomerHandler) CreateCustomer(c *gin.Context) {
 	}
 
 	customer := &entity.Customer{
-		NIK:             req.NIK,
-		FullName:        req.FullName,
-		LegalName:       req.LegalName,
-		BirthPlace:      req.BirthPlace,
-		BirthDate:       birthDate,
-		Salary:          req.Salary,
-		KTPPhotoPath:    req.KTPPhotoPath,
-		SelfiePhotoPath: req.SelfiePhotoPath,
+		NIK:        req.NIK,
+		FullName:   req.FullName,
+		LegalName:  req.LegalName,
+		BirthPlace: req.BirthPlace,
+		BirthDate:  birthDate,
+		Salary:     req.Salary,
 	}
 
 	var limits []*entity.CustomerLimit
@@ -74,6 +77,11 @@ func (h *CustomerHandler) CreateCustomer(c *gin.Context) {
 	}
 
 	if err := h.customerUseCase.CreateCustomer(c.Request.Context(), customer, limits); err != nil {
+		var ageErr *entity.AgeIneligibleError
+		if errors.As(err, &ageErr) {
+			response.ErrorWithData(c, http.StatusUnprocessableEntity, "Applicant is not eligible", err.Error(), toAgeIneligibleResponse(ageErr))
+			return
+		}
 		response.Error(c, http.StatusInternalServerError, "Failed to create customer", err.Error())
 		return
 	}
@@ -94,7 +102,16 @@ func (h *CustomerHandler) GetMyProfile(c *gin.Context) {
 		return
 	}
 
-	response.Success(c, http.StatusOK, "Customer profile retrieved successfully", h.toCustomerResponse(customer))
+	profile := h.toCustomerResponse(customer)
+
+	requests, err := h.limitRequestUseCase.GetRequestsByCustomerID(c.Request.Context(), customer.ID)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to retrieve limit change requests", err.Error())
+		return
+	}
+	profile.LimitChangeRequests = toLimitChangeRequestResponses(h.limitRequestUseCase, requests)
+
+	response.Success(c, http.StatusOK, "Customer profile retrieved successfully", profile)
 }
 
 func (h *CustomerHandler) GetCustomerByID(c *gin.Context) {
@@ -148,19 +165,170 @@ func (h *CustomerHandler) GetCustomerLimits(c *gin.Context) {
 
 	var limitResponses []dto.CustomerLimitResponse
 	for _, limit := range limits {
-		limitResponses = append(limitResponses, dto.CustomerLimitResponse{
-			ID:              limit.ID,
-			CustomerID:      limit.CustomerID,
-			TenorMonths:     limit.TenorMonths,
-			LimitAmount:     limit.LimitAmount,
-			UsedAmount:      limit.UsedAmount,
-			AvailableAmount: limit.AvailableAmount(),
-		})
+		limitResponses = append(limitResponses, h.toCustomerLimitResponse(limit))
 	}
 
 	response.Success(c, http.StatusOK, fmt.Sprintf("Found %d tenor limits for customer", len(limitResponses)), limitResponses)
 }
 
+func (h *CustomerHandler) GetLimitHistory(c *gin.Context) {
+	id, tenor, ok := h.parseCustomerTenor(c)
+	if !ok {
+		return
+	}
+
+	role, _ := c.Get("role")
+	if role.(string) == string(entity.RoleCustomer) {
+		customerID, exists := c.Get("customer_id")
+		if !exists || customerID.(uint64) != id {
+			response.Error(c, http.StatusForbidden, "Access denied", "You can only access your own limits")
+			return
+		}
+	}
+
+	limit, movements, err := h.customerUseCase.GetLimitHistory(c.Request.Context(), id, tenor)
+	if err != nil {
+		response.Error(c, http.StatusNotFound, "Customer limit not found", err.Error())
+		return
+	}
+
+	history := dto.LimitHistoryResponse{
+		Limit:     h.toCustomerLimitResponse(limit),
+		Movements: make([]dto.LimitMovementResponse, 0, len(movements)),
+	}
+	for _, movement := range movements {
+		history.LedgerTotal += movement.Amount
+		history.Movements = append(history.Movements, toLimitMovementResponse(movement))
+	}
+	history.LedgerTotal = math.Round(history.LedgerTotal*100) / 100
+	history.InSync = history.LedgerTotal == limit.UsedAmount
+
+	response.Success(c, http.StatusOK, fmt.Sprintf("Found %d limit movements", len(history.Movements)), history)
+}
+
+func (h *CustomerHandler) AdjustUsedAmount(c *gin.Context) {
+	id, tenor, ok := h.parseCustomerTenor(c)
+	if !ok {
+		return
+	}
+
+	var req dto.AdjustUsedAmountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid request", err.Error())
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+
+	movement, err := h.customerUseCase.AdjustUsedAmount(c.Request.Context(), id, tenor, req.Amount, userID.(uint64), req.Reason)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Failed to adjust used amount", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusCreated, "Used amount adjusted successfully", toLimitMovementResponse(movement))
+}
+
+func (h *CustomerHandler) UpdateLimitAmount(c *gin.Context) {
+	id, tenor, ok := h.parseCustomerTenor(c)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateLimitAmountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid request", err.Error())
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+
+	change, err := h.customerUseCase.UpdateLimitAmount(c.Request.Context(), id, tenor, req.LimitAmount, userID.(uint64), req.Reason)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Failed to update customer limit", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Customer limit updated successfully", toLimitChangeResponse(change))
+}
+
+func (h *CustomerHandler) GetLimitChanges(c *gin.Context) {
+	id, tenor, ok := h.parseCustomerTenor(c)
+	if !ok {
+		return
+	}
+
+	changes, err := h.customerUseCase.GetLimitChanges(c.Request.Context(), id, tenor)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to retrieve limit changes", err.Error())
+		return
+	}
+
+	changeResponses := make([]dto.LimitChangeResponse, 0, len(changes))
+	for _, change := range changes {
+		changeResponses = append(changeResponses, toLimitChangeResponse(change))
+	}
+
+	response.Success(c, http.StatusOK, fmt.Sprintf("Found %d limit changes", len(changeResponses)), changeResponses)
+}
+
+func (h *CustomerHandler) RecommendLimits(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid customer ID", "Customer ID must be a valid number")
+		return
+	}
+
+	recommendation, err := h.customerUseCase.RecommendLimits(c.Request.Context(), id)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Failed to recommend limits", err.Error())
+		return
+	}
+
+	current := make(map[int]float64)
+	if limits, err := h.customerUseCase.GetCustomerLimits(c.Request.Context(), id); err == nil {
+		for _, limit := range limits {
+			current[limit.TenorMonths] = limit.LimitAmount
+		}
+	}
+
+	recommendationResponse := dto.LimitRecommendationResponse{
+		CustomerID: id,
+		Salary:     recommendation.Salary,
+		Age:        recommendation.Age,
+		Limits:     make([]dto.RecommendedLimitResponse, 0, len(recommendation.Limits)),
+		Reasoning:  recommendation.Reasoning,
+	}
+	for _, limit := range recommendation.Limits {
+		recommendationResponse.Limits = append(recommendationResponse.Limits, dto.RecommendedLimitResponse{
+			TenorMonths:        limit.TenorMonths,
+			CurrentLimitAmount: current[limit.TenorMonths],
+			RecommendedAmount:  limit.LimitAmount,
+			Multiplier:         limit.Multiplier,
+			AgeFactor:          limit.AgeFactor,
+			Reasoning:          limit.Reasoning,
+		})
+	}
+
+	response.Success(c, http.StatusOK, "Limit recommendation generated", recommendationResponse)
+}
+
+func (h *CustomerHandler) parseCustomerTenor(c *gin.Context) (uint64, int, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid customer ID", "Customer ID must be a valid number")
+		return 0, 0, false
+	}
+
+	tenor, err := strconv.Atoi(c.Param("tenor"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid tenor", "Tenor must be a valid number of months")
+		return 0, 0, false
+	}
+
+	return id, tenor, true
+}
+
 func (h *CustomerHandler) GetAllCustomers(c *gin.Context) {
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
 	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
@@ -190,34 +358,28 @@ func (h *CustomerHandler) GetAllCustomers(c *gin.Context) {
 }
 
 func (h *CustomerHandler) validateCustomerRequest(req *dto.CreateCustomerRequest) string {
-	if len(req.Limits) < 4 {
-		missingCount := 4 - len(req.Limits)
-		return fmt.Sprintf("Incomplete tenor limits. You provided %d tenor(s), but PT XYZ requires exactly 4 tenors (1, 2, 3, 4 months). Missing %d tenor(s).",
-			len(req.Limits), missingCount)
+	// Omitted limits are filled in from the salary-based recommendation
+	if len(req.Limits) == 0 {
+		return ""
 	}
 
-	if len(req.Limits) > 4 {
-		extraCount := len(req.Limits) - 4
-		return fmt.Sprintf("Too many tenor limits. You provided %d tenor(s), but PT XYZ only accepts exactly 4 tenors (1, 2, 3, 4 months). Please remove %d tenor(s).",
-			len(req.Limits), extraCount)
-	}
+	// Offered tenors left out are also filled in from the recommendation
+	offered := utils.ValidTenorsString()
 
 	tenorMap := make(map[int]bool)
-	providedTenors := make([]int, 0)
 
 	for i, limit := range req.Limits {
-		if limit.TenorMonths < 1 || limit.TenorMonths > 4 {
-			return fmt.Sprintf("Invalid tenor at position %d: %d months is not allowed. PT XYZ only accepts tenors: 1, 2, 3, 4 months.",
-				i+1, limit.TenorMonths)
+		if !utils.IsValidTenor(limit.TenorMonths) {
+			return fmt.Sprintf("Invalid tenor at position %d: %d months is not allowed. PT XYZ only accepts tenors: %s months.",
+				i+1, limit.TenorMonths, offered)
 		}
 
 		if tenorMap[limit.TenorMonths] {
-			return fmt.Sprintf("Duplicate tenor detected: %d months appears more than once. Each tenor (1, 2, 3, 4) must appear exactly once.",
-				limit.TenorMonths)
+			return fmt.Sprintf("Duplicate tenor detected: %d months appears more than once. Each tenor (%s) must appear exactly once.",
+				limit.TenorMonths, offered)
 		}
 
 		tenorMap[limit.TenorMonths] = true
-		providedTenors = append(providedTenors, limit.TenorMonths)
 
 		if limit.LimitAmount <= 0 {
 			return fmt.Sprintf("Invalid limit amount for tenor %d months: %.2f. Limit amount must be greater than 0.",
@@ -225,20 +387,6 @@ func (h *CustomerHandler) validateCustomerRequest(req *dto.CreateCustomerRequest
 		}
 	}
 
-	requiredTenors := []int{1, 2, 3, 4}
-	missingTenors := make([]int, 0)
-
-	for _, required := range requiredTenors {
-		if !tenorMap[required] {
-			missingTenors = append(missingTenors, required)
-		}
-	}
-
-	if len(missingTenors) > 0 {
-		return fmt.Sprintf("Missing required tenors: %v months. PT XYZ requires ALL tenors (1, 2, 3, 4 months) to be provided. You provided: %v months.",
-			missingTenors, providedTenors)
-	}
-
 	return ""
 }
 
@@ -253,7 +401,7 @@ func (h *CustomerHandler) getValidationErrorMessage(validationErrors validator.V
 			if err.Field() == "NIK" {
 				messages = append(messages, "NIK must be exactly 16 digits")
 			} else if err.Field() == "Limits" {
-				messages = append(messages, "PT XYZ requires exactly 4 tenor limits (1, 2, 3, 4 months)")
+				messages = append(messages, fmt.Sprintf("PT XYZ requires one limit for each tenor (%s months)", utils.ValidTenorsString()))
 			} else {
 				messages = append(messages, fmt.Sprintf("%s must be exactly %s characters", getFieldDisplayName(err.Field()), err.Param()))
 			}
@@ -267,18 +415,14 @@ func (h *CustomerHandler) getValidationErrorMessage(validationErrors validator.V
 			}
 		case "max":
 			if err.Field() == "Limits" {
-				messages = append(messages, "Maximum 4 tenor limits allowed (PT XYZ policy)")
-			} else if err.Field() == "TenorMonths" {
-				messages = append(messages, "Tenor months cannot exceed 4 (PT XYZ only offers 1, 2, 3, 4 months)")
+				messages = append(messages, fmt.Sprintf("Maximum %d tenor limits allowed (PT XYZ policy)", len(utils.ValidTenors())))
 			} else {
 				messages = append(messages, fmt.Sprintf("%s cannot exceed %s", getFieldDisplayName(err.Field()), err.Param()))
 			}
 		case "oneof":
-			if err.Field() == "TenorMonths" {
-				messages = append(messages, "Tenor months must be one of: 1, 2, 3, 4 months only")
-			} else {
-				messages = append(messages, fmt.Sprintf("%s must be one of the allowed values", getFieldDisplayName(err.Field())))
-			}
+			messages = append(messages, fmt.Sprintf("%s must be one of the allowed values", getFieldDisplayName(err.Field())))
+		case "tenor":
+			messages = append(messages, fmt.Sprintf("Tenor months must be one of: %s months only", utils.ValidTenorsString()))
 		default:
 			messages = append(messages, fmt.Sprintf("%s is invalid: %s", getFieldDisplayName(err.Field()), err.Tag()))
 		}
@@ -312,20 +456,68 @@ func getFieldDisplayName(field string) string {
 	}
 }
 
+func (h *CustomerHandler) toCustomerLimitResponse(limit *entity.CustomerLimit) dto.CustomerLimitResponse {
+	return dto.CustomerLimitResponse{
+		ID:              limit.ID,
+		CustomerID:      limit.CustomerID,
+		TenorMonths:     limit.TenorMonths,
+		LimitAmount:     limit.LimitAmount,
+		UsedAmount:      limit.UsedAmount,
+		AvailableAmount: limit.AvailableAmount(),
+	}
+}
+
+func toLimitMovementResponse(movement *entity.LimitMovement) dto.LimitMovementResponse {
+	return dto.LimitMovementResponse{
+		ID:              movement.ID,
+		Type:            movement.Type,
+		Amount:          movement.Amount,
+		UsedAmountAfter: movement.UsedAmountAfter,
+		TransactionID:   movement.TransactionID,
+		PaymentID:       movement.PaymentID,
+		AdminID:         movement.AdminID,
+		Reason:          movement.Reason,
+		CreatedAt:       movement.CreatedAt,
+	}
+}
+
+func toLimitChangeResponse(change *entity.LimitChange) dto.LimitChangeResponse {
+	return dto.LimitChangeResponse{
+		ID:             change.ID,
+		CustomerID:     change.CustomerID,
+		TenorMonths:    change.TenorMonths,
+		OldLimitAmount: change.OldLimitAmount,
+		NewLimitAmount: change.NewLimitAmount,
+		ChangedBy:      change.ChangedBy,
+		Reason:         change.Reason,
+		CreatedAt:      change.CreatedAt,
+	}
+}
+
 func (h *CustomerHandler) toCustomerResponse(customer *entity.Customer) *dto.CustomerResponse {
 	response := &dto.CustomerResponse{
-		ID:              customer.ID,
-		UserID:          customer.UserID,
-		NIK:             customer.NIK,
-		FullName:        customer.FullName,
-		LegalName:       customer.LegalName,
-		BirthPlace:      customer.BirthPlace,
-		BirthDate:       customer.BirthDate,
-		Salary:          customer.Salary,
-		KTPPhotoPath:    customer.KTPPhotoPath,
-		SelfiePhotoPath: customer.SelfiePhotoPath,
-		CreatedAt:       customer.CreatedAt,
-		UpdatedAt:       customer.UpdatedAt,
+		ID:               customer.ID,
+		UserID:           customer.UserID,
+		NIK:              customer.NIK,
+		FullName:         customer.FullName,
+		LegalName:        customer.LegalName,
+		BirthPlace:       customer.BirthPlace,
+		BirthDate:        customer.BirthDate,
+		Salary:           customer.Salary,
+		Sex:              customer.Sex,
+		ProvinceCode:     customer.ProvinceCode,
+		RegencyCode:      customer.RegencyCode,
+		DistrictCode:     customer.DistrictCode,
+		KTPPhotoPath:     customer.KTPPhotoPath,
+		SelfiePhotoPath:  customer.SelfiePhotoPath,
+		KTPUploadedAt:    customer.KTPUploadedAt,
+		SelfieUploadedAt: customer.SelfieUploadedAt,
+		KYCStatus:        customer.KYCStatus,
+		KYCReviewReason:  customer.KYCReviewReason,
+		KYCReviewedAt:    customer.KYCReviewedAt,
+		CreditBalance:    customer.CreditBalance,
+		CreatedAt:        customer.CreatedAt,
+		UpdatedAt:        customer.UpdatedAt,
 	}
 
 	if customer.User.ID != 0 {
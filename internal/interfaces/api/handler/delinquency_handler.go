@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"net/http"
+	"pt-xyz-multifinance/internal/usecase"
+	"pt-xyz-multifinance/pkg/response"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DelinquencyHandler struct {
+	delinquencyUseCase usecase.DelinquencyUseCase
+}
+
+func NewDelinquencyHandler(delinquencyUseCase usecase.DelinquencyUseCase) *DelinquencyHandler {
+	return &DelinquencyHandler{
+		delinquencyUseCase: delinquencyUseCase,
+	}
+}
+
+func (h *DelinquencyHandler) RunAssessment(c *gin.Context) {
+	asOf := time.Now()
+	if asOfParam := c.Query("as_of"); asOfParam != "" {
+		parsed, err := time.Parse("2006-01-02", asOfParam)
+		if err != nil {
+			response.Error(c, http.StatusBadRequest, "Invalid as_of date format", "as_of must be in YYYY-MM-DD format")
+			return
+		}
+		asOf = parsed
+	}
+
+	result, err := h.delinquencyUseCase.RunAssessment(c.Request.Context(), asOf)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to run delinquency assessment", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Delinquency assessment completed", result)
+}
+
+func (h *DelinquencyHandler) AssessTransaction(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid transaction ID", err.Error())
+		return
+	}
+
+	transaction, err := h.delinquencyUseCase.AssessTransaction(c.Request.Context(), id, time.Now())
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Failed to assess transaction", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Transaction delinquency assessed", gin.H{
+		"transaction_id":  transaction.ID,
+		"status":          transaction.Status,
+		"days_past_due":   transaction.DaysPastDue,
+		"accrued_penalty": transaction.AccruedPenalty,
+	})
+}
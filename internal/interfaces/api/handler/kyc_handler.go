@@ -0,0 +1,264 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"pt-xyz-multifinance/internal/domain/entity"
+	"pt-xyz-multifinance/internal/interfaces/dto"
+	"pt-xyz-multifinance/internal/usecase"
+	"pt-xyz-multifinance/pkg/response"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type KYCHandler struct {
+	kycUseCase usecase.KYCDocumentUseCase
+	maxSize    int64
+}
+
+func NewKYCHandler(kycUseCase usecase.KYCDocumentUseCase, maxSize int64) *KYCHandler {
+	return &KYCHandler{
+		kycUseCase: kycUseCase,
+		maxSize:    maxSize,
+	}
+}
+
+func (h *KYCHandler) UploadKTP(c *gin.Context) {
+	h.uploadOwnDocument(c, entity.KYCDocumentKTP)
+}
+
+func (h *KYCHandler) UploadSelfie(c *gin.Context) {
+	h.uploadOwnDocument(c, entity.KYCDocumentSelfie)
+}
+
+// UploadCustomerDocument lets an admin upload the images of a customer
+// created without a user account, e.g. /admin/customers/:id/kyc/documents/ktp.
+func (h *KYCHandler) UploadCustomerDocument(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid customer ID", "Customer ID must be a valid number")
+		return
+	}
+
+	docType := entity.KYCDocumentType(strings.ToUpper(c.Param("type")))
+	if !docType.IsValid() {
+		response.Error(c, http.StatusBadRequest, "Invalid document type", fmt.Sprintf("Unknown KYC document type: %s", c.Param("type")))
+		return
+	}
+
+	h.uploadDocument(c, id, docType)
+}
+
+func (h *KYCHandler) uploadOwnDocument(c *gin.Context, docType entity.KYCDocumentType) {
+	customerID, exists := c.Get("customer_id")
+	if !exists {
+		response.Error(c, http.StatusForbidden, "Customer ID not found", "Only customers can upload KYC documents")
+		return
+	}
+
+	h.uploadDocument(c, customerID.(uint64), docType)
+}
+
+// uploadDocument reads the image from the multipart field "file".
+func (h *KYCHandler) uploadDocument(c *gin.Context, customerID uint64, docType entity.KYCDocumentType) {
+	// Leave room for the multipart envelope around the file itself
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.maxSize+64*1024)
+
+	file, header, err := c.Request.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			response.Error(c, http.StatusRequestEntityTooLarge, "File too large", fmt.Sprintf("Files may be at most %d bytes", h.maxSize))
+			return
+		}
+		response.Error(c, http.StatusBadRequest, "Invalid upload", "Send the image as multipart form field \"file\"")
+		return
+	}
+	defer file.Close()
+
+	link, err := h.kycUseCase.UploadDocument(c.Request.Context(), customerID, docType, file, header.Size)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Failed to upload KYC document", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusCreated, fmt.Sprintf("%s uploaded successfully", docType), toKYCDocumentResponse(link))
+}
+
+func (h *KYCHandler) GetMyDocuments(c *gin.Context) {
+	customerID, exists := c.Get("customer_id")
+	if !exists {
+		response.Error(c, http.StatusBadRequest, "Customer ID not found", "Customer data not available")
+		return
+	}
+
+	h.respondWithDocuments(c, customerID.(uint64))
+}
+
+func (h *KYCHandler) GetCustomerDocuments(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid customer ID", "Customer ID must be a valid number")
+		return
+	}
+
+	h.respondWithDocuments(c, id)
+}
+
+func (h *KYCHandler) respondWithDocuments(c *gin.Context, customerID uint64) {
+	links, err := h.kycUseCase.GetDocumentLinks(c.Request.Context(), customerID)
+	if err != nil {
+		response.Error(c, http.StatusNotFound, "Failed to retrieve KYC documents", err.Error())
+		return
+	}
+
+	documents := make([]dto.KYCDocumentResponse, 0, len(links))
+	for _, link := range links {
+		documents = append(documents, toKYCDocumentResponse(link))
+	}
+
+	response.Success(c, http.StatusOK, fmt.Sprintf("Found %d KYC documents", len(documents)), documents)
+}
+
+func (h *KYCHandler) GetReviewQueue(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	if limit < 1 || limit > 100 {
+		response.Error(c, http.StatusBadRequest, "Invalid limit parameter", "Limit must be between 1 and 100")
+		return
+	}
+
+	if offset < 0 {
+		response.Error(c, http.StatusBadRequest, "Invalid offset parameter", "Offset must be 0 or greater")
+		return
+	}
+
+	status := entity.KYCStatus(strings.ToUpper(c.DefaultQuery("status", string(entity.KYCPendingReview))))
+
+	items, err := h.kycUseCase.GetReviewQueue(c.Request.Context(), status, limit, offset)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Failed to retrieve KYC review queue", err.Error())
+		return
+	}
+
+	reviews := make([]dto.KYCReviewResponse, 0, len(items))
+	for _, item := range items {
+		reviews = append(reviews, toKYCReviewResponse(item.Customer, item.Documents))
+	}
+
+	response.Success(c, http.StatusOK, fmt.Sprintf("Found %d %s customers", len(reviews), status), reviews)
+}
+
+func (h *KYCHandler) ApproveCustomer(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid customer ID", "Customer ID must be a valid number")
+		return
+	}
+
+	var req dto.ApproveKYCRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		response.Error(c, http.StatusBadRequest, "Invalid request", err.Error())
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+
+	customer, err := h.kycUseCase.ApproveCustomer(c.Request.Context(), id, userID.(uint64), req.Reason)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Failed to approve KYC", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Customer KYC verified", toKYCReviewResponse(customer, nil))
+}
+
+func (h *KYCHandler) RejectCustomer(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid customer ID", "Customer ID must be a valid number")
+		return
+	}
+
+	var req dto.RejectKYCRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid request", err.Error())
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+
+	customer, err := h.kycUseCase.RejectCustomer(c.Request.Context(), id, userID.(uint64), req.Reason)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Failed to reject KYC", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Customer KYC rejected", toKYCReviewResponse(customer, nil))
+}
+
+// ServeSignedFile streams a stored file to anyone holding a valid signed URL.
+func (h *KYCHandler) ServeSignedFile(c *gin.Context) {
+	key := strings.TrimPrefix(c.Param("key"), "/")
+
+	expires, err := strconv.ParseInt(c.Query("expires"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusForbidden, "Access denied", "Missing or invalid link expiry")
+		return
+	}
+
+	file, contentType, err := h.kycUseCase.OpenSignedDocument(c.Request.Context(), key, expires, c.Query("signature"))
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidFileLink) {
+			response.Error(c, http.StatusForbidden, "Access denied", err.Error())
+			return
+		}
+		response.Error(c, http.StatusNotFound, "File not found", err.Error())
+		return
+	}
+	defer file.Close()
+
+	c.Header("Content-Type", contentType)
+	c.Header("Cache-Control", "private, no-store")
+	c.Status(http.StatusOK)
+	if _, err := io.Copy(c.Writer, file); err != nil {
+		c.Error(err)
+	}
+}
+
+func toKYCDocumentResponse(link *usecase.KYCDocumentLink) dto.KYCDocumentResponse {
+	return dto.KYCDocumentResponse{
+		DocumentType: link.DocumentType,
+		ObjectKey:    link.ObjectKey,
+		Checksum:     link.Checksum,
+		UploadedAt:   link.UploadedAt,
+		URL:          link.URL,
+		URLExpiresAt: link.ExpiresAt,
+	}
+}
+
+func toKYCReviewResponse(customer *entity.Customer, links []*usecase.KYCDocumentLink) dto.KYCReviewResponse {
+	review := dto.KYCReviewResponse{
+		CustomerID:   customer.ID,
+		NIK:          customer.NIK,
+		FullName:     customer.FullName,
+		LegalName:    customer.LegalName,
+		BirthPlace:   customer.BirthPlace,
+		BirthDate:    customer.BirthDate,
+		KYCStatus:    customer.KYCStatus,
+		SubmittedAt:  customer.KYCSubmittedAt,
+		ReviewReason: customer.KYCReviewReason,
+		ReviewedBy:   customer.KYCReviewedBy,
+		ReviewedAt:   customer.KYCReviewedAt,
+		Documents:    make([]dto.KYCDocumentResponse, 0, len(links)),
+	}
+	for _, link := range links {
+		review.Documents = append(review.Documents, toKYCDocumentResponse(link))
+	}
+	return review
+}
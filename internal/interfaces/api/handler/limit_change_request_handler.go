@@ -0,0 +1,225 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"pt-xyz-multifinance/internal/domain/entity"
+	"pt-xyz-multifinance/internal/interfaces/dto"
+	"pt-xyz-multifinance/internal/usecase"
+	"pt-xyz-multifinance/pkg/response"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type LimitChangeRequestHandler struct {
+	requestUseCase usecase.LimitChangeRequestUseCase
+	maxSize        int64
+}
+
+func NewLimitChangeRequestHandler(requestUseCase usecase.LimitChangeRequestUseCase, maxSize int64) *LimitChangeRequestHandler {
+	return &LimitChangeRequestHandler{
+		requestUseCase: requestUseCase,
+		maxSize:        maxSize,
+	}
+}
+
+func (h *LimitChangeRequestHandler) SubmitRequest(c *gin.Context) {
+	customerID, exists := c.Get("customer_id")
+	if !exists {
+		response.Error(c, http.StatusForbidden, "Customer ID not found", "Only customers can request a limit change")
+		return
+	}
+
+	var req dto.CreateLimitChangeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid request", err.Error())
+		return
+	}
+
+	request := &entity.LimitChangeRequest{
+		CustomerID:           customerID.(uint64),
+		TenorMonths:          req.TenorMonths,
+		RequestedLimitAmount: req.RequestedLimitAmount,
+		UpdatedSalary:        req.UpdatedSalary,
+		CustomerNote:         req.Note,
+	}
+
+	if err := h.requestUseCase.SubmitRequest(c.Request.Context(), request); err != nil {
+		response.Error(c, http.StatusBadRequest, "Failed to submit limit change request", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusCreated, "Limit change request submitted", toLimitChangeRequestResponse(h.requestUseCase, request))
+}
+
+// UploadDocument attaches a supporting document, sent as multipart field
+// "file", to one of the customer's pending requests.
+func (h *LimitChangeRequestHandler) UploadDocument(c *gin.Context) {
+	customerID, exists := c.Get("customer_id")
+	if !exists {
+		response.Error(c, http.StatusForbidden, "Customer ID not found", "Only customers can upload limit change documents")
+		return
+	}
+
+	// Named request_id so the customer ownership middleware, which compares
+	// :id with the caller's customer ID, leaves it alone
+	id, err := strconv.ParseUint(c.Param("request_id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid request ID", "Request ID must be a valid number")
+		return
+	}
+
+	// Leave room for the multipart envelope around the file itself
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.maxSize+64*1024)
+
+	file, header, err := c.Request.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			response.Error(c, http.StatusRequestEntityTooLarge, "File too large", fmt.Sprintf("Files may be at most %d bytes", h.maxSize))
+			return
+		}
+		response.Error(c, http.StatusBadRequest, "Invalid upload", "Send the document as multipart form field \"file\"")
+		return
+	}
+	defer file.Close()
+
+	request, err := h.requestUseCase.UploadDocument(c.Request.Context(), customerID.(uint64), id, file, header.Size)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Failed to upload limit change document", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusCreated, "Limit change document uploaded successfully", toLimitChangeRequestResponse(h.requestUseCase, request))
+}
+
+func (h *LimitChangeRequestHandler) GetMyRequests(c *gin.Context) {
+	customerID, exists := c.Get("customer_id")
+	if !exists {
+		response.Error(c, http.StatusBadRequest, "Customer ID not found", "Customer data not available")
+		return
+	}
+
+	requests, err := h.requestUseCase.GetRequestsByCustomerID(c.Request.Context(), customerID.(uint64))
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to retrieve limit change requests", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, fmt.Sprintf("Found %d limit change requests", len(requests)), toLimitChangeRequestResponses(h.requestUseCase, requests))
+}
+
+func (h *LimitChangeRequestHandler) GetRequestQueue(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	if limit < 1 || limit > 100 {
+		response.Error(c, http.StatusBadRequest, "Invalid limit parameter", "Limit must be between 1 and 100")
+		return
+	}
+
+	if offset < 0 {
+		response.Error(c, http.StatusBadRequest, "Invalid offset parameter", "Offset must be 0 or greater")
+		return
+	}
+
+	status := entity.LimitChangeRequestStatus(strings.ToUpper(c.DefaultQuery("status", string(entity.LimitRequestPending))))
+
+	requests, err := h.requestUseCase.GetRequestsByStatus(c.Request.Context(), status, limit, offset)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to retrieve limit change requests", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, fmt.Sprintf("Found %d %s limit change requests", len(requests), status), toLimitChangeRequestResponses(h.requestUseCase, requests))
+}
+
+func (h *LimitChangeRequestHandler) ApproveRequest(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid request ID", "Request ID must be a valid number")
+		return
+	}
+
+	var req dto.ApproveLimitChangeRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		response.Error(c, http.StatusBadRequest, "Invalid request", err.Error())
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+
+	request, err := h.requestUseCase.ApproveRequest(c.Request.Context(), id, userID.(uint64), req.ApprovedLimitAmount, req.Reason)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Failed to approve limit change request", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Limit change request approved", toLimitChangeRequestResponse(h.requestUseCase, request))
+}
+
+func (h *LimitChangeRequestHandler) RejectRequest(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid request ID", "Request ID must be a valid number")
+		return
+	}
+
+	var req dto.RejectLimitChangeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid request", err.Error())
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+
+	request, err := h.requestUseCase.RejectRequest(c.Request.Context(), id, userID.(uint64), req.Reason)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Failed to reject limit change request", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Limit change request rejected", toLimitChangeRequestResponse(h.requestUseCase, request))
+}
+
+func toLimitChangeRequestResponses(requestUseCase usecase.LimitChangeRequestUseCase, requests []*entity.LimitChangeRequest) []dto.LimitChangeRequestResponse {
+	responses := make([]dto.LimitChangeRequestResponse, 0, len(requests))
+	for _, request := range requests {
+		responses = append(responses, toLimitChangeRequestResponse(requestUseCase, request))
+	}
+	return responses
+}
+
+// toLimitChangeRequestResponse includes a signed link to the supporting
+// document when there is one; the link is left out if it cannot be signed.
+func toLimitChangeRequestResponse(requestUseCase usecase.LimitChangeRequestUseCase, request *entity.LimitChangeRequest) dto.LimitChangeRequestResponse {
+	resp := dto.LimitChangeRequestResponse{
+		ID:                   request.ID,
+		CustomerID:           request.CustomerID,
+		TenorMonths:          request.TenorMonths,
+		CurrentLimitAmount:   request.CurrentLimitAmount,
+		RequestedLimitAmount: request.RequestedLimitAmount,
+		ApprovedLimitAmount:  request.ApprovedLimitAmount,
+		UpdatedSalary:        request.UpdatedSalary,
+		DocumentKey:          request.DocumentKey,
+		DocumentChecksum:     request.DocumentChecksum,
+		DocumentUploadedAt:   request.DocumentUploadedAt,
+		Note:                 request.CustomerNote,
+		Status:               request.Status,
+		ReviewReason:         request.ReviewReason,
+		ReviewedBy:           request.ReviewedBy,
+		ReviewedAt:           request.ReviewedAt,
+		CreatedAt:            request.CreatedAt,
+		UpdatedAt:            request.UpdatedAt,
+	}
+
+	if url, expiresAt, err := requestUseCase.DocumentURL(request); err == nil && url != "" {
+		resp.DocumentURL = url
+		resp.DocumentURLExpiresAt = &expiresAt
+	}
+	return resp
+}
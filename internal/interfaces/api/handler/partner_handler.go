@@ -0,0 +1,332 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"pt-xyz-multifinance/internal/domain/entity"
+	"pt-xyz-multifinance/internal/interfaces/dto"
+	"pt-xyz-multifinance/internal/usecase"
+	"pt-xyz-multifinance/pkg/response"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PartnerHandler struct {
+	partnerUseCase usecase.PartnerUseCase
+}
+
+func NewPartnerHandler(partnerUseCase usecase.PartnerUseCase) *PartnerHandler {
+	return &PartnerHandler{
+		partnerUseCase: partnerUseCase,
+	}
+}
+
+func (h *PartnerHandler) CreatePartner(c *gin.Context) {
+	var req dto.PartnerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid request", err.Error())
+		return
+	}
+
+	partner := &entity.Partner{}
+	applyPartnerRequest(partner, &req)
+
+	if err := h.partnerUseCase.CreatePartner(c.Request.Context(), partner); err != nil {
+		response.Error(c, http.StatusBadRequest, "Failed to create partner", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusCreated, "Partner created successfully", toPartnerResponse(partner))
+}
+
+func (h *PartnerHandler) GetPartners(c *gin.Context) {
+	limit, offset, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+
+	partners, err := h.partnerUseCase.GetPartners(c.Request.Context(), limit, offset)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to retrieve partners", err.Error())
+		return
+	}
+
+	partnerResponses := make([]dto.PartnerResponse, 0, len(partners))
+	for _, partner := range partners {
+		partnerResponses = append(partnerResponses, toPartnerResponse(partner))
+	}
+
+	response.Success(c, http.StatusOK, fmt.Sprintf("Found %d partners", len(partnerResponses)), partnerResponses)
+}
+
+func (h *PartnerHandler) GetPartnerByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid partner ID", err.Error())
+		return
+	}
+
+	partner, err := h.partnerUseCase.GetPartnerByID(c.Request.Context(), id)
+	if err != nil {
+		response.Error(c, http.StatusNotFound, "Partner not found", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Partner retrieved successfully", toPartnerResponse(partner))
+}
+
+func (h *PartnerHandler) UpdatePartner(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid partner ID", err.Error())
+		return
+	}
+
+	var req dto.PartnerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid request", err.Error())
+		return
+	}
+
+	partner, err := h.partnerUseCase.GetPartnerByID(c.Request.Context(), id)
+	if err != nil {
+		response.Error(c, http.StatusNotFound, "Partner not found", err.Error())
+		return
+	}
+	applyPartnerRequest(partner, &req)
+
+	if err := h.partnerUseCase.UpdatePartner(c.Request.Context(), partner); err != nil {
+		response.Error(c, http.StatusBadRequest, "Failed to update partner", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Partner updated successfully", toPartnerResponse(partner))
+}
+
+// RotateCredentials returns the new secret once; it cannot be retrieved again.
+func (h *PartnerHandler) RotateCredentials(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid partner ID", err.Error())
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+
+	issued, err := h.partnerUseCase.RotateCredentials(c.Request.Context(), id, userID.(uint64))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Failed to rotate partner credentials", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusCreated, "Partner credentials issued, store the secret now", dto.IssuedPartnerCredentialResponse{
+		PartnerCredentialResponse: toPartnerCredentialResponse(issued.Credential),
+		APISecret:                 issued.Secret,
+	})
+}
+
+func (h *PartnerHandler) GetCredentials(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid partner ID", err.Error())
+		return
+	}
+
+	credentials, err := h.partnerUseCase.GetCredentials(c.Request.Context(), id)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to retrieve partner credentials", err.Error())
+		return
+	}
+
+	credentialResponses := make([]dto.PartnerCredentialResponse, 0, len(credentials))
+	for _, credential := range credentials {
+		credentialResponses = append(credentialResponses, toPartnerCredentialResponse(credential))
+	}
+
+	response.Success(c, http.StatusOK, fmt.Sprintf("Found %d partner credentials", len(credentialResponses)), credentialResponses)
+}
+
+func (h *PartnerHandler) RevokeCredential(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid partner ID", err.Error())
+		return
+	}
+
+	credentialID, err := strconv.ParseUint(c.Param("credential_id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid credential ID", err.Error())
+		return
+	}
+
+	credential, err := h.partnerUseCase.RevokeCredential(c.Request.Context(), id, credentialID)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Failed to revoke partner credential", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Partner credential revoked", toPartnerCredentialResponse(credential))
+}
+
+// LinkCustomer lets the partner originate contracts for the customer, e.g.
+// after the customer signed a consent form at the dealer.
+func (h *PartnerHandler) LinkCustomer(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid partner ID", err.Error())
+		return
+	}
+
+	customerID, err := strconv.ParseUint(c.Param("customer_id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid customer ID", err.Error())
+		return
+	}
+
+	h.linkCustomer(c, id, customerID)
+}
+
+func (h *PartnerHandler) UnlinkCustomer(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid partner ID", err.Error())
+		return
+	}
+
+	customerID, err := strconv.ParseUint(c.Param("customer_id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid customer ID", err.Error())
+		return
+	}
+
+	h.unlinkCustomer(c, id, customerID)
+}
+
+func (h *PartnerHandler) GetMyPartners(c *gin.Context) {
+	customerID, exists := c.Get("customer_id")
+	if !exists {
+		response.Error(c, http.StatusBadRequest, "Customer ID not found", "Customer data not available")
+		return
+	}
+
+	links, err := h.partnerUseCase.GetCustomerLinks(c.Request.Context(), customerID.(uint64))
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to retrieve linked partners", err.Error())
+		return
+	}
+
+	linkResponses := make([]dto.PartnerCustomerResponse, 0, len(links))
+	for _, link := range links {
+		linkResponses = append(linkResponses, toPartnerCustomerResponse(link))
+	}
+
+	response.Success(c, http.StatusOK, fmt.Sprintf("Found %d linked partners", len(linkResponses)), linkResponses)
+}
+
+// LinkMyPartner is the customer's consent to the partner originating
+// contracts for them.
+func (h *PartnerHandler) LinkMyPartner(c *gin.Context) {
+	customerID, exists := c.Get("customer_id")
+	if !exists {
+		response.Error(c, http.StatusBadRequest, "Customer ID not found", "Customer data not available")
+		return
+	}
+
+	partnerID, err := strconv.ParseUint(c.Param("partner_id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid partner ID", err.Error())
+		return
+	}
+
+	h.linkCustomer(c, partnerID, customerID.(uint64))
+}
+
+func (h *PartnerHandler) UnlinkMyPartner(c *gin.Context) {
+	customerID, exists := c.Get("customer_id")
+	if !exists {
+		response.Error(c, http.StatusBadRequest, "Customer ID not found", "Customer data not available")
+		return
+	}
+
+	partnerID, err := strconv.ParseUint(c.Param("partner_id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid partner ID", err.Error())
+		return
+	}
+
+	h.unlinkCustomer(c, partnerID, customerID.(uint64))
+}
+
+func (h *PartnerHandler) linkCustomer(c *gin.Context, partnerID, customerID uint64) {
+	userID, _ := c.Get("user_id")
+
+	link, err := h.partnerUseCase.LinkCustomer(c.Request.Context(), partnerID, customerID, userID.(uint64))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Failed to link customer to partner", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusCreated, "Customer linked to partner", toPartnerCustomerResponse(link))
+}
+
+func (h *PartnerHandler) unlinkCustomer(c *gin.Context, partnerID, customerID uint64) {
+	if err := h.partnerUseCase.UnlinkCustomer(c.Request.Context(), partnerID, customerID); err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to unlink customer from partner", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Customer unlinked from partner", nil)
+}
+
+func applyPartnerRequest(partner *entity.Partner, req *dto.PartnerRequest) {
+	partner.Type = req.Type
+	partner.Name = req.Name
+	partner.SettlementBankName = req.SettlementBankName
+	partner.SettlementAccountNumber = req.SettlementAccountNumber
+	partner.SettlementAccountName = req.SettlementAccountName
+	if req.Status != "" {
+		partner.Status = req.Status
+	}
+}
+
+func toPartnerResponse(partner *entity.Partner) dto.PartnerResponse {
+	return dto.PartnerResponse{
+		ID:                      partner.ID,
+		Type:                    partner.Type,
+		Name:                    partner.Name,
+		SettlementBankName:      partner.SettlementBankName,
+		SettlementAccountNumber: partner.SettlementAccountNumber,
+		SettlementAccountName:   partner.SettlementAccountName,
+		Status:                  partner.Status,
+		CreatedAt:               partner.CreatedAt,
+		UpdatedAt:               partner.UpdatedAt,
+	}
+}
+
+func toPartnerCustomerResponse(link *entity.PartnerCustomer) dto.PartnerCustomerResponse {
+	return dto.PartnerCustomerResponse{
+		ID:          link.ID,
+		PartnerID:   link.PartnerID,
+		PartnerName: link.Partner.Name,
+		PartnerType: link.Partner.Type,
+		CustomerID:  link.CustomerID,
+		LinkedBy:    link.LinkedBy,
+		CreatedAt:   link.CreatedAt,
+	}
+}
+
+func toPartnerCredentialResponse(credential *entity.PartnerCredential) dto.PartnerCredentialResponse {
+	return dto.PartnerCredentialResponse{
+		ID:         credential.ID,
+		PartnerID:  credential.PartnerID,
+		APIKey:     credential.APIKey,
+		Active:     credential.IsActiveAt(time.Now()),
+		ExpiresAt:  credential.ExpiresAt,
+		RevokedAt:  credential.RevokedAt,
+		LastUsedAt: credential.LastUsedAt,
+		CreatedBy:  credential.CreatedBy,
+		CreatedAt:  credential.CreatedAt,
+	}
+}
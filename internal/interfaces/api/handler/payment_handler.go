@@ -0,0 +1,157 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"pt-xyz-multifinance/internal/domain/entity"
+	"pt-xyz-multifinance/internal/interfaces/dto"
+	"pt-xyz-multifinance/internal/usecase"
+	"pt-xyz-multifinance/pkg/response"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PaymentHandler struct {
+	paymentUseCase     usecase.PaymentUseCase
+	transactionUseCase usecase.TransactionUseCase
+}
+
+func NewPaymentHandler(paymentUseCase usecase.PaymentUseCase, transactionUseCase usecase.TransactionUseCase) *PaymentHandler {
+	return &PaymentHandler{
+		paymentUseCase:     paymentUseCase,
+		transactionUseCase: transactionUseCase,
+	}
+}
+
+func (h *PaymentHandler) RecordPayment(c *gin.Context) {
+	idParam := c.Param("id")
+	transactionID, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid transaction ID", err.Error())
+		return
+	}
+
+	var req dto.RecordPaymentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid request", err.Error())
+		return
+	}
+
+	var paymentDate time.Time
+	if req.PaymentDate != "" {
+		paymentDate, err = time.Parse("2006-01-02", req.PaymentDate)
+		if err != nil {
+			response.Error(c, http.StatusBadRequest, "Invalid payment date format", "Payment date must be in YYYY-MM-DD format")
+			return
+		}
+	}
+
+	userID, _ := c.Get("user_id")
+
+	payment := &entity.Payment{
+		TransactionID:   transactionID,
+		Amount:          req.Amount,
+		PaymentDate:     paymentDate,
+		PaymentMethod:   req.PaymentMethod,
+		ReferenceNumber: req.ReferenceNumber,
+		Notes:           req.Notes,
+		RecordedBy:      userID.(uint64),
+	}
+
+	if err := h.paymentUseCase.RecordPayment(c.Request.Context(), payment); err != nil {
+		response.Error(c, http.StatusBadRequest, "Failed to record payment", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusCreated, "Payment recorded successfully", h.toPaymentResponse(payment))
+}
+
+func (h *PaymentHandler) GetTransactionPayments(c *gin.Context) {
+	idParam := c.Param("id")
+	transactionID, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid transaction ID", err.Error())
+		return
+	}
+
+	transaction, err := h.transactionUseCase.GetTransactionByID(c.Request.Context(), transactionID)
+	if err != nil {
+		response.Error(c, http.StatusNotFound, "Transaction not found", err.Error())
+		return
+	}
+
+	role, _ := c.Get("role")
+	if role.(string) == string(entity.RoleCustomer) {
+		customerID, exists := c.Get("customer_id")
+		if !exists || transaction.CustomerID != customerID.(uint64) {
+			response.Error(c, http.StatusForbidden, "Access denied", "You can only access your own transactions")
+			return
+		}
+	}
+
+	payments, err := h.paymentUseCase.GetPaymentsByTransactionID(c.Request.Context(), transactionID)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to retrieve payments", err.Error())
+		return
+	}
+
+	paymentResponses := make([]dto.PaymentResponse, 0, len(payments))
+	for _, payment := range payments {
+		paymentResponses = append(paymentResponses, *h.toPaymentResponse(payment))
+	}
+
+	response.Success(c, http.StatusOK, fmt.Sprintf("Found %d payments for transaction", len(paymentResponses)), paymentResponses)
+}
+
+func (h *PaymentHandler) GetPaymentByID(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid payment ID", err.Error())
+		return
+	}
+
+	payment, err := h.paymentUseCase.GetPaymentByID(c.Request.Context(), id)
+	if err != nil {
+		response.Error(c, http.StatusNotFound, "Payment not found", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Payment retrieved successfully", h.toPaymentResponse(payment))
+}
+
+func (h *PaymentHandler) toPaymentResponse(payment *entity.Payment) *dto.PaymentResponse {
+	response := &dto.PaymentResponse{
+		ID:                 payment.ID,
+		TransactionID:      payment.TransactionID,
+		CustomerID:         payment.CustomerID,
+		Amount:             payment.Amount,
+		PaymentDate:        payment.PaymentDate,
+		PaymentMethod:      payment.PaymentMethod,
+		ReferenceNumber:    payment.ReferenceNumber,
+		AllocatedPenalty:   payment.AllocatedPenalty,
+		AllocatedInterest:  payment.AllocatedInterest,
+		AllocatedAdminFee:  payment.AllocatedAdminFee,
+		AllocatedPrincipal: payment.AllocatedPrincipal,
+		ExcessAmount:       payment.ExcessAmount,
+		LimitRestored:      payment.LimitRestored,
+		Notes:              payment.Notes,
+		RecordedBy:         payment.RecordedBy,
+		Allocations:        make([]dto.PaymentAllocationResponse, 0, len(payment.Allocations)),
+		CreatedAt:          payment.CreatedAt,
+	}
+
+	for _, allocation := range payment.Allocations {
+		response.Allocations = append(response.Allocations, dto.PaymentAllocationResponse{
+			InstallmentID: allocation.InstallmentID,
+			Penalty:       allocation.Penalty,
+			Interest:      allocation.Interest,
+			AdminFee:      allocation.AdminFee,
+			Principal:     allocation.Principal,
+		})
+	}
+
+	return response
+}
@@ -0,0 +1,275 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"pt-xyz-multifinance/internal/domain/entity"
+	"pt-xyz-multifinance/internal/interfaces/dto"
+	"pt-xyz-multifinance/internal/usecase"
+	"pt-xyz-multifinance/pkg/response"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ProductHandler struct {
+	productUseCase  usecase.ProductUseCase
+	rateCardUseCase usecase.RateCardUseCase
+}
+
+func NewProductHandler(productUseCase usecase.ProductUseCase, rateCardUseCase usecase.RateCardUseCase) *ProductHandler {
+	return &ProductHandler{
+		productUseCase:  productUseCase,
+		rateCardUseCase: rateCardUseCase,
+	}
+}
+
+func (h *ProductHandler) CreateProduct(c *gin.Context) {
+	var req dto.ProductRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid request", err.Error())
+		return
+	}
+
+	product := &entity.Product{IsActive: true}
+	applyProductRequest(product, &req)
+
+	if err := h.productUseCase.CreateProduct(c.Request.Context(), product); err != nil {
+		response.Error(c, http.StatusBadRequest, "Failed to create product", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusCreated, "Product created successfully", toProductResponse(product))
+}
+
+func (h *ProductHandler) GetAllProducts(c *gin.Context) {
+	products, err := h.productUseCase.GetAllProducts(c.Request.Context())
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to retrieve products", err.Error())
+		return
+	}
+
+	productResponses := make([]dto.ProductResponse, 0, len(products))
+	for _, product := range products {
+		productResponses = append(productResponses, *toProductResponse(product))
+	}
+
+	response.Success(c, http.StatusOK, fmt.Sprintf("Found %d products", len(productResponses)), productResponses)
+}
+
+func (h *ProductHandler) GetProductByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid product ID", err.Error())
+		return
+	}
+
+	product, err := h.productUseCase.GetProductByID(c.Request.Context(), id)
+	if err != nil {
+		response.Error(c, http.StatusNotFound, "Product not found", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Product retrieved successfully", toProductResponse(product))
+}
+
+func (h *ProductHandler) UpdateProduct(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid product ID", err.Error())
+		return
+	}
+
+	var req dto.ProductRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid request", err.Error())
+		return
+	}
+
+	product, err := h.productUseCase.GetProductByID(c.Request.Context(), id)
+	if err != nil {
+		response.Error(c, http.StatusNotFound, "Product not found", err.Error())
+		return
+	}
+	applyProductRequest(product, &req)
+
+	if err := h.productUseCase.UpdateProduct(c.Request.Context(), product); err != nil {
+		response.Error(c, http.StatusBadRequest, "Failed to update product", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Product updated successfully", toProductResponse(product))
+}
+
+func (h *ProductHandler) DeleteProduct(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid product ID", err.Error())
+		return
+	}
+
+	if err := h.productUseCase.DeleteProduct(c.Request.Context(), id); err != nil {
+		response.Error(c, http.StatusBadRequest, "Failed to delete product", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Product deleted successfully", nil)
+}
+
+func (h *ProductHandler) CreateRateCard(c *gin.Context) {
+	productID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid product ID", err.Error())
+		return
+	}
+
+	var req dto.CreateRateCardRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid request", err.Error())
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	card := &entity.RateCard{
+		ProductID:     productID,
+		TenorMonths:   req.TenorMonths,
+		AssetType:     req.AssetType,
+		InterestRate:  req.InterestRate,
+		AdminFeeType:  req.AdminFeeType,
+		AdminFeeValue: req.AdminFeeValue,
+		CreatedBy:     userID.(uint64),
+	}
+
+	if req.EffectiveFrom != "" {
+		if card.EffectiveFrom, err = parseEffectiveDate(req.EffectiveFrom); err != nil {
+			response.Error(c, http.StatusBadRequest, "Invalid effective_from", err.Error())
+			return
+		}
+	}
+	if req.EffectiveTo != "" {
+		effectiveTo, err := parseEffectiveDate(req.EffectiveTo)
+		if err != nil {
+			response.Error(c, http.StatusBadRequest, "Invalid effective_to", err.Error())
+			return
+		}
+		card.EffectiveTo = &effectiveTo
+	}
+
+	if err := h.rateCardUseCase.CreateRateCard(c.Request.Context(), card); err != nil {
+		response.Error(c, http.StatusBadRequest, "Failed to create rate card", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusCreated, "Rate card created successfully", toRateCardResponse(card))
+}
+
+func (h *ProductHandler) GetRateCards(c *gin.Context) {
+	productID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid product ID", err.Error())
+		return
+	}
+
+	cards, err := h.rateCardUseCase.GetRateCardsByProductID(c.Request.Context(), productID)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to retrieve rate cards", err.Error())
+		return
+	}
+
+	cardResponses := make([]dto.RateCardResponse, 0, len(cards))
+	for _, card := range cards {
+		cardResponses = append(cardResponses, *toRateCardResponse(card))
+	}
+
+	response.Success(c, http.StatusOK, fmt.Sprintf("Found %d rate cards", len(cardResponses)), cardResponses)
+}
+
+func (h *ProductHandler) GetRateCardByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid rate card ID", err.Error())
+		return
+	}
+
+	card, err := h.rateCardUseCase.GetRateCardByID(c.Request.Context(), id)
+	if err != nil {
+		response.Error(c, http.StatusNotFound, "Rate card not found", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Rate card retrieved successfully", toRateCardResponse(card))
+}
+
+// parseEffectiveDate accepts a plain date (midnight UTC) or an RFC 3339 time.
+func parseEffectiveDate(value string) (time.Time, error) {
+	if date, err := time.Parse("2006-01-02", value); err == nil {
+		return date, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+func applyProductRequest(product *entity.Product, req *dto.ProductRequest) {
+	product.Code = req.Code
+	product.Name = req.Name
+	product.Description = req.Description
+	product.AllowedTenors = req.AllowedTenors
+	product.InterestRate = req.InterestRate
+	product.AdminFeeType = req.AdminFeeType
+	product.AdminFeeValue = req.AdminFeeValue
+	product.MinOTR = req.MinOTR
+	product.MaxOTR = req.MaxOTR
+	product.EligibleAssetTypes = req.EligibleAssetTypes
+	product.MinAge = req.MinAge
+	product.MaxAgeAtTenorEnd = req.MaxAgeAtTenorEnd
+	if req.IsActive != nil {
+		product.IsActive = *req.IsActive
+	}
+}
+
+func toProductResponse(product *entity.Product) *dto.ProductResponse {
+	return &dto.ProductResponse{
+		ID:                 product.ID,
+		Code:               product.Code,
+		Name:               product.Name,
+		Description:        product.Description,
+		AllowedTenors:      product.AllowedTenors,
+		InterestRate:       product.InterestRate,
+		AdminFeeType:       product.AdminFeeType,
+		AdminFeeValue:      product.AdminFeeValue,
+		MinOTR:             product.MinOTR,
+		MaxOTR:             product.MaxOTR,
+		EligibleAssetTypes: product.EligibleAssetTypes,
+		MinAge:             product.MinAge,
+		MaxAgeAtTenorEnd:   product.MaxAgeAtTenorEnd,
+		IsActive:           product.IsActive,
+		CreatedAt:          product.CreatedAt,
+		UpdatedAt:          product.UpdatedAt,
+	}
+}
+
+func toRateCardResponse(card *entity.RateCard) *dto.RateCardResponse {
+	return &dto.RateCardResponse{
+		ID:            card.ID,
+		ProductID:     card.ProductID,
+		TenorMonths:   card.TenorMonths,
+		AssetType:     card.AssetType,
+		InterestRate:  card.InterestRate,
+		AdminFeeType:  card.AdminFeeType,
+		AdminFeeValue: card.AdminFeeValue,
+		EffectiveFrom: card.EffectiveFrom,
+		EffectiveTo:   card.EffectiveTo,
+		CreatedBy:     card.CreatedBy,
+		CreatedAt:     card.CreatedAt,
+	}
+}
+
+func toAgeIneligibleResponse(err *entity.AgeIneligibleError) dto.AgeIneligibleResponse {
+	return dto.AgeIneligibleResponse{
+		Code:        err.Code,
+		ProductCode: err.ProductCode,
+		TenorMonths: err.TenorMonths,
+		Age:         err.Age,
+		Limit:       err.Limit,
+	}
+}
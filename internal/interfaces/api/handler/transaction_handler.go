@@ -2,6 +2,8 @@ package handler
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/http"
 	"pt-xyz-multifinance/internal/domain/entity"
 	"pt-xyz-multifinance/internal/interfaces/dto"
@@ -17,14 +19,23 @@ import (
 type TransactionHandler struct {
 	transactionUseCase usecase.TransactionUseCase
 	customerUseCase    usecase.CustomerUseCase
+	scheduleUseCase    usecase.InstallmentScheduleUseCase
+	partnerUseCase     usecase.PartnerUseCase
 	createSemaphore    chan struct{}
 	customerMutexMap   sync.Map
 }
 
-func NewTransactionHandler(transactionUseCase usecase.TransactionUseCase, customerUseCase usecase.CustomerUseCase) *TransactionHandler {
+func NewTransactionHandler(
+	transactionUseCase usecase.TransactionUseCase,
+	customerUseCase usecase.CustomerUseCase,
+	scheduleUseCase usecase.InstallmentScheduleUseCase,
+	partnerUseCase usecase.PartnerUseCase,
+) *TransactionHandler {
 	return &TransactionHandler{
 		transactionUseCase: transactionUseCase,
 		customerUseCase:    customerUseCase,
+		scheduleUseCase:    scheduleUseCase,
+		partnerUseCase:     partnerUseCase,
 		createSemaphore:    make(chan struct{}, 10),
 	}
 }
@@ -41,18 +52,33 @@ func (h *TransactionHandler) CreateTransaction(c *gin.Context) {
 		return
 	}
 
-	role, _ := c.Get("role")
-	if role.(string) == string(entity.RoleCustomer) {
-		customerID, exists := c.Get("customer_id")
-		if !exists {
-			response.Error(c, http.StatusBadRequest, "Customer ID not found", "Customer data not available")
+	// Partners originate contracts for the customers linked to them; the source follows the partner type
+	var partnerID *uint64
+	if id, ok := c.Get("partner_id"); ok {
+		id := id.(uint64)
+		partnerID = &id
+		if !h.checkPartnerRequest(c, id, req.CustomerID, &req.TransactionSource) {
 			return
 		}
-
-		if req.CustomerID != customerID.(uint64) {
-			response.Error(c, http.StatusForbidden, "Access denied", "You can only create transactions for yourself")
+	} else {
+		if req.TransactionSource == "" {
+			response.Error(c, http.StatusBadRequest, "Invalid request", "transaction_source is required")
 			return
 		}
+
+		role, _ := c.Get("role")
+		if role.(string) == string(entity.RoleCustomer) {
+			customerID, exists := c.Get("customer_id")
+			if !exists {
+				response.Error(c, http.StatusBadRequest, "Customer ID not found", "Customer data not available")
+				return
+			}
+
+			if req.CustomerID != customerID.(uint64) {
+				response.Error(c, http.StatusForbidden, "Access denied", "You can only create transactions for yourself")
+				return
+			}
+		}
 	}
 
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
@@ -75,13 +101,15 @@ func (h *TransactionHandler) CreateTransaction(c *gin.Context) {
 
 		transaction := &entity.Transaction{
 			CustomerID:        req.CustomerID,
+			ProductID:         req.ProductID,
 			TenorMonths:       req.TenorMonths,
 			OTRAmount:         req.OTRAmount,
-			AdminFee:          req.AdminFee,
-			InterestAmount:    req.InterestAmount,
+			DownPaymentAmount: req.DownPaymentAmount,
+			ClientAdminFee:    req.AdminFee,
 			AssetName:         req.AssetName,
 			AssetType:         req.AssetType,
 			TransactionSource: req.TransactionSource,
+			PartnerID:         partnerID,
 			Status:            entity.StatusPending,
 		}
 
@@ -96,6 +124,30 @@ func (h *TransactionHandler) CreateTransaction(c *gin.Context) {
 	select {
 	case result := <-resultChan:
 		if result.err != nil {
+			var mismatchErr *entity.AdminFeeMismatchError
+			if errors.As(result.err, &mismatchErr) {
+				response.ErrorWithData(c, http.StatusUnprocessableEntity, "Admin fee mismatch", result.err.Error(), dto.AdminFeeMismatchResponse{
+					ExpectedAdminFee: mismatchErr.Expected,
+					ProvidedAdminFee: mismatchErr.Provided,
+				})
+				return
+			}
+			var affordabilityErr *entity.AffordabilityError
+			if errors.As(result.err, &affordabilityErr) {
+				response.ErrorWithData(c, http.StatusUnprocessableEntity, "Installments exceed the affordable share of salary", result.err.Error(), dto.AffordabilityErrorResponse{
+					Salary:               affordabilityErr.Salary,
+					ExistingInstallments: affordabilityErr.ExistingInstallments,
+					NewInstallment:       affordabilityErr.NewInstallment,
+					DebtToIncomeRatio:    affordabilityErr.Ratio,
+					MaxDebtToIncomeRatio: affordabilityErr.MaxRatio,
+				})
+				return
+			}
+			var ageErr *entity.AgeIneligibleError
+			if errors.As(result.err, &ageErr) {
+				response.ErrorWithData(c, http.StatusUnprocessableEntity, "Applicant is not eligible", result.err.Error(), toAgeIneligibleResponse(ageErr))
+				return
+			}
 			response.Error(c, http.StatusBadRequest, "Failed to create transaction", result.err.Error())
 			return
 		}
@@ -114,6 +166,96 @@ func (h *TransactionHandler) CreateTransaction(c *gin.Context) {
 	}
 }
 
+// SimulateTransaction quotes every eligible tenor for a purchase without
+// creating a transaction.
+func (h *TransactionHandler) SimulateTransaction(c *gin.Context) {
+	var req dto.SimulateTransactionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid request", err.Error())
+		return
+	}
+
+	if id, ok := c.Get("partner_id"); ok {
+		if !h.checkPartnerRequest(c, id.(uint64), req.CustomerID, &req.TransactionSource) {
+			return
+		}
+	} else if req.TransactionSource == "" {
+		response.Error(c, http.StatusBadRequest, "Invalid request", "transaction_source is required")
+		return
+	} else if role, _ := c.Get("role"); role.(string) == string(entity.RoleCustomer) {
+		customerID, exists := c.Get("customer_id")
+		if !exists {
+			response.Error(c, http.StatusBadRequest, "Customer ID not found", "Customer data not available")
+			return
+		}
+
+		if req.CustomerID != customerID.(uint64) {
+			response.Error(c, http.StatusForbidden, "Access denied", "You can only simulate transactions for yourself")
+			return
+		}
+	}
+
+	options, err := h.transactionUseCase.SimulateTransaction(c.Request.Context(), &usecase.SimulationRequest{
+		CustomerID:        req.CustomerID,
+		ProductID:         req.ProductID,
+		OTRAmount:         req.OTRAmount,
+		DownPaymentAmount: req.DownPaymentAmount,
+		AssetType:         req.AssetType,
+		TransactionSource: req.TransactionSource,
+	})
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Failed to simulate transaction", err.Error())
+		return
+	}
+
+	optionResponses := make([]dto.SimulationOptionResponse, 0, len(options))
+	for _, option := range options {
+		optionResponses = append(optionResponses, dto.SimulationOptionResponse{
+			ProductID:         option.ProductID,
+			ProductCode:       option.ProductCode,
+			TenorMonths:       option.TenorMonths,
+			RateCardID:        option.RateCardID,
+			InterestMethod:    option.InterestMethod,
+			InterestRate:      option.InterestRate,
+			DownPaymentAmount: option.DownPaymentAmount,
+			FinancedAmount:    option.FinancedAmount,
+			AdminFee:          option.AdminFee,
+			InterestAmount:    option.InterestAmount,
+			InstallmentAmount: option.InstallmentAmount,
+			TotalPayable:      option.TotalPayable,
+			AvailableLimit:    option.AvailableLimit,
+			LimitSufficient:   option.LimitSufficient,
+			DebtToIncomeRatio: option.DebtToIncomeRatio,
+			Affordable:        option.Affordable,
+		})
+	}
+
+	response.Success(c, http.StatusOK, fmt.Sprintf("Found %d financing options", len(optionResponses)), optionResponses)
+}
+
+// checkPartnerRequest applies the partner rules to a request sent with partner
+// credentials: the transaction source follows the partner type, and the
+// customer must have linked the partner. On refusal it writes the response
+// and returns false.
+func (h *TransactionHandler) checkPartnerRequest(c *gin.Context, partnerID, customerID uint64, source *entity.TransactionSource) bool {
+	partnerSource := entity.PartnerType(c.GetString("partner_type")).TransactionSource()
+	if *source != "" && *source != partnerSource {
+		response.Error(c, http.StatusBadRequest, "Invalid transaction source", fmt.Sprintf("Transactions from this partner have source %s", partnerSource))
+		return false
+	}
+	*source = partnerSource
+
+	if err := h.partnerUseCase.CheckCustomerLink(c.Request.Context(), partnerID, customerID); err != nil {
+		if errors.Is(err, usecase.ErrCustomerNotLinked) {
+			response.Error(c, http.StatusForbidden, "Access denied", "The customer has not linked this partner")
+			return false
+		}
+		response.Error(c, http.StatusInternalServerError, "Failed to check the partner customer link", err.Error())
+		return false
+	}
+	return true
+}
+
 func (h *TransactionHandler) GetTransactionByID(c *gin.Context) {
 	idParam := c.Param("id")
 	id, err := strconv.ParseUint(idParam, 10, 64)
@@ -141,6 +283,76 @@ func (h *TransactionHandler) GetTransactionByID(c *gin.Context) {
 	response.Success(c, http.StatusOK, "Transaction retrieved successfully", h.toTransactionResponse(transaction))
 }
 
+func (h *TransactionHandler) GetTransactionByContractNumber(c *gin.Context) {
+	transaction, err := h.transactionUseCase.GetTransactionByContractNumber(c.Request.Context(), c.Param("contract_number"))
+	if err != nil {
+		response.Error(c, http.StatusNotFound, "Transaction not found", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Transaction retrieved successfully", h.toTransactionResponse(transaction))
+}
+
+func (h *TransactionHandler) GetTransactionSchedule(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid transaction ID", err.Error())
+		return
+	}
+
+	transaction, err := h.transactionUseCase.GetTransactionByID(c.Request.Context(), id)
+	if err != nil {
+		response.Error(c, http.StatusNotFound, "Transaction not found", err.Error())
+		return
+	}
+
+	role, _ := c.Get("role")
+	if role.(string) == string(entity.RoleCustomer) {
+		customerID, exists := c.Get("customer_id")
+		if !exists || transaction.CustomerID != customerID.(uint64) {
+			response.Error(c, http.StatusForbidden, "Access denied", "You can only access your own transactions")
+			return
+		}
+	}
+
+	installments, err := h.scheduleUseCase.GetScheduleByTransactionID(c.Request.Context(), id)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to retrieve installment schedule", err.Error())
+		return
+	}
+
+	scheduleResponse := dto.TransactionScheduleResponse{
+		TransactionID:  transaction.ID,
+		ContractNumber: transaction.ContractNumber,
+		TenorMonths:    transaction.TenorMonths,
+		DaysPastDue:    transaction.DaysPastDue,
+		AccruedPenalty: transaction.AccruedPenalty,
+		Installments:   make([]dto.InstallmentScheduleResponse, 0, len(installments)),
+	}
+	for _, installment := range installments {
+		scheduleResponse.TotalAmountDue += installment.AmountDue
+		scheduleResponse.Installments = append(scheduleResponse.Installments, dto.InstallmentScheduleResponse{
+			ID:                installment.ID,
+			InstallmentNumber: installment.InstallmentNumber,
+			DueDate:           installment.DueDate.Format("2006-01-02"),
+			PrincipalAmount:   installment.PrincipalAmount,
+			InterestAmount:    installment.InterestAmount,
+			AdminFeeAmount:    installment.AdminFeeAmount,
+			AmountDue:         installment.AmountDue,
+			PenaltyAmount:     installment.PenaltyAmount,
+			AmountPaid:        installment.TotalPaid(),
+			Outstanding:       installment.Outstanding(),
+			DaysPastDue:       installment.DaysPastDue,
+			Status:            installment.Status,
+			PaidAt:            installment.PaidAt,
+			CreatedAt:         installment.CreatedAt,
+		})
+	}
+
+	response.Success(c, http.StatusOK, "Installment schedule retrieved successfully", scheduleResponse)
+}
+
 func (h *TransactionHandler) GetTransactionsByCustomerID(c *gin.Context) {
 	idParam := c.Param("customer_id")
 	customerID, err := strconv.ParseUint(idParam, 10, 64)
@@ -186,8 +398,27 @@ func (h *TransactionHandler) UpdateTransactionStatus(c *gin.Context) {
 		return
 	}
 
-	if err := h.transactionUseCase.UpdateTransactionStatus(c.Request.Context(), id, req.Status); err != nil {
-		response.Error(c, http.StatusInternalServerError, "Failed to update transaction status", err.Error())
+	if !req.Status.IsValid() {
+		response.Error(c, http.StatusBadRequest, "Invalid status", fmt.Sprintf("Unknown transaction status: %s", req.Status))
+		return
+	}
+
+	if err := h.transactionUseCase.UpdateTransactionStatus(c.Request.Context(), id, req.Status, req.Reason); err != nil {
+		var transitionErr *entity.InvalidStatusTransitionError
+		switch {
+		case errors.As(err, &transitionErr):
+			response.ErrorWithData(c, http.StatusConflict, "Invalid status transition", err.Error(), dto.InvalidStatusTransitionResponse{
+				CurrentStatus:   transitionErr.From,
+				RequestedStatus: transitionErr.To,
+				AllowedStatuses: transitionErr.Allowed,
+			})
+		case errors.Is(err, usecase.ErrTransactionNotFound):
+			response.Error(c, http.StatusNotFound, "Transaction not found", err.Error())
+		case errors.Is(err, usecase.ErrTransactionOnHold):
+			response.Error(c, http.StatusConflict, "Transaction is on hold", err.Error())
+		default:
+			response.Error(c, http.StatusInternalServerError, "Failed to update transaction status", err.Error())
+		}
 		return
 	}
 
@@ -227,15 +458,33 @@ func (h *TransactionHandler) toTransactionResponse(transaction *entity.Transacti
 		ID:                transaction.ID,
 		ContractNumber:    transaction.ContractNumber,
 		CustomerID:        transaction.CustomerID,
+		ProductID:         transaction.ProductID,
+		RateCardID:        transaction.RateCardID,
 		TenorMonths:       transaction.TenorMonths,
 		OTRAmount:         transaction.OTRAmount,
+		DownPaymentAmount: transaction.DownPaymentAmount,
+		FinancedAmount:    transaction.FinancedPrincipal(),
 		AdminFee:          transaction.AdminFee,
+		AdminFeeRuleID:    transaction.AdminFeeRuleID,
 		InstallmentAmount: transaction.InstallmentAmount,
 		InterestAmount:    transaction.InterestAmount,
+		InterestMethod:    transaction.InterestMethod,
+		InterestRate:      transaction.InterestRate,
 		AssetName:         transaction.AssetName,
 		AssetType:         transaction.AssetType,
 		Status:            transaction.Status,
 		TransactionSource: transaction.TransactionSource,
+		PartnerID:         transaction.PartnerID,
+		StatusReason:      transaction.StatusReason,
+		ApprovedAt:        transaction.ApprovedAt,
+		RejectedAt:        transaction.RejectedAt,
+		ActivatedAt:       transaction.ActivatedAt,
+		CompletedAt:       transaction.CompletedAt,
+		DefaultedAt:       transaction.DefaultedAt,
+		DaysPastDue:       transaction.DaysPastDue,
+		AccruedPenalty:    transaction.AccruedPenalty,
+		LimitRestored:     transaction.LimitRestored,
+		DebtToIncomeRatio: transaction.DebtToIncomeRatio,
 		CreatedAt:         transaction.CreatedAt,
 		UpdatedAt:         transaction.UpdatedAt,
 	}
@@ -252,6 +501,7 @@ func (h *TransactionHandler) toTransactionResponse(transaction *entity.Transacti
 			Salary:          transaction.Customer.Salary,
 			KTPPhotoPath:    transaction.Customer.KTPPhotoPath,
 			SelfiePhotoPath: transaction.Customer.SelfiePhotoPath,
+			CreditBalance:   transaction.Customer.CreditBalance,
 			CreatedAt:       transaction.Customer.CreatedAt,
 			UpdatedAt:       transaction.Customer.UpdatedAt,
 		}
@@ -0,0 +1,265 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"pt-xyz-multifinance/internal/domain/entity"
+	"pt-xyz-multifinance/internal/interfaces/dto"
+	"pt-xyz-multifinance/internal/usecase"
+	"pt-xyz-multifinance/pkg/response"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxWatchlistImportSize bounds the CSV upload of a watchlist import.
+const maxWatchlistImportSize = 10 << 20
+
+type WatchlistHandler struct {
+	watchlistUseCase usecase.WatchlistUseCase
+}
+
+func NewWatchlistHandler(watchlistUseCase usecase.WatchlistUseCase) *WatchlistHandler {
+	return &WatchlistHandler{
+		watchlistUseCase: watchlistUseCase,
+	}
+}
+
+func (h *WatchlistHandler) CreateEntry(c *gin.Context) {
+	var req dto.WatchlistRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid request", err.Error())
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	adminID := userID.(uint64)
+	entry := &entity.Watchlist{CreatedBy: &adminID}
+	applyWatchlistRequest(entry, &req)
+
+	if err := h.watchlistUseCase.CreateEntry(c.Request.Context(), entry); err != nil {
+		response.Error(c, http.StatusBadRequest, "Failed to create watchlist entry", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusCreated, "Watchlist entry created successfully", toWatchlistResponse(entry))
+}
+
+func (h *WatchlistHandler) GetEntries(c *gin.Context) {
+	limit, offset, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+
+	entries, err := h.watchlistUseCase.GetEntries(c.Request.Context(), limit, offset)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to retrieve watchlist", err.Error())
+		return
+	}
+
+	entryResponses := make([]dto.WatchlistResponse, 0, len(entries))
+	for _, entry := range entries {
+		entryResponses = append(entryResponses, toWatchlistResponse(entry))
+	}
+
+	response.Success(c, http.StatusOK, fmt.Sprintf("Found %d watchlist entries", len(entryResponses)), entryResponses)
+}
+
+func (h *WatchlistHandler) GetEntryByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid watchlist entry ID", err.Error())
+		return
+	}
+
+	entry, err := h.watchlistUseCase.GetEntryByID(c.Request.Context(), id)
+	if err != nil {
+		response.Error(c, http.StatusNotFound, "Watchlist entry not found", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Watchlist entry retrieved successfully", toWatchlistResponse(entry))
+}
+
+func (h *WatchlistHandler) UpdateEntry(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid watchlist entry ID", err.Error())
+		return
+	}
+
+	var req dto.WatchlistRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid request", err.Error())
+		return
+	}
+
+	entry, err := h.watchlistUseCase.GetEntryByID(c.Request.Context(), id)
+	if err != nil {
+		response.Error(c, http.StatusNotFound, "Watchlist entry not found", err.Error())
+		return
+	}
+	applyWatchlistRequest(entry, &req)
+
+	if err := h.watchlistUseCase.UpdateEntry(c.Request.Context(), entry); err != nil {
+		response.Error(c, http.StatusBadRequest, "Failed to update watchlist entry", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Watchlist entry updated successfully", toWatchlistResponse(entry))
+}
+
+func (h *WatchlistHandler) DeleteEntry(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid watchlist entry ID", err.Error())
+		return
+	}
+
+	if err := h.watchlistUseCase.DeleteEntry(c.Request.Context(), id); err != nil {
+		response.Error(c, http.StatusBadRequest, "Failed to delete watchlist entry", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Watchlist entry deleted successfully", nil)
+}
+
+// ImportEntries reads a CSV file from the multipart field "file".
+func (h *WatchlistHandler) ImportEntries(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxWatchlistImportSize)
+
+	file, _, err := c.Request.FormFile("file")
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid upload", "Send the CSV as multipart form field \"file\"")
+		return
+	}
+	defer file.Close()
+
+	userID, _ := c.Get("user_id")
+
+	result, err := h.watchlistUseCase.ImportCSV(c.Request.Context(), file, userID.(uint64))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Failed to import watchlist", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Watchlist imported successfully", dto.WatchlistImportResponse{
+		Created: result.Created,
+		Updated: result.Updated,
+	})
+}
+
+func (h *WatchlistHandler) GetHits(c *gin.Context) {
+	limit, offset, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+
+	// status=ALL lists every hit, including blocked and resolved ones
+	status := entity.WatchlistHitStatus(strings.ToUpper(c.DefaultQuery("status", string(entity.WatchlistHitOpen))))
+	if status == "ALL" {
+		status = ""
+	}
+
+	hits, err := h.watchlistUseCase.GetHits(c.Request.Context(), status, limit, offset)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to retrieve watchlist hits", err.Error())
+		return
+	}
+
+	hitResponses := make([]dto.WatchlistHitResponse, 0, len(hits))
+	for _, hit := range hits {
+		hitResponses = append(hitResponses, toWatchlistHitResponse(hit))
+	}
+
+	response.Success(c, http.StatusOK, fmt.Sprintf("Found %d watchlist hits", len(hitResponses)), hitResponses)
+}
+
+func (h *WatchlistHandler) ResolveHit(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid watchlist hit ID", err.Error())
+		return
+	}
+
+	var req dto.ResolveWatchlistHitRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid request", err.Error())
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+
+	hit, err := h.watchlistUseCase.ResolveHit(c.Request.Context(), id, userID.(uint64), req.Status, req.Note)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Failed to resolve watchlist hit", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Watchlist hit resolved", toWatchlistHitResponse(hit))
+}
+
+func parsePagination(c *gin.Context) (int, int, bool) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	if limit < 1 || limit > 100 {
+		response.Error(c, http.StatusBadRequest, "Invalid limit parameter", "Limit must be between 1 and 100")
+		return 0, 0, false
+	}
+
+	if offset < 0 {
+		response.Error(c, http.StatusBadRequest, "Invalid offset parameter", "Offset must be 0 or greater")
+		return 0, 0, false
+	}
+
+	return limit, offset, true
+}
+
+func applyWatchlistRequest(entry *entity.Watchlist, req *dto.WatchlistRequest) {
+	entry.NIK = req.NIK
+	entry.Name = req.Name
+	entry.Reason = req.Reason
+	entry.Source = req.Source
+	entry.ExpiresAt = nil
+	if req.ExpiresAt != "" {
+		// Already checked by the binding
+		expiresAt, _ := time.Parse("2006-01-02", req.ExpiresAt)
+		entry.ExpiresAt = &expiresAt
+	}
+}
+
+func toWatchlistResponse(entry *entity.Watchlist) dto.WatchlistResponse {
+	return dto.WatchlistResponse{
+		ID:        entry.ID,
+		NIK:       entry.NIK,
+		Name:      entry.Name,
+		Reason:    entry.Reason,
+		Source:    entry.Source,
+		ExpiresAt: entry.ExpiresAt,
+		CreatedBy: entry.CreatedBy,
+		CreatedAt: entry.CreatedAt,
+		UpdatedAt: entry.UpdatedAt,
+	}
+}
+
+func toWatchlistHitResponse(hit *entity.WatchlistHit) dto.WatchlistHitResponse {
+	return dto.WatchlistHitResponse{
+		ID:             hit.ID,
+		WatchlistID:    hit.WatchlistID,
+		NIK:            hit.NIK,
+		Stage:          hit.Stage,
+		Action:         hit.Action,
+		CustomerID:     hit.CustomerID,
+		TransactionID:  hit.TransactionID,
+		Status:         hit.Status,
+		Reason:         hit.Watchlist.Reason,
+		Source:         hit.Watchlist.Source,
+		ResolutionNote: hit.ResolutionNote,
+		ResolvedBy:     hit.ResolvedBy,
+		ResolvedAt:     hit.ResolvedAt,
+		CreatedAt:      hit.CreatedAt,
+	}
+}
@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"errors"
 	"net/http"
 	"pt-xyz-multifinance/internal/domain/entity"
 	"pt-xyz-multifinance/internal/usecase"
@@ -71,6 +72,37 @@ func AuthMiddleware(authUseCase usecase.AuthUseCase) gin.HandlerFunc {
 	}
 }
 
+// PartnerOrUserAuthMiddleware lets a partner authenticate with its API key
+// and secret headers; requests without them need a user JWT as usual.
+func PartnerOrUserAuthMiddleware(partnerUseCase usecase.PartnerUseCase, authUseCase usecase.AuthUseCase) gin.HandlerFunc {
+	userAuth := AuthMiddleware(authUseCase)
+	return func(c *gin.Context) {
+		apiKey := c.GetHeader("X-API-Key")
+		if apiKey == "" {
+			userAuth(c)
+			return
+		}
+
+		partner, err := partnerUseCase.Authenticate(c.Request.Context(), apiKey, c.GetHeader("X-API-Secret"))
+		if err != nil {
+			switch {
+			case errors.Is(err, usecase.ErrInvalidPartnerCredentials):
+				response.Error(c, http.StatusUnauthorized, "Invalid partner credentials", "Check the X-API-Key and X-API-Secret headers")
+			case errors.Is(err, usecase.ErrPartnerNotActive):
+				response.Error(c, http.StatusForbidden, "Partner is not active", err.Error())
+			default:
+				response.Error(c, http.StatusInternalServerError, "Failed to authenticate partner", err.Error())
+			}
+			c.Abort()
+			return
+		}
+
+		c.Set("partner_id", partner.ID)
+		c.Set("partner_type", string(partner.Type))
+		c.Next()
+	}
+}
+
 func RequireRole(roles ...entity.UserRole) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		userRole, exists := c.Get("role")
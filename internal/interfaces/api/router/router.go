@@ -14,7 +14,18 @@ func SetupRoutes(
 	customerHandler *handler.CustomerHandler,
 	transactionHandler *handler.TransactionHandler,
 	authHandler *handler.AuthHandler,
+	paymentHandler *handler.PaymentHandler,
+	delinquencyHandler *handler.DelinquencyHandler,
+	limitRequestHandler *handler.LimitChangeRequestHandler,
+	productHandler *handler.ProductHandler,
+	adminFeeRuleHandler *handler.AdminFeeRuleHandler,
+	contractHandler *handler.ContractHandler,
+	kycHandler *handler.KYCHandler,
+	watchlistHandler *handler.WatchlistHandler,
+	duplicateHandler *handler.CustomerDuplicateHandler,
+	partnerHandler *handler.PartnerHandler,
 	authUseCase usecase.AuthUseCase,
+	partnerUseCase usecase.PartnerUseCase,
 ) {
 	// Global middleware
 	r.Use(middleware.CORSMiddleware())
@@ -38,6 +49,9 @@ func SetupRoutes(
 			auth.POST("/login", authHandler.Login)
 		}
 
+		// Signed file links; the signature in the query is the credential
+		v1.GET("/files/*key", kycHandler.ServeSignedFile)
+
 		// Admin-only routes
 		admin := v1.Group("/admin")
 		admin.Use(middleware.AuthMiddleware(authUseCase))
@@ -47,12 +61,88 @@ func SetupRoutes(
 			admin.GET("/customers", customerHandler.GetAllCustomers)
 			admin.GET("/customers/:id", customerHandler.GetCustomerByID)
 			admin.GET("/customers/:id/limits", customerHandler.GetCustomerLimits)
+			admin.GET("/customers/:id/limits/:tenor/history", customerHandler.GetLimitHistory)
+			admin.POST("/customers/:id/limits/:tenor/adjustments", customerHandler.AdjustUsedAmount)
+			admin.PUT("/customers/:id/limits/:tenor", customerHandler.UpdateLimitAmount)
+			admin.POST("/customers/:id/limits/recommend", customerHandler.RecommendLimits)
+			admin.GET("/customers/:id/limits/:tenor/changes", customerHandler.GetLimitChanges)
+			admin.GET("/customers/:id/kyc", kycHandler.GetCustomerDocuments)
+			admin.POST("/customers/:id/kyc/documents/:type", kycHandler.UploadCustomerDocument)
+
+			// KYC review queue
+			admin.GET("/kyc/reviews", kycHandler.GetReviewQueue)
+			admin.POST("/customers/:id/kyc/approve", kycHandler.ApproveCustomer)
+			admin.POST("/customers/:id/kyc/reject", kycHandler.RejectCustomer)
+
+			// Suspected duplicate customers
+			admin.GET("/customer-duplicates", duplicateHandler.GetClusters)
+			admin.POST("/customer-duplicates/:id/resolve", duplicateHandler.ResolveDuplicate)
 
 			// Admin can access all transactions
 			admin.GET("/transactions", transactionHandler.GetAllTransactions)
 			admin.GET("/transactions/:id", transactionHandler.GetTransactionByID)
+			admin.GET("/transactions/:id/schedule", transactionHandler.GetTransactionSchedule)
 			admin.PUT("/transactions/:id/status", transactionHandler.UpdateTransactionStatus)
 			admin.GET("/transactions/customer/:customer_id", transactionHandler.GetTransactionsByCustomerID)
+			admin.GET("/transactions/contract/:contract_number", transactionHandler.GetTransactionByContractNumber)
+			admin.GET("/transactions/:id/contract", contractHandler.DownloadContract)
+
+			// Admin records repayments against a contract
+			admin.POST("/transactions/:id/payments", paymentHandler.RecordPayment)
+			admin.GET("/transactions/:id/payments", paymentHandler.GetTransactionPayments)
+			admin.GET("/payments/:id", paymentHandler.GetPaymentByID)
+
+			// Delinquency: days-past-due, late penalties and defaulting
+			admin.POST("/delinquency/run", delinquencyHandler.RunAssessment)
+			admin.POST("/transactions/:id/delinquency", delinquencyHandler.AssessTransaction)
+
+			// Limit change request queue
+			admin.GET("/limit-requests", limitRequestHandler.GetRequestQueue)
+			admin.POST("/limit-requests/:id/approve", limitRequestHandler.ApproveRequest)
+			admin.POST("/limit-requests/:id/reject", limitRequestHandler.RejectRequest)
+
+			// Product catalog
+			admin.POST("/products", productHandler.CreateProduct)
+			admin.GET("/products", productHandler.GetAllProducts)
+			admin.GET("/products/:id", productHandler.GetProductByID)
+			admin.PUT("/products/:id", productHandler.UpdateProduct)
+			admin.DELETE("/products/:id", productHandler.DeleteProduct)
+			admin.POST("/products/:id/rate-cards", productHandler.CreateRateCard)
+			admin.GET("/products/:id/rate-cards", productHandler.GetRateCards)
+			admin.GET("/rate-cards/:id", productHandler.GetRateCardByID)
+
+			// Admin fee rules
+			admin.POST("/admin-fee-rules", adminFeeRuleHandler.CreateRule)
+			admin.GET("/admin-fee-rules", adminFeeRuleHandler.GetAllRules)
+			admin.GET("/admin-fee-rules/:id", adminFeeRuleHandler.GetRuleByID)
+			admin.PUT("/admin-fee-rules/:id", adminFeeRuleHandler.UpdateRule)
+			admin.DELETE("/admin-fee-rules/:id", adminFeeRuleHandler.DeleteRule)
+
+			// Watchlist screening of NIKs
+			admin.POST("/watchlist", watchlistHandler.CreateEntry)
+			admin.POST("/watchlist/import", watchlistHandler.ImportEntries)
+			admin.GET("/watchlist", watchlistHandler.GetEntries)
+			admin.GET("/watchlist/:id", watchlistHandler.GetEntryByID)
+			admin.PUT("/watchlist/:id", watchlistHandler.UpdateEntry)
+			admin.DELETE("/watchlist/:id", watchlistHandler.DeleteEntry)
+			admin.GET("/watchlist-hits", watchlistHandler.GetHits)
+			admin.POST("/watchlist-hits/:id/resolve", watchlistHandler.ResolveHit)
+
+			// Dealers and merchants; a new API secret is only shown by the rotate call
+			admin.POST("/partners", partnerHandler.CreatePartner)
+			admin.GET("/partners", partnerHandler.GetPartners)
+			admin.GET("/partners/:id", partnerHandler.GetPartnerByID)
+			admin.PUT("/partners/:id", partnerHandler.UpdatePartner)
+			admin.POST("/partners/:id/credentials", partnerHandler.RotateCredentials)
+			admin.GET("/partners/:id/credentials", partnerHandler.GetCredentials)
+			admin.DELETE("/partners/:id/credentials/:credential_id", partnerHandler.RevokeCredential)
+			admin.POST("/partners/:id/customers/:customer_id", partnerHandler.LinkCustomer)
+			admin.DELETE("/partners/:id/customers/:customer_id", partnerHandler.UnlinkCustomer)
+
+			// Versioned credit agreement templates
+			admin.POST("/contract-templates", contractHandler.CreateTemplate)
+			admin.GET("/contract-templates", contractHandler.GetTemplates)
+			admin.PUT("/contract-templates/:id/status", contractHandler.UpdateTemplateStatus)
 
 			// Admin can create customers directly (without user registration)
 			admin.POST("/customers", customerHandler.CreateCustomer)
@@ -66,18 +156,41 @@ func SetupRoutes(
 			// Customers can only access their own data
 			customers.GET("/:id", customerHandler.GetCustomerByID)
 			customers.GET("/:id/limits", customerHandler.GetCustomerLimits)
+			customers.GET("/:id/limits/:tenor/history", customerHandler.GetLimitHistory)
 
 			// Customer can view their own profile (using their customer ID from token)
 			customers.GET("/me", customerHandler.GetMyProfile)
+
+			// Customers ask for a higher limit and follow the request from here or /me
+			customers.POST("/me/limit-requests", limitRequestHandler.SubmitRequest)
+			customers.GET("/me/limit-requests", limitRequestHandler.GetMyRequests)
+			customers.POST("/me/limit-requests/:request_id/document", limitRequestHandler.UploadDocument)
+
+			// KYC images are uploaded as multipart field "file"
+			customers.POST("/me/kyc/ktp", kycHandler.UploadKTP)
+			customers.POST("/me/kyc/selfie", kycHandler.UploadSelfie)
+			customers.GET("/me/kyc", kycHandler.GetMyDocuments)
+
+			// Partners may only originate contracts for customers linked to them
+			customers.GET("/me/partners", partnerHandler.GetMyPartners)
+			customers.POST("/me/partners/:partner_id", partnerHandler.LinkMyPartner)
+			customers.DELETE("/me/partners/:partner_id", partnerHandler.UnlinkMyPartner)
 		}
 
+		// Both admin and customer can create and simulate transactions, and partners with their API credentials
+		// But customers can only create for themselves (will be validated in handler)
+		partnerOrUser := middleware.PartnerOrUserAuthMiddleware(partnerUseCase, authUseCase)
+		v1.POST("/transactions", partnerOrUser, transactionHandler.CreateTransaction)
+		v1.POST("/transactions/simulate", partnerOrUser, transactionHandler.SimulateTransaction)
+
 		// Transaction routes (authentication required + ownership check)
 		transactions := v1.Group("/transactions")
 		transactions.Use(middleware.AuthMiddleware(authUseCase))
 		{
-			// Both admin and customer can create transactions
-			// But customers can only create for themselves (will be validated in handler)
-			transactions.POST("", transactionHandler.CreateTransaction)
+			// The :id here is a transaction ID, so ownership is checked in the handler
+			transactions.GET("/:id/schedule", transactionHandler.GetTransactionSchedule)
+			transactions.GET("/:id/payments", paymentHandler.GetTransactionPayments)
+			transactions.GET("/:id/contract", contractHandler.DownloadContract)
 
 			// Protected routes with ownership middleware
 			protected := transactions.Group("")
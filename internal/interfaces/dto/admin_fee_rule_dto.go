@@ -0,0 +1,39 @@
+package dto
+
+import (
+	"pt-xyz-multifinance/internal/domain/entity"
+	"time"
+)
+
+type AdminFeeTierRequest struct {
+	UpToOTR float64             `json:"up_to_otr" binding:"min=0"`
+	Type    entity.AdminFeeType `json:"type" binding:"required,oneof=FLAT PERCENTAGE"`
+	Value   float64             `json:"value" binding:"min=0"`
+}
+
+type AdminFeeRuleRequest struct {
+	Name              string                   `json:"name" binding:"required,min=2,max=255"`
+	ProductID         *uint64                  `json:"product_id"`
+	TransactionSource entity.TransactionSource `json:"transaction_source" binding:"omitempty,oneof=ECOMMERCE WEB DEALER"`
+	Type              entity.AdminFeeType      `json:"type" binding:"required,oneof=FLAT PERCENTAGE TIERED"`
+	Value             float64                  `json:"value" binding:"min=0"`
+	Tiers             []AdminFeeTierRequest    `json:"tiers" binding:"omitempty,dive"`
+	MinFee            float64                  `json:"min_fee" binding:"min=0"`
+	MaxFee            float64                  `json:"max_fee" binding:"min=0"`
+	IsActive          *bool                    `json:"is_active"`
+}
+
+type AdminFeeRuleResponse struct {
+	ID                uint64                   `json:"id"`
+	Name              string                   `json:"name"`
+	ProductID         *uint64                  `json:"product_id,omitempty"`
+	TransactionSource entity.TransactionSource `json:"transaction_source,omitempty"`
+	Type              entity.AdminFeeType      `json:"type"`
+	Value             float64                  `json:"value"`
+	Tiers             []entity.AdminFeeTier    `json:"tiers,omitempty"`
+	MinFee            float64                  `json:"min_fee"`
+	MaxFee            float64                  `json:"max_fee"`
+	IsActive          bool                     `json:"is_active"`
+	CreatedAt         time.Time                `json:"created_at"`
+	UpdatedAt         time.Time                `json:"updated_at"`
+}
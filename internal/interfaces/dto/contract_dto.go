@@ -0,0 +1,24 @@
+package dto
+
+import (
+	"time"
+)
+
+type CreateContractTemplateRequest struct {
+	Name string `json:"name" binding:"required,min=2,max=255"`
+	Body string `json:"body" binding:"required"`
+}
+
+type UpdateContractTemplateStatusRequest struct {
+	IsActive *bool `json:"is_active" binding:"required"`
+}
+
+type ContractTemplateResponse struct {
+	ID        uint64    `json:"id"`
+	Version   int       `json:"version"`
+	Name      string    `json:"name"`
+	Body      string    `json:"body"`
+	IsActive  bool      `json:"is_active"`
+	CreatedBy uint64    `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -1,40 +1,51 @@
 package dto
 
 import (
+	"pt-xyz-multifinance/internal/domain/entity"
 	"time"
 )
 
 type CreateCustomerRequest struct {
-	NIK             string               `json:"nik" binding:"required,len=16"`
-	FullName        string               `json:"full_name" binding:"required,min=2,max=255"`
-	LegalName       string               `json:"legal_name" binding:"required,min=2,max=255"`
-	BirthPlace      string               `json:"birth_place" binding:"required,min=2,max=255"`
-	BirthDate       string               `json:"birth_date" binding:"required"`
-	Salary          float64              `json:"salary" binding:"required,min=0"`
-	KTPPhotoPath    string               `json:"ktp_photo_path"`
-	SelfiePhotoPath string               `json:"selfie_photo_path"`
-	Limits          []CreateLimitRequest `json:"limits" binding:"required,min=4,max=4"`
+	NIK        string               `json:"nik" binding:"required,len=16"`
+	FullName   string               `json:"full_name" binding:"required,min=2,max=255"`
+	LegalName  string               `json:"legal_name" binding:"required,min=2,max=255"`
+	BirthPlace string               `json:"birth_place" binding:"required,min=2,max=255"`
+	BirthDate  string               `json:"birth_date" binding:"required"`
+	Salary     float64              `json:"salary" binding:"required,min=0"`
+	Limits     []CreateLimitRequest `json:"limits" binding:"omitempty,dive"`
 }
 
 type CreateLimitRequest struct {
-	TenorMonths int     `json:"tenor_months" binding:"required,oneof=1 2 3 4"`
+	TenorMonths int     `json:"tenor_months" binding:"required,tenor"`
 	LimitAmount float64 `json:"limit_amount" binding:"required,min=1"`
 }
 
 type CustomerResponse struct {
-	ID              uint64       `json:"id"`
-	UserID          uint64       `json:"user_id"`
-	NIK             string       `json:"nik"`
-	FullName        string       `json:"full_name"`
-	LegalName       string       `json:"legal_name"`
-	BirthPlace      string       `json:"birth_place"`
-	BirthDate       time.Time    `json:"birth_date"`
-	Salary          float64      `json:"salary"`
-	KTPPhotoPath    string       `json:"ktp_photo_path"`
-	SelfiePhotoPath string       `json:"selfie_photo_path"`
-	User            UserResponse `json:"user,omitempty"`
-	CreatedAt       time.Time    `json:"created_at"`
-	UpdatedAt       time.Time    `json:"updated_at"`
+	ID               uint64           `json:"id"`
+	UserID           uint64           `json:"user_id"`
+	NIK              string           `json:"nik"`
+	FullName         string           `json:"full_name"`
+	LegalName        string           `json:"legal_name"`
+	BirthPlace       string           `json:"birth_place"`
+	BirthDate        time.Time        `json:"birth_date"`
+	Salary           float64          `json:"salary"`
+	Sex              entity.Sex       `json:"sex,omitempty"`
+	ProvinceCode     string           `json:"province_code,omitempty"`
+	RegencyCode      string           `json:"regency_code,omitempty"`
+	DistrictCode     string           `json:"district_code,omitempty"`
+	KTPPhotoPath     string           `json:"ktp_photo_path"`
+	SelfiePhotoPath  string           `json:"selfie_photo_path"`
+	KTPUploadedAt    *time.Time       `json:"ktp_uploaded_at,omitempty"`
+	SelfieUploadedAt *time.Time       `json:"selfie_uploaded_at,omitempty"`
+	KYCStatus        entity.KYCStatus `json:"kyc_status"`
+	KYCReviewReason  string           `json:"kyc_review_reason,omitempty"`
+	KYCReviewedAt    *time.Time       `json:"kyc_reviewed_at,omitempty"`
+	CreditBalance    float64          `json:"credit_balance"`
+	User             UserResponse     `json:"user,omitempty"`
+	CreatedAt        time.Time        `json:"created_at"`
+	UpdatedAt        time.Time        `json:"updated_at"`
+
+	LimitChangeRequests []LimitChangeRequestResponse `json:"limit_change_requests,omitempty"`
 }
 
 type CustomerLimitResponse struct {
@@ -45,3 +56,92 @@ type CustomerLimitResponse struct {
 	UsedAmount      float64 `json:"used_amount"`
 	AvailableAmount float64 `json:"available_amount"`
 }
+
+type AdjustUsedAmountRequest struct {
+	Amount float64 `json:"amount" binding:"required"`
+	Reason string  `json:"reason" binding:"required,min=3,max=500"`
+}
+
+type LimitMovementResponse struct {
+	ID              uint64                   `json:"id"`
+	Type            entity.LimitMovementType `json:"type"`
+	Amount          float64                  `json:"amount"`
+	UsedAmountAfter float64                  `json:"used_amount_after"`
+	TransactionID   *uint64                  `json:"transaction_id,omitempty"`
+	PaymentID       *uint64                  `json:"payment_id,omitempty"`
+	AdminID         *uint64                  `json:"admin_id,omitempty"`
+	Reason          string                   `json:"reason"`
+	CreatedAt       time.Time                `json:"created_at"`
+}
+
+type LimitHistoryResponse struct {
+	Limit       CustomerLimitResponse   `json:"limit"`
+	LedgerTotal float64                 `json:"ledger_total"`
+	InSync      bool                    `json:"in_sync"`
+	Movements   []LimitMovementResponse `json:"movements"`
+}
+
+type UpdateLimitAmountRequest struct {
+	LimitAmount float64 `json:"limit_amount" binding:"required,gt=0"`
+	Reason      string  `json:"reason" binding:"required,min=3,max=500"`
+}
+
+type LimitChangeResponse struct {
+	ID             uint64    `json:"id"`
+	CustomerID     uint64    `json:"customer_id"`
+	TenorMonths    int       `json:"tenor_months"`
+	OldLimitAmount float64   `json:"old_limit_amount"`
+	NewLimitAmount float64   `json:"new_limit_amount"`
+	ChangedBy      uint64    `json:"changed_by"`
+	Reason         string    `json:"reason"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+type RecommendedLimitResponse struct {
+	TenorMonths        int     `json:"tenor_months"`
+	CurrentLimitAmount float64 `json:"current_limit_amount"`
+	RecommendedAmount  float64 `json:"recommended_amount"`
+	Multiplier         float64 `json:"multiplier"`
+	AgeFactor          float64 `json:"age_factor"`
+	Reasoning          string  `json:"reasoning"`
+}
+
+type LimitRecommendationResponse struct {
+	CustomerID uint64                     `json:"customer_id"`
+	Salary     float64                    `json:"salary"`
+	Age        int                        `json:"age"`
+	Limits     []RecommendedLimitResponse `json:"limits"`
+	Reasoning  []string                   `json:"reasoning"`
+}
+
+type KYCDocumentResponse struct {
+	DocumentType entity.KYCDocumentType `json:"document_type"`
+	ObjectKey    string                 `json:"object_key"`
+	Checksum     string                 `json:"checksum"`
+	UploadedAt   *time.Time             `json:"uploaded_at"`
+	URL          string                 `json:"url"`
+	URLExpiresAt time.Time              `json:"url_expires_at"`
+}
+
+type ApproveKYCRequest struct {
+	Reason string `json:"reason" binding:"max=500"`
+}
+
+type RejectKYCRequest struct {
+	Reason string `json:"reason" binding:"required,min=3,max=500"`
+}
+
+type KYCReviewResponse struct {
+	CustomerID   uint64                `json:"customer_id"`
+	NIK          string                `json:"nik"`
+	FullName     string                `json:"full_name"`
+	LegalName    string                `json:"legal_name"`
+	BirthPlace   string                `json:"birth_place"`
+	BirthDate    time.Time             `json:"birth_date"`
+	KYCStatus    entity.KYCStatus      `json:"kyc_status"`
+	SubmittedAt  *time.Time            `json:"submitted_at"`
+	ReviewReason string                `json:"review_reason,omitempty"`
+	ReviewedBy   *uint64               `json:"reviewed_by,omitempty"`
+	ReviewedAt   *time.Time            `json:"reviewed_at,omitempty"`
+	Documents    []KYCDocumentResponse `json:"documents"`
+}
@@ -0,0 +1,47 @@
+package dto
+
+import (
+	"pt-xyz-multifinance/internal/domain/entity"
+	"time"
+)
+
+type ResolveDuplicateRequest struct {
+	Status entity.DuplicateStatus `json:"status" binding:"required,oneof=CONFIRMED DISMISSED"`
+	Note   string                 `json:"note" binding:"required,min=3,max=500"`
+}
+
+// DuplicateCustomerResponse is the part of a customer an admin compares when
+// reviewing a duplicate cluster.
+type DuplicateCustomerResponse struct {
+	ID         uint64           `json:"id"`
+	NIK        string           `json:"nik"`
+	FullName   string           `json:"full_name"`
+	LegalName  string           `json:"legal_name"`
+	BirthPlace string           `json:"birth_place"`
+	BirthDate  time.Time        `json:"birth_date"`
+	KYCStatus  entity.KYCStatus `json:"kyc_status"`
+	CreatedAt  time.Time        `json:"created_at"`
+}
+
+type CustomerDuplicateResponse struct {
+	ID                uint64                 `json:"id"`
+	CustomerID        uint64                 `json:"customer_id"`
+	MatchedCustomerID uint64                 `json:"matched_customer_id"`
+	Score             float64                `json:"score"`
+	NameSimilarity    float64                `json:"name_similarity"`
+	BirthDateMatch    bool                   `json:"birth_date_match"`
+	BirthPlaceMatch   bool                   `json:"birth_place_match"`
+	NIKDistance       int                    `json:"nik_distance"`
+	Reasons           string                 `json:"reasons"`
+	Status            entity.DuplicateStatus `json:"status"`
+	ResolutionNote    string                 `json:"resolution_note,omitempty"`
+	ResolvedBy        *uint64                `json:"resolved_by,omitempty"`
+	ResolvedAt        *time.Time             `json:"resolved_at,omitempty"`
+	CreatedAt         time.Time              `json:"created_at"`
+}
+
+type DuplicateClusterResponse struct {
+	MaxScore  float64                     `json:"max_score"`
+	Customers []DuplicateCustomerResponse `json:"customers"`
+	Flags     []CustomerDuplicateResponse `json:"flags"`
+}
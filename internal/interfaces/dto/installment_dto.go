@@ -0,0 +1,33 @@
+package dto
+
+import (
+	"pt-xyz-multifinance/internal/domain/entity"
+	"time"
+)
+
+type InstallmentScheduleResponse struct {
+	ID                uint64                   `json:"id"`
+	InstallmentNumber int                      `json:"installment_number"`
+	DueDate           string                   `json:"due_date"`
+	PrincipalAmount   float64                  `json:"principal_amount"`
+	InterestAmount    float64                  `json:"interest_amount"`
+	AdminFeeAmount    float64                  `json:"admin_fee_amount"`
+	AmountDue         float64                  `json:"amount_due"`
+	PenaltyAmount     float64                  `json:"penalty_amount"`
+	AmountPaid        float64                  `json:"amount_paid"`
+	Outstanding       float64                  `json:"outstanding"`
+	DaysPastDue       int                      `json:"days_past_due"`
+	Status            entity.InstallmentStatus `json:"status"`
+	PaidAt            *time.Time               `json:"paid_at,omitempty"`
+	CreatedAt         time.Time                `json:"created_at"`
+}
+
+type TransactionScheduleResponse struct {
+	TransactionID  uint64                        `json:"transaction_id"`
+	ContractNumber string                        `json:"contract_number"`
+	TenorMonths    int                           `json:"tenor_months"`
+	TotalAmountDue float64                       `json:"total_amount_due"`
+	DaysPastDue    int                           `json:"days_past_due"`
+	AccruedPenalty float64                       `json:"accrued_penalty"`
+	Installments   []InstallmentScheduleResponse `json:"installments"`
+}
@@ -0,0 +1,44 @@
+package dto
+
+import (
+	"pt-xyz-multifinance/internal/domain/entity"
+	"time"
+)
+
+type CreateLimitChangeRequest struct {
+	TenorMonths          int      `json:"tenor_months" binding:"required,tenor"`
+	RequestedLimitAmount float64  `json:"requested_limit_amount" binding:"required,gt=0"`
+	UpdatedSalary        *float64 `json:"updated_salary" binding:"omitempty,gt=0"`
+	Note                 string   `json:"note" binding:"max=500"`
+}
+
+type ApproveLimitChangeRequest struct {
+	ApprovedLimitAmount float64 `json:"approved_limit_amount" binding:"omitempty,gt=0"`
+	Reason              string  `json:"reason" binding:"max=500"`
+}
+
+type RejectLimitChangeRequest struct {
+	Reason string `json:"reason" binding:"required,min=3,max=500"`
+}
+
+type LimitChangeRequestResponse struct {
+	ID                   uint64                          `json:"id"`
+	CustomerID           uint64                          `json:"customer_id"`
+	TenorMonths          int                             `json:"tenor_months"`
+	CurrentLimitAmount   float64                         `json:"current_limit_amount"`
+	RequestedLimitAmount float64                         `json:"requested_limit_amount"`
+	ApprovedLimitAmount  float64                         `json:"approved_limit_amount,omitempty"`
+	UpdatedSalary        *float64                        `json:"updated_salary,omitempty"`
+	DocumentKey          string                          `json:"document_key,omitempty"`
+	DocumentChecksum     string                          `json:"document_checksum,omitempty"`
+	DocumentUploadedAt   *time.Time                      `json:"document_uploaded_at,omitempty"`
+	DocumentURL          string                          `json:"document_url,omitempty"`
+	DocumentURLExpiresAt *time.Time                      `json:"document_url_expires_at,omitempty"`
+	Note                 string                          `json:"note,omitempty"`
+	Status               entity.LimitChangeRequestStatus `json:"status"`
+	ReviewReason         string                          `json:"review_reason,omitempty"`
+	ReviewedBy           *uint64                         `json:"reviewed_by,omitempty"`
+	ReviewedAt           *time.Time                      `json:"reviewed_at,omitempty"`
+	CreatedAt            time.Time                       `json:"created_at"`
+	UpdatedAt            time.Time                       `json:"updated_at"`
+}
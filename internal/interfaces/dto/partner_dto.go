@@ -0,0 +1,55 @@
+package dto
+
+import (
+	"pt-xyz-multifinance/internal/domain/entity"
+	"time"
+)
+
+type PartnerRequest struct {
+	Type                    entity.PartnerType   `json:"type" binding:"required,oneof=DEALER MERCHANT"`
+	Name                    string               `json:"name" binding:"required,min=2,max=255"`
+	SettlementBankName      string               `json:"settlement_bank_name" binding:"required,max=100"`
+	SettlementAccountNumber string               `json:"settlement_account_number" binding:"required,max=50,numeric"`
+	SettlementAccountName   string               `json:"settlement_account_name" binding:"required,max=255"`
+	Status                  entity.PartnerStatus `json:"status" binding:"omitempty,oneof=ACTIVE SUSPENDED TERMINATED"`
+}
+
+type PartnerResponse struct {
+	ID                      uint64               `json:"id"`
+	Type                    entity.PartnerType   `json:"type"`
+	Name                    string               `json:"name"`
+	SettlementBankName      string               `json:"settlement_bank_name"`
+	SettlementAccountNumber string               `json:"settlement_account_number"`
+	SettlementAccountName   string               `json:"settlement_account_name"`
+	Status                  entity.PartnerStatus `json:"status"`
+	CreatedAt               time.Time            `json:"created_at"`
+	UpdatedAt               time.Time            `json:"updated_at"`
+}
+
+type PartnerCredentialResponse struct {
+	ID         uint64     `json:"id"`
+	PartnerID  uint64     `json:"partner_id"`
+	APIKey     string     `json:"api_key"`
+	Active     bool       `json:"active"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	CreatedBy  *uint64    `json:"created_by,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// IssuedPartnerCredentialResponse is the only response carrying the secret.
+type IssuedPartnerCredentialResponse struct {
+	PartnerCredentialResponse
+	APISecret string `json:"api_secret"`
+}
+
+type PartnerCustomerResponse struct {
+	ID          uint64             `json:"id"`
+	PartnerID   uint64             `json:"partner_id"`
+	PartnerName string             `json:"partner_name,omitempty"`
+	PartnerType entity.PartnerType `json:"partner_type,omitempty"`
+	CustomerID  uint64             `json:"customer_id"`
+	LinkedBy    uint64             `json:"linked_by"`
+	CreatedAt   time.Time          `json:"created_at"`
+}
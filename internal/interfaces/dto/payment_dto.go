@@ -0,0 +1,41 @@
+package dto
+
+import (
+	"time"
+)
+
+type RecordPaymentRequest struct {
+	Amount          float64 `json:"amount" binding:"required,gt=0"`
+	PaymentDate     string  `json:"payment_date"`
+	PaymentMethod   string  `json:"payment_method" binding:"required,min=2,max=50"`
+	ReferenceNumber string  `json:"reference_number" binding:"max=100"`
+	Notes           string  `json:"notes" binding:"max=500"`
+}
+
+type PaymentAllocationResponse struct {
+	InstallmentID uint64  `json:"installment_id"`
+	Penalty       float64 `json:"penalty"`
+	Interest      float64 `json:"interest"`
+	AdminFee      float64 `json:"admin_fee"`
+	Principal     float64 `json:"principal"`
+}
+
+type PaymentResponse struct {
+	ID                 uint64                      `json:"id"`
+	TransactionID      uint64                      `json:"transaction_id"`
+	CustomerID         uint64                      `json:"customer_id"`
+	Amount             float64                     `json:"amount"`
+	PaymentDate        time.Time                   `json:"payment_date"`
+	PaymentMethod      string                      `json:"payment_method"`
+	ReferenceNumber    string                      `json:"reference_number"`
+	AllocatedPenalty   float64                     `json:"allocated_penalty"`
+	AllocatedInterest  float64                     `json:"allocated_interest"`
+	AllocatedAdminFee  float64                     `json:"allocated_admin_fee"`
+	AllocatedPrincipal float64                     `json:"allocated_principal"`
+	ExcessAmount       float64                     `json:"excess_amount"`
+	LimitRestored      float64                     `json:"limit_restored"`
+	Notes              string                      `json:"notes"`
+	RecordedBy         uint64                      `json:"recorded_by"`
+	Allocations        []PaymentAllocationResponse `json:"allocations"`
+	CreatedAt          time.Time                   `json:"created_at"`
+}
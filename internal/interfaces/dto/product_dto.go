@@ -0,0 +1,75 @@
+package dto
+
+import (
+	"pt-xyz-multifinance/internal/domain/entity"
+	"time"
+)
+
+type ProductRequest struct {
+	Code               string              `json:"code" binding:"required,min=2,max=50"`
+	Name               string              `json:"name" binding:"required,min=2,max=255"`
+	Description        string              `json:"description" binding:"max=500"`
+	AllowedTenors      []int               `json:"allowed_tenors" binding:"required,min=1,dive,gt=0"`
+	InterestRate       float64             `json:"interest_rate" binding:"min=0"`
+	AdminFeeType       entity.AdminFeeType `json:"admin_fee_type" binding:"required,oneof=FLAT PERCENTAGE"`
+	AdminFeeValue      float64             `json:"admin_fee_value" binding:"min=0"`
+	MinOTR             float64             `json:"min_otr" binding:"min=0"`
+	MaxOTR             float64             `json:"max_otr" binding:"min=0"`
+	EligibleAssetTypes []entity.AssetType  `json:"eligible_asset_types" binding:"required,min=1"`
+	MinAge             int                 `json:"min_age" binding:"min=0,max=100"`
+	MaxAgeAtTenorEnd   int                 `json:"max_age_at_tenor_end" binding:"min=0,max=100"`
+	IsActive           *bool               `json:"is_active"`
+}
+
+type ProductResponse struct {
+	ID                 uint64              `json:"id"`
+	Code               string              `json:"code"`
+	Name               string              `json:"name"`
+	Description        string              `json:"description,omitempty"`
+	AllowedTenors      []int               `json:"allowed_tenors"`
+	InterestRate       float64             `json:"interest_rate"`
+	AdminFeeType       entity.AdminFeeType `json:"admin_fee_type"`
+	AdminFeeValue      float64             `json:"admin_fee_value"`
+	MinOTR             float64             `json:"min_otr"`
+	MaxOTR             float64             `json:"max_otr"`
+	EligibleAssetTypes []entity.AssetType  `json:"eligible_asset_types"`
+	MinAge             int                 `json:"min_age,omitempty"`
+	MaxAgeAtTenorEnd   int                 `json:"max_age_at_tenor_end,omitempty"`
+	IsActive           bool                `json:"is_active"`
+	CreatedAt          time.Time           `json:"created_at"`
+	UpdatedAt          time.Time           `json:"updated_at"`
+}
+
+type CreateRateCardRequest struct {
+	TenorMonths   int                 `json:"tenor_months" binding:"required,min=1"`
+	AssetType     entity.AssetType    `json:"asset_type" binding:"required"`
+	InterestRate  float64             `json:"interest_rate" binding:"min=0"`
+	AdminFeeType  entity.AdminFeeType `json:"admin_fee_type" binding:"required,oneof=FLAT PERCENTAGE"`
+	AdminFeeValue float64             `json:"admin_fee_value" binding:"min=0"`
+	EffectiveFrom string              `json:"effective_from"` // YYYY-MM-DD or RFC 3339, defaults to now
+	EffectiveTo   string              `json:"effective_to"`   // YYYY-MM-DD or RFC 3339, empty for open-ended
+}
+
+type RateCardResponse struct {
+	ID            uint64              `json:"id"`
+	ProductID     uint64              `json:"product_id"`
+	TenorMonths   int                 `json:"tenor_months"`
+	AssetType     entity.AssetType    `json:"asset_type"`
+	InterestRate  float64             `json:"interest_rate"`
+	AdminFeeType  entity.AdminFeeType `json:"admin_fee_type"`
+	AdminFeeValue float64             `json:"admin_fee_value"`
+	EffectiveFrom time.Time           `json:"effective_from"`
+	EffectiveTo   *time.Time          `json:"effective_to,omitempty"`
+	CreatedBy     uint64              `json:"created_by"`
+	CreatedAt     time.Time           `json:"created_at"`
+}
+
+// AgeIneligibleResponse carries the coded reason an applicant's age was
+// refused.
+type AgeIneligibleResponse struct {
+	Code        entity.EligibilityCode `json:"code"`
+	ProductCode string                 `json:"product_code"`
+	TenorMonths int                    `json:"tenor_months,omitempty"`
+	Age         int                    `json:"age"`
+	Limit       int                    `json:"limit"`
+}
@@ -7,28 +7,47 @@ import (
 
 type CreateTransactionRequest struct {
 	CustomerID        uint64                   `json:"customer_id" binding:"required"`
-	TenorMonths       int                      `json:"tenor_months" binding:"required,min=1"`
+	ProductID         uint64                   `json:"product_id"`
+	TenorMonths       int                      `json:"tenor_months" binding:"required,tenor"`
 	OTRAmount         float64                  `json:"otr_amount" binding:"required,min=0"`
-	AdminFee          float64                  `json:"admin_fee" binding:"required,min=0"`
-	InterestAmount    float64                  `json:"interest_amount" binding:"required,min=0"`
+	DownPaymentAmount float64                  `json:"down_payment_amount" binding:"min=0"`
+	AdminFee          *float64                 `json:"admin_fee" binding:"omitempty,min=0"` // optional, checked against the computed fee
 	AssetName         string                   `json:"asset_name" binding:"required,min=2"`
 	AssetType         entity.AssetType         `json:"asset_type" binding:"required"`
-	TransactionSource entity.TransactionSource `json:"transaction_source" binding:"required"`
+	TransactionSource entity.TransactionSource `json:"transaction_source"` // required unless a partner sends the request
 }
 
 type TransactionResponse struct {
 	ID                uint64                   `json:"id"`
 	ContractNumber    string                   `json:"contract_number"`
 	CustomerID        uint64                   `json:"customer_id"`
+	ProductID         uint64                   `json:"product_id"`
+	RateCardID        *uint64                  `json:"rate_card_id,omitempty"`
 	TenorMonths       int                      `json:"tenor_months"`
 	OTRAmount         float64                  `json:"otr_amount"`
+	DownPaymentAmount float64                  `json:"down_payment_amount"`
+	FinancedAmount    float64                  `json:"financed_amount"`
 	AdminFee          float64                  `json:"admin_fee"`
+	AdminFeeRuleID    *uint64                  `json:"admin_fee_rule_id,omitempty"`
 	InstallmentAmount float64                  `json:"installment_amount"`
 	InterestAmount    float64                  `json:"interest_amount"`
+	InterestMethod    entity.InterestMethod    `json:"interest_method"`
+	InterestRate      float64                  `json:"interest_rate"`
 	AssetName         string                   `json:"asset_name"`
 	AssetType         entity.AssetType         `json:"asset_type"`
 	Status            entity.TransactionStatus `json:"status"`
 	TransactionSource entity.TransactionSource `json:"transaction_source"`
+	PartnerID         *uint64                  `json:"partner_id,omitempty"`
+	StatusReason      string                   `json:"status_reason,omitempty"`
+	ApprovedAt        *time.Time               `json:"approved_at,omitempty"`
+	RejectedAt        *time.Time               `json:"rejected_at,omitempty"`
+	ActivatedAt       *time.Time               `json:"activated_at,omitempty"`
+	CompletedAt       *time.Time               `json:"completed_at,omitempty"`
+	DefaultedAt       *time.Time               `json:"defaulted_at,omitempty"`
+	DaysPastDue       int                      `json:"days_past_due"`
+	AccruedPenalty    float64                  `json:"accrued_penalty"`
+	LimitRestored     float64                  `json:"limit_restored"`
+	DebtToIncomeRatio float64                  `json:"debt_to_income_ratio"`
 	Customer          CustomerResponse         `json:"customer"`
 	CreatedAt         time.Time                `json:"created_at"`
 	UpdatedAt         time.Time                `json:"updated_at"`
@@ -36,4 +55,52 @@ type TransactionResponse struct {
 
 type UpdateTransactionStatusRequest struct {
 	Status entity.TransactionStatus `json:"status" binding:"required"`
+	Reason string                   `json:"reason" binding:"max=500"`
+}
+
+type InvalidStatusTransitionResponse struct {
+	CurrentStatus   entity.TransactionStatus   `json:"current_status"`
+	RequestedStatus entity.TransactionStatus   `json:"requested_status"`
+	AllowedStatuses []entity.TransactionStatus `json:"allowed_statuses"`
+}
+
+type AdminFeeMismatchResponse struct {
+	ExpectedAdminFee float64 `json:"expected_admin_fee"`
+	ProvidedAdminFee float64 `json:"provided_admin_fee"`
+}
+
+type AffordabilityErrorResponse struct {
+	Salary               float64 `json:"salary"`
+	ExistingInstallments float64 `json:"existing_installments"`
+	NewInstallment       float64 `json:"new_installment"`
+	DebtToIncomeRatio    float64 `json:"debt_to_income_ratio"`
+	MaxDebtToIncomeRatio float64 `json:"max_debt_to_income_ratio"`
+}
+
+type SimulateTransactionRequest struct {
+	CustomerID        uint64                   `json:"customer_id" binding:"required"`
+	ProductID         uint64                   `json:"product_id"`
+	OTRAmount         float64                  `json:"otr_amount" binding:"required,gt=0"`
+	DownPaymentAmount float64                  `json:"down_payment_amount" binding:"min=0"`
+	AssetType         entity.AssetType         `json:"asset_type" binding:"required"`
+	TransactionSource entity.TransactionSource `json:"transaction_source"` // required unless a partner sends the request
+}
+
+type SimulationOptionResponse struct {
+	ProductID         uint64                `json:"product_id"`
+	ProductCode       string                `json:"product_code"`
+	TenorMonths       int                   `json:"tenor_months"`
+	RateCardID        *uint64               `json:"rate_card_id,omitempty"`
+	InterestMethod    entity.InterestMethod `json:"interest_method"`
+	InterestRate      float64               `json:"interest_rate"`
+	DownPaymentAmount float64               `json:"down_payment_amount"`
+	FinancedAmount    float64               `json:"financed_amount"`
+	AdminFee          float64               `json:"admin_fee"`
+	InterestAmount    float64               `json:"interest_amount"`
+	InstallmentAmount float64               `json:"installment_amount"`
+	TotalPayable      float64               `json:"total_payable"`
+	AvailableLimit    float64               `json:"available_limit"`
+	LimitSufficient   bool                  `json:"limit_sufficient"`
+	DebtToIncomeRatio float64               `json:"debt_to_income_ratio"`
+	Affordable        bool                  `json:"affordable"`
 }
@@ -0,0 +1,21 @@
+package dto
+
+import (
+	"pt-xyz-multifinance/pkg/utils"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// RegisterValidators adds the custom binding tags used by the request DTOs.
+// The "tenor" tag accepts only tenors offered by the product catalog.
+func RegisterValidators() error {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return nil
+	}
+
+	return v.RegisterValidation("tenor", func(fl validator.FieldLevel) bool {
+		return utils.IsValidTenor(int(fl.Field().Int()))
+	})
+}
@@ -0,0 +1,53 @@
+package dto
+
+import (
+	"pt-xyz-multifinance/internal/domain/entity"
+	"time"
+)
+
+type WatchlistRequest struct {
+	NIK       string `json:"nik" binding:"required,len=16,numeric"`
+	Name      string `json:"name" binding:"max=255"`
+	Reason    string `json:"reason" binding:"required,min=3,max=500"`
+	Source    string `json:"source" binding:"required,max=100"`
+	ExpiresAt string `json:"expires_at" binding:"omitempty,datetime=2006-01-02"`
+}
+
+type WatchlistResponse struct {
+	ID        uint64     `json:"id"`
+	NIK       string     `json:"nik"`
+	Name      string     `json:"name"`
+	Reason    string     `json:"reason"`
+	Source    string     `json:"source"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	CreatedBy *uint64    `json:"created_by,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+type WatchlistImportResponse struct {
+	Created int `json:"created"`
+	Updated int `json:"updated"`
+}
+
+type ResolveWatchlistHitRequest struct {
+	Status entity.WatchlistHitStatus `json:"status" binding:"required,oneof=CLEARED CONFIRMED"`
+	Note   string                    `json:"note" binding:"required,min=3,max=500"`
+}
+
+type WatchlistHitResponse struct {
+	ID             uint64                    `json:"id"`
+	WatchlistID    uint64                    `json:"watchlist_id"`
+	NIK            string                    `json:"nik"`
+	Stage          entity.ScreeningStage     `json:"stage"`
+	Action         entity.WatchlistAction    `json:"action"`
+	CustomerID     *uint64                   `json:"customer_id,omitempty"`
+	TransactionID  *uint64                   `json:"transaction_id,omitempty"`
+	Status         entity.WatchlistHitStatus `json:"status"`
+	Reason         string                    `json:"reason,omitempty"`
+	Source         string                    `json:"source,omitempty"`
+	ResolutionNote string                    `json:"resolution_note,omitempty"`
+	ResolvedBy     *uint64                   `json:"resolved_by,omitempty"`
+	ResolvedAt     *time.Time                `json:"resolved_at,omitempty"`
+	CreatedAt      time.Time                 `json:"created_at"`
+}
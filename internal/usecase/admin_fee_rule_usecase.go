@@ -0,0 +1,194 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"pt-xyz-multifinance/internal/domain/entity"
+	"pt-xyz-multifinance/internal/domain/repository"
+	"pt-xyz-multifinance/internal/domain/service"
+	"pt-xyz-multifinance/pkg/logger"
+	"strings"
+	"time"
+)
+
+type AdminFeeMismatchAction string
+
+const (
+	AdminFeeMismatchReject AdminFeeMismatchAction = "REJECT"
+	AdminFeeMismatchIgnore AdminFeeMismatchAction = "IGNORE"
+)
+
+// AdminFeePolicy decides what happens when a client sends an admin fee that
+// differs from the one computed on the server.
+type AdminFeePolicy struct {
+	OnMismatch AdminFeeMismatchAction
+}
+
+// AdminFeeQuote is a server-computed admin fee and the rule behind it. Rule
+// is nil when the rate card fee applied.
+type AdminFeeQuote struct {
+	Amount float64
+	Rule   *entity.AdminFeeRule
+}
+
+type AdminFeeRuleUseCase interface {
+	CreateRule(ctx context.Context, rule *entity.AdminFeeRule) error
+	GetRuleByID(ctx context.Context, id uint64) (*entity.AdminFeeRule, error)
+	GetAllRules(ctx context.Context) ([]*entity.AdminFeeRule, error)
+	UpdateRule(ctx context.Context, rule *entity.AdminFeeRule) error
+	DeleteRule(ctx context.Context, id uint64) error
+	QuoteAdminFee(ctx context.Context, product *entity.Product, card *entity.RateCard, source entity.TransactionSource, otrAmount float64) (*AdminFeeQuote, error)
+}
+
+type adminFeeRuleUseCase struct {
+	ruleRepo    repository.AdminFeeRuleRepository
+	productRepo repository.ProductRepository
+}
+
+func NewAdminFeeRuleUseCase(ruleRepo repository.AdminFeeRuleRepository, productRepo repository.ProductRepository) AdminFeeRuleUseCase {
+	return &adminFeeRuleUseCase{
+		ruleRepo:    ruleRepo,
+		productRepo: productRepo,
+	}
+}
+
+func (uc *adminFeeRuleUseCase) CreateRule(ctx context.Context, rule *entity.AdminFeeRule) error {
+	if err := uc.validateRule(ctx, rule); err != nil {
+		return err
+	}
+
+	if err := uc.ruleRepo.Create(ctx, rule); err != nil {
+		logger.Error("Failed to create admin fee rule", "name", rule.Name, "error", err)
+		return err
+	}
+
+	logger.Info("Admin fee rule created", "ruleID", rule.ID, "type", rule.Type)
+	return nil
+}
+
+// UpdateRule never edits a rule in place, since contracts keep only the ID of
+// the rule that priced them. The rule is deactivated and the edited copy is
+// stored under a new ID.
+func (uc *adminFeeRuleUseCase) UpdateRule(ctx context.Context, rule *entity.AdminFeeRule) error {
+	if err := uc.validateRule(ctx, rule); err != nil {
+		return err
+	}
+
+	previousID := rule.ID
+	rule.ID = 0
+	rule.CreatedAt = time.Time{}
+	rule.UpdatedAt = time.Time{}
+
+	if err := uc.ruleRepo.Supersede(ctx, previousID, rule); err != nil {
+		logger.Error("Failed to update admin fee rule", "ruleID", previousID, "error", err)
+		rule.ID = previousID
+		return err
+	}
+
+	logger.Info("Admin fee rule superseded", "previousRuleID", previousID, "ruleID", rule.ID, "type", rule.Type)
+	return nil
+}
+
+// DeleteRule deactivates the rule; it stays on record for the contracts it
+// priced.
+func (uc *adminFeeRuleUseCase) DeleteRule(ctx context.Context, id uint64) error {
+	return uc.ruleRepo.Deactivate(ctx, id)
+}
+
+func (uc *adminFeeRuleUseCase) GetRuleByID(ctx context.Context, id uint64) (*entity.AdminFeeRule, error) {
+	return uc.ruleRepo.GetByID(ctx, id)
+}
+
+func (uc *adminFeeRuleUseCase) GetAllRules(ctx context.Context) ([]*entity.AdminFeeRule, error) {
+	return uc.ruleRepo.GetAll(ctx)
+}
+
+// QuoteAdminFee computes the admin fee of a new contract. The most specific
+// active rule for the product and source wins, the newest one among equally
+// specific rules; without one the rate card fee is charged.
+func (uc *adminFeeRuleUseCase) QuoteAdminFee(ctx context.Context, product *entity.Product, card *entity.RateCard, source entity.TransactionSource, otrAmount float64) (*AdminFeeQuote, error) {
+	rules, err := uc.ruleRepo.GetActive(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var selected *entity.AdminFeeRule
+	for _, rule := range rules {
+		if !rule.Matches(product.ID, source) {
+			continue
+		}
+		if selected == nil || rule.Specificity() > selected.Specificity() ||
+			(rule.Specificity() == selected.Specificity() && rule.ID > selected.ID) {
+			selected = rule
+		}
+	}
+
+	if selected != nil {
+		amount, err := service.CalculateAdminFee(selected, otrAmount)
+		if err != nil {
+			return nil, fmt.Errorf("failed to apply admin fee rule %d: %w", selected.ID, err)
+		}
+		return &AdminFeeQuote{Amount: amount, Rule: selected}, nil
+	}
+
+	if card == nil {
+		return nil, fmt.Errorf("%w for product %s", ErrNoEffectiveRateCard, product.Code)
+	}
+
+	amount, err := service.CalculateAdminFee(&entity.AdminFeeRule{Type: card.AdminFeeType, Value: card.AdminFeeValue}, otrAmount)
+	if err != nil {
+		return nil, err
+	}
+	return &AdminFeeQuote{Amount: amount}, nil
+}
+
+func (uc *adminFeeRuleUseCase) validateRule(ctx context.Context, rule *entity.AdminFeeRule) error {
+	rule.Name = strings.TrimSpace(rule.Name)
+	if rule.Name == "" {
+		return fmt.Errorf("rule name is required")
+	}
+
+	if rule.ProductID != nil {
+		if _, err := uc.productRepo.GetByID(ctx, *rule.ProductID); err != nil {
+			return fmt.Errorf("product not found: %w", err)
+		}
+	}
+
+	switch rule.TransactionSource {
+	case "", entity.SourceEcommerce, entity.SourceWeb, entity.SourceDealer:
+	default:
+		return fmt.Errorf("invalid transaction source: %s", rule.TransactionSource)
+	}
+
+	if rule.Type == entity.AdminFeeTiered {
+		if len(rule.Tiers) == 0 {
+			return fmt.Errorf("tiered rules need at least one tier")
+		}
+		openEnded := false
+		for _, tier := range rule.Tiers {
+			if tier.UpToOTR < 0 {
+				return fmt.Errorf("tier upper bound cannot be negative")
+			}
+			if tier.UpToOTR == 0 {
+				openEnded = true
+			}
+			if err := validateAdminFee(tier.Type, tier.Value); err != nil {
+				return err
+			}
+		}
+		if !openEnded {
+			return fmt.Errorf("the last tier must have no upper bound (up_to_otr 0)")
+		}
+	} else if err := validateAdminFee(rule.Type, rule.Value); err != nil {
+		return err
+	}
+
+	if rule.MinFee < 0 || rule.MaxFee < 0 {
+		return fmt.Errorf("fee caps cannot be negative")
+	}
+	if rule.MaxFee > 0 && rule.MaxFee < rule.MinFee {
+		return fmt.Errorf("max fee %.2f is below min fee %.2f", rule.MaxFee, rule.MinFee)
+	}
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package usecase
+
+import (
+	"math"
+	"pt-xyz-multifinance/internal/domain/entity"
+)
+
+// AffordabilityPolicy caps the share of a customer's monthly salary that may
+// go to installments. A MaxDebtToIncome of 0 computes the ratio without
+// rejecting anything.
+type AffordabilityPolicy struct {
+	MaxDebtToIncome float64 // e.g. 0.3 for 30% of salary
+}
+
+// existingInstallments sums the monthly installments the customer owes or is
+// about to owe: contracts that are PENDING, APPROVED, ACTIVE or DEFAULTED.
+// Rejected and completed contracts carry no installments.
+func existingInstallments(transactions []*entity.Transaction) float64 {
+	total := 0.0
+	for _, transaction := range transactions {
+		switch transaction.Status {
+		case entity.StatusPending, entity.StatusApproved, entity.StatusActive, entity.StatusDefaulted:
+			total += transaction.InstallmentAmount
+		}
+	}
+	return roundAmount(total)
+}
+
+// check returns the debt-to-income ratio of the existing installments plus
+// the new one, and an AffordabilityError when it exceeds the cap. Without a
+// salary the ratio cannot be computed, so any installment is unaffordable.
+func (p AffordabilityPolicy) check(salary, existing, installment float64) (float64, error) {
+	ratio := 0.0
+	if salary > 0 {
+		ratio = math.Round((existing+installment)/salary*10000) / 10000
+	}
+
+	if p.MaxDebtToIncome <= 0 {
+		return ratio, nil
+	}
+	if salary <= 0 || ratio > p.MaxDebtToIncome {
+		return ratio, &entity.AffordabilityError{
+			Salary:               salary,
+			ExistingInstallments: existing,
+			NewInstallment:       installment,
+			Ratio:                ratio,
+			MaxRatio:             p.MaxDebtToIncome,
+		}
+	}
+	return ratio, nil
+}
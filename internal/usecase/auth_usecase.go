@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"pt-xyz-multifinance/internal/domain/entity"
 	"pt-xyz-multifinance/internal/domain/repository"
+	"pt-xyz-multifinance/internal/domain/service"
+	"pt-xyz-multifinance/internal/infrastructure/database"
 	"pt-xyz-multifinance/internal/interfaces/dto"
 	"pt-xyz-multifinance/pkg/logger"
 	"time"
@@ -23,25 +25,40 @@ type AuthUseCase interface {
 }
 
 type authUseCase struct {
-	userRepo     repository.UserRepository
-	customerRepo repository.CustomerRepository
-	limitRepo    repository.LimitRepository
-	db           *gorm.DB
-	jwtSecret    string
+	userRepo         repository.UserRepository
+	customerRepo     repository.CustomerRepository
+	limitRepo        repository.LimitRepository
+	limitRecommender service.LimitRecommender
+	nikParser        *service.NIKParser
+	watchlist        WatchlistUseCase
+	duplicates       CustomerDuplicateUseCase
+	productUseCase   ProductUseCase
+	db               *gorm.DB
+	jwtSecret        string
 }
 
 func NewAuthUseCase(
 	userRepo repository.UserRepository,
 	customerRepo repository.CustomerRepository,
 	limitRepo repository.LimitRepository,
+	limitRecommender service.LimitRecommender,
+	nikParser *service.NIKParser,
+	watchlist WatchlistUseCase,
+	duplicates CustomerDuplicateUseCase,
+	productUseCase ProductUseCase,
 	db *gorm.DB,
 ) AuthUseCase {
 	return &authUseCase{
-		userRepo:     userRepo,
-		customerRepo: customerRepo,
-		limitRepo:    limitRepo,
-		db:           db,
-		jwtSecret:    "xyz-secret-key-2024",
+		userRepo:         userRepo,
+		customerRepo:     customerRepo,
+		limitRepo:        limitRepo,
+		limitRecommender: limitRecommender,
+		nikParser:        nikParser,
+		watchlist:        watchlist,
+		duplicates:       duplicates,
+		productUseCase:   productUseCase,
+		db:               db,
+		jwtSecret:        "xyz-secret-key-2024",
 	}
 }
 
@@ -62,15 +79,36 @@ func (uc *authUseCase) Register(ctx context.Context, req *dto.RegisterRequest) (
 	}
 
 	// If role is CUSTOMER, validate customer data and NIK uniqueness
+	var nikInfo *service.NIKInfo
+	var watchlistHits []*entity.WatchlistHit
 	if req.Role == "CUSTOMER" {
 		if req.CustomerData == nil {
 			return nil, nil, fmt.Errorf("customer data is required for customer role")
 		}
 
+		birthDate, err := time.Parse("2006-01-02", req.CustomerData.BirthDate)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid birth date format: %w", err)
+		}
+
+		// The NIK must decode to a valid region and the stated birth date
+		if nikInfo, err = validateNIK(uc.nikParser, req.CustomerData.NIK, birthDate); err != nil {
+			return nil, nil, err
+		}
+
+		// Applicants no active product accepts at their age cannot register
+		if err := uc.productUseCase.CheckApplicantAge(ctx, birthDate, time.Now()); err != nil {
+			return nil, nil, err
+		}
+
 		// Check if NIK already exists
 		if _, err := uc.customerRepo.GetByNIK(ctx, req.CustomerData.NIK); err == nil {
 			return nil, nil, fmt.Errorf("NIK already exists")
 		}
+
+		if watchlistHits, err = uc.watchlist.Screen(ctx, entity.ScreeningRegistration, req.CustomerData.NIK, nil); err != nil {
+			return nil, nil, err
+		}
 	}
 
 	// Hash password
@@ -84,6 +122,7 @@ func (uc *authUseCase) Register(ctx context.Context, req *dto.RegisterRequest) (
 
 	// Start transaction
 	err = uc.db.Transaction(func(tx *gorm.DB) error {
+		ctx := database.WithTx(ctx, tx)
 		// Create user
 		user = &entity.User{
 			Username: req.Username,
@@ -104,28 +143,50 @@ func (uc *authUseCase) Register(ctx context.Context, req *dto.RegisterRequest) (
 			}
 
 			customer = &entity.Customer{
-				UserID:          user.ID,
-				NIK:             req.CustomerData.NIK,
-				FullName:        req.CustomerData.FullName,
-				LegalName:       req.CustomerData.LegalName,
-				BirthPlace:      req.CustomerData.BirthPlace,
-				BirthDate:       birthDate,
-				Salary:          req.CustomerData.Salary,
-				KTPPhotoPath:    req.CustomerData.KTPPhotoPath,
-				SelfiePhotoPath: req.CustomerData.SelfiePhotoPath,
+				UserID:     user.ID,
+				NIK:        req.CustomerData.NIK,
+				FullName:   req.CustomerData.FullName,
+				LegalName:  req.CustomerData.LegalName,
+				BirthPlace: req.CustomerData.BirthPlace,
+				BirthDate:  birthDate,
+				Salary:     req.CustomerData.Salary,
+				KYCStatus:  entity.KYCUnverified,
 			}
+			applyNIKInfo(customer, nikInfo)
 
 			if err := uc.customerRepo.Create(ctx, customer); err != nil {
 				return fmt.Errorf("failed to create customer: %w", err)
 			}
 
+			if err := uc.watchlist.RecordHits(ctx, watchlistHits, customer.ID, nil); err != nil {
+				return err
+			}
+
+			// Likely re-registrations are flagged for review, not refused
+			if _, err := uc.duplicates.FlagDuplicates(ctx, customer); err != nil {
+				return err
+			}
+
+			var limits []*entity.CustomerLimit
 			for _, limitReq := range req.CustomerData.Limits {
-				limit := &entity.CustomerLimit{
-					CustomerID:  customer.ID,
+				limits = append(limits, &entity.CustomerLimit{
 					TenorMonths: limitReq.TenorMonths,
 					LimitAmount: limitReq.LimitAmount,
-					UsedAmount:  0,
-				}
+				})
+			}
+
+			// Tenors without an explicit limit start on the recommended one
+			limits, recommendedTenors, err := withRecommendedLimits(uc.limitRecommender, customer, limits)
+			if err != nil {
+				return err
+			}
+			if len(recommendedTenors) > 0 {
+				logger.Info("Using recommended limits", "customerID", customer.ID, "tenors", recommendedTenors)
+			}
+
+			for _, limit := range limits {
+				limit.CustomerID = customer.ID
+				limit.UsedAmount = 0
 
 				if err := uc.limitRepo.Create(ctx, limit); err != nil {
 					return fmt.Errorf("failed to create customer limit: %w", err)
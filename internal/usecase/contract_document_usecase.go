@@ -0,0 +1,333 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"pt-xyz-multifinance/internal/domain/entity"
+	"pt-xyz-multifinance/internal/domain/repository"
+	"pt-xyz-multifinance/pkg/logger"
+	"pt-xyz-multifinance/pkg/pdf"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// ContractData is what a contract template is executed with.
+type ContractData struct {
+	TemplateVersion int
+	Customer        *entity.Customer
+	Transaction     *entity.Transaction
+	Schedule        []*entity.InstallmentSchedule
+	TotalPayable    float64
+	GeneratedAt     time.Time
+}
+
+type ContractDocumentUseCase interface {
+	CreateTemplate(ctx context.Context, tmpl *entity.ContractTemplate) error
+	GetTemplates(ctx context.Context) ([]*entity.ContractTemplate, error)
+	SetTemplateActive(ctx context.Context, id uint64, active bool) (*entity.ContractTemplate, error)
+	EnsureDefaultTemplate(ctx context.Context) error
+	GenerateContract(ctx context.Context, transactionID uint64) (*entity.ContractDocument, error)
+	IssueContract(ctx context.Context, transaction *entity.Transaction) (*entity.ContractDocument, error)
+	GetContractDocument(ctx context.Context, transactionID uint64) (*entity.ContractDocument, error)
+}
+
+type contractDocumentUseCase struct {
+	templateRepo    repository.ContractTemplateRepository
+	documentRepo    repository.ContractDocumentRepository
+	transactionRepo repository.TransactionRepository
+	customerRepo    repository.CustomerRepository
+	scheduleRepo    repository.InstallmentScheduleRepository
+}
+
+func NewContractDocumentUseCase(
+	templateRepo repository.ContractTemplateRepository,
+	documentRepo repository.ContractDocumentRepository,
+	transactionRepo repository.TransactionRepository,
+	customerRepo repository.CustomerRepository,
+	scheduleRepo repository.InstallmentScheduleRepository,
+) ContractDocumentUseCase {
+	return &contractDocumentUseCase{
+		templateRepo:    templateRepo,
+		documentRepo:    documentRepo,
+		transactionRepo: transactionRepo,
+		customerRepo:    customerRepo,
+		scheduleRepo:    scheduleRepo,
+	}
+}
+
+// CreateTemplate stores the body as the next template version. The body is
+// rendered against sample data first so a broken template never goes live.
+func (uc *contractDocumentUseCase) CreateTemplate(ctx context.Context, tmpl *entity.ContractTemplate) error {
+	tmpl.Name = strings.TrimSpace(tmpl.Name)
+	if tmpl.Name == "" {
+		return fmt.Errorf("template name is required")
+	}
+
+	if _, err := renderContractText(tmpl, sampleContractData()); err != nil {
+		return err
+	}
+
+	tmpl.IsActive = true
+	if err := uc.templateRepo.Create(ctx, tmpl); err != nil {
+		logger.Error("Failed to create contract template", "name", tmpl.Name, "error", err)
+		return err
+	}
+
+	logger.Info("Contract template created", "templateID", tmpl.ID, "version", tmpl.Version)
+	return nil
+}
+
+func (uc *contractDocumentUseCase) GetTemplates(ctx context.Context) ([]*entity.ContractTemplate, error) {
+	return uc.templateRepo.GetAll(ctx)
+}
+
+// SetTemplateActive withdraws or restores a version. Deactivating the newest
+// version rolls new documents back to the previous active one.
+func (uc *contractDocumentUseCase) SetTemplateActive(ctx context.Context, id uint64, active bool) (*entity.ContractTemplate, error) {
+	tmpl, err := uc.templateRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("contract template not found: %w", err)
+	}
+
+	tmpl.IsActive = active
+	if err := uc.templateRepo.Update(ctx, tmpl); err != nil {
+		return nil, err
+	}
+
+	logger.Info("Contract template updated", "templateID", tmpl.ID, "version", tmpl.Version, "active", active)
+	return tmpl, nil
+}
+
+// EnsureDefaultTemplate seeds version 1 when no template exists yet.
+func (uc *contractDocumentUseCase) EnsureDefaultTemplate(ctx context.Context) error {
+	templates, err := uc.templateRepo.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+	if len(templates) > 0 {
+		return nil
+	}
+
+	return uc.CreateTemplate(ctx, &entity.ContractTemplate{
+		Name: "Standard Credit Agreement",
+		Body: defaultContractTemplate,
+	})
+}
+
+// GenerateContract renders the agreement of an approved transaction with the
+// newest active template and stores it. A transaction keeps the first
+// document generated for it, so regenerating returns the stored one.
+func (uc *contractDocumentUseCase) GenerateContract(ctx context.Context, transactionID uint64) (*entity.ContractDocument, error) {
+	if existing, err := uc.documentRepo.GetByTransactionID(ctx, transactionID); err != nil {
+		return nil, err
+	} else if existing != nil {
+		return existing, nil
+	}
+
+	transaction, err := uc.transactionRepo.GetByID(ctx, transactionID)
+	if err != nil {
+		return nil, fmt.Errorf("transaction not found: %w", err)
+	}
+	return uc.IssueContract(ctx, transaction)
+}
+
+// IssueContract renders and stores the agreement of the given transaction,
+// which may not be saved in its approved state yet. It returns the stored
+// document if the transaction already has one.
+func (uc *contractDocumentUseCase) IssueContract(ctx context.Context, transaction *entity.Transaction) (*entity.ContractDocument, error) {
+	transactionID := transaction.ID
+	if existing, err := uc.documentRepo.GetByTransactionID(ctx, transactionID); err != nil {
+		return nil, err
+	} else if existing != nil {
+		return existing, nil
+	}
+
+	if !transaction.HasBeenApproved() {
+		return nil, fmt.Errorf("transaction %s is %s, contracts are only issued for approved transactions", transaction.ContractNumber, transaction.Status)
+	}
+
+	customer, err := uc.customerRepo.GetByID(ctx, transaction.CustomerID)
+	if err != nil {
+		return nil, fmt.Errorf("customer not found: %w", err)
+	}
+
+	schedule, err := uc.scheduleRepo.GetByTransactionID(ctx, transactionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get installment schedule: %w", err)
+	}
+
+	tmpl, err := uc.templateRepo.GetLatestActive(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if tmpl == nil {
+		return nil, fmt.Errorf("no active contract template")
+	}
+
+	data := &ContractData{
+		TemplateVersion: tmpl.Version,
+		Customer:        customer,
+		Transaction:     transaction,
+		Schedule:        schedule,
+		TotalPayable:    roundAmount(transaction.FinancedPrincipal() + transaction.InterestAmount + transaction.AdminFee),
+		GeneratedAt:     time.Now(),
+	}
+
+	text, err := renderContractText(tmpl, data)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := pdf.NewDocument("Credit Agreement "+transaction.ContractNumber, data.GeneratedAt)
+	doc.AddText(text)
+	content := doc.Bytes()
+	sum := sha256.Sum256(content)
+
+	document := &entity.ContractDocument{
+		TransactionID:   transaction.ID,
+		TemplateID:      tmpl.ID,
+		TemplateVersion: tmpl.Version,
+		FileName:        fmt.Sprintf("contract-%s.pdf", transaction.ContractNumber),
+		ContentType:     "application/pdf",
+		Content:         content,
+		SHA256:          hex.EncodeToString(sum[:]),
+		Size:            int64(len(content)),
+		GeneratedAt:     data.GeneratedAt,
+	}
+
+	if err := uc.documentRepo.Create(ctx, document); err != nil {
+		// A concurrent request may have stored the document first
+		if existing, getErr := uc.documentRepo.GetByTransactionID(ctx, transactionID); getErr == nil && existing != nil {
+			return existing, nil
+		}
+		logger.Error("Failed to store contract document", "transactionID", transactionID, "error", err)
+		return nil, err
+	}
+
+	logger.Info("Contract document generated",
+		"transactionID", transaction.ID,
+		"contractNumber", transaction.ContractNumber,
+		"templateVersion", tmpl.Version,
+		"sha256", document.SHA256)
+
+	return document, nil
+}
+
+// GetContractDocument returns the stored agreement, generating it for
+// transactions approved before documents were produced. The content is checked
+// against its hash before it is handed out.
+func (uc *contractDocumentUseCase) GetContractDocument(ctx context.Context, transactionID uint64) (*entity.ContractDocument, error) {
+	document, err := uc.GenerateContract(ctx, transactionID)
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(document.Content)
+	if hex.EncodeToString(sum[:]) != document.SHA256 {
+		logger.Error("Contract document hash mismatch", "transactionID", transactionID, "documentID", document.ID)
+		return nil, fmt.Errorf("contract document of transaction %d failed its integrity check", transactionID)
+	}
+
+	return document, nil
+}
+
+func renderContractText(tmpl *entity.ContractTemplate, data *ContractData) (string, error) {
+	parsed, err := template.New(fmt.Sprintf("contract-v%d", tmpl.Version)).
+		Funcs(contractTemplateFuncs).
+		Option("missingkey=error").
+		Parse(tmpl.Body)
+	if err != nil {
+		return "", fmt.Errorf("invalid contract template: %w", err)
+	}
+
+	var out bytes.Buffer
+	if err := parsed.Execute(&out, data); err != nil {
+		return "", fmt.Errorf("failed to render contract template: %w", err)
+	}
+	return out.String(), nil
+}
+
+var contractTemplateFuncs = template.FuncMap{
+	"money": formatRupiah,
+	"date": func(t time.Time) string {
+		return t.Format("02 January 2006")
+	},
+}
+
+// formatRupiah writes an amount as Rp 1.234.567,89.
+func formatRupiah(amount float64) string {
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	whole := fmt.Sprintf("%.2f", roundAmount(amount))
+	integer, fraction := whole[:len(whole)-3], whole[len(whole)-2:]
+
+	var grouped []string
+	for len(integer) > 3 {
+		grouped = append([]string{integer[len(integer)-3:]}, grouped...)
+		integer = integer[:len(integer)-3]
+	}
+	grouped = append([]string{integer}, grouped...)
+
+	return fmt.Sprintf("%sRp %s,%s", sign, strings.Join(grouped, "."), fraction)
+}
+
+func sampleContractData() *ContractData {
+	now := time.Now()
+	customer := &entity.Customer{ID: 1, NIK: "3171234567890001", FullName: "Sample Customer", LegalName: "Sample Customer", BirthPlace: "Jakarta", BirthDate: now.AddDate(-30, 0, 0), Salary: 10000000}
+	transaction := &entity.Transaction{ID: 1, ContractNumber: "XYZ-SAMPLE", CustomerID: 1, TenorMonths: 1, OTRAmount: 1000000, FinancedAmount: 1000000, AdminFee: 50000, InstallmentAmount: 1070000, InterestAmount: 20000, InterestMethod: entity.InterestFlat, InterestRate: 24, AssetName: "Sample Asset", AssetType: entity.AssetWhiteGoods, TransactionSource: entity.SourceWeb, Status: entity.StatusApproved, ApprovedAt: &now, Customer: *customer}
+	return &ContractData{
+		TemplateVersion: 0,
+		Customer:        customer,
+		Transaction:     transaction,
+		Schedule: []*entity.InstallmentSchedule{
+			{InstallmentNumber: 1, DueDate: now.AddDate(0, 1, 0), PrincipalAmount: 1000000, InterestAmount: 20000, AdminFeeAmount: 50000, AmountDue: 1070000},
+		},
+		TotalPayable: 1070000,
+		GeneratedAt:  now,
+	}
+}
+
+const defaultContractTemplate = `CREDIT AGREEMENT
+PT XYZ Multifinance
+
+Contract Number : {{.Transaction.ContractNumber}}
+Agreement Date  : {{date .GeneratedAt}}
+Template        : version {{.TemplateVersion}}
+
+1. THE CUSTOMER
+   Name         : {{.Customer.LegalName}}
+   NIK          : {{.Customer.NIK}}
+   Place/Date of Birth : {{.Customer.BirthPlace}}, {{date .Customer.BirthDate}}
+
+2. THE FINANCED ASSET
+   Asset        : {{.Transaction.AssetName}} ({{.Transaction.AssetType}})
+   OTR Price    : {{money .Transaction.OTRAmount}}
+   Down Payment : {{money .Transaction.DownPaymentAmount}}
+   Financed     : {{money .Transaction.FinancedPrincipal}}
+
+3. TERMS
+   Tenor        : {{.Transaction.TenorMonths}} months
+   Interest     : {{.Transaction.InterestRate}}% per year ({{.Transaction.InterestMethod}}), {{money .Transaction.InterestAmount}} in total
+   Admin Fee    : {{money .Transaction.AdminFee}}
+   Installment  : {{money .Transaction.InstallmentAmount}} per month
+   Total Payable: {{money .TotalPayable}}
+
+4. INSTALLMENT SCHEDULE
+   No  Due Date    Principal          Interest         Admin Fee        Amount Due
+{{range .Schedule}}   {{printf "%-3d" .InstallmentNumber}} {{.DueDate.Format "2006-01-02"}}  {{printf "%-18s" (money .PrincipalAmount)}} {{printf "%-16s" (money .InterestAmount)}} {{printf "%-16s" (money .AdminFeeAmount)}} {{money .AmountDue}}
+{{end}}
+5. OBLIGATIONS
+   The Customer shall pay each installment on or before its due date. Late
+   installments accrue a daily penalty as published by PT XYZ Multifinance.
+   The Customer declares that the data stated above is true and complete.
+
+Signed electronically by the Customer and PT XYZ Multifinance on {{date .GeneratedAt}}.
+`
@@ -0,0 +1,67 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"pt-xyz-multifinance/internal/domain/repository"
+	"pt-xyz-multifinance/internal/domain/service"
+	"pt-xyz-multifinance/pkg/constants"
+	"strings"
+	"time"
+)
+
+// ContractNumberGenerator issues unique contract numbers from a database
+// sequence and checks numbers presented for lookup.
+type ContractNumberGenerator interface {
+	Next(ctx context.Context) (string, error)
+	Validate(contractNumber string) error
+}
+
+type contractNumberGenerator struct {
+	sequenceRepo repository.ContractSequenceRepository
+	format       service.ContractNumberFormat
+}
+
+func NewContractNumberGenerator(sequenceRepo repository.ContractSequenceRepository, format service.ContractNumberFormat) ContractNumberGenerator {
+	return &contractNumberGenerator{
+		sequenceRepo: sequenceRepo,
+		format:       format,
+	}
+}
+
+func (g *contractNumberGenerator) Next(ctx context.Context) (string, error) {
+	now := time.Now()
+
+	sequence, err := g.sequenceRepo.Next(ctx, g.format.SequenceScope(now))
+	if err != nil {
+		return "", fmt.Errorf("failed to issue contract number: %w", err)
+	}
+
+	return g.format.Format(now, sequence), nil
+}
+
+// Validate rejects numbers whose check digit does not match. Numbers issued
+// before the sequence existed (XYZ followed by a Unix time) carry no check
+// digit and are accepted as they are.
+func (g *contractNumberGenerator) Validate(contractNumber string) error {
+	if isLegacyContractNumber(contractNumber) {
+		return nil
+	}
+	if !service.ValidContractCheckDigit(contractNumber) {
+		return fmt.Errorf("invalid contract number %q: check digit does not match", contractNumber)
+	}
+	return nil
+}
+
+func isLegacyContractNumber(contractNumber string) bool {
+	digits := strings.TrimPrefix(contractNumber, constants.ContractPrefix)
+	if digits == contractNumber || digits == "" {
+		return false
+	}
+	for _, r := range digits {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,195 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"pt-xyz-multifinance/internal/domain/entity"
+	"pt-xyz-multifinance/internal/domain/repository"
+	"pt-xyz-multifinance/internal/domain/service"
+	"pt-xyz-multifinance/pkg/logger"
+	"sort"
+	"strings"
+	"time"
+)
+
+// maxDuplicateCandidates bounds how many existing customers are scored
+// against a new registration.
+const maxDuplicateCandidates = 200
+
+// DuplicateCluster groups customers linked, directly or through each other,
+// by duplicate flags.
+type DuplicateCluster struct {
+	Customers []*entity.Customer
+	Flags     []*entity.CustomerDuplicate
+	MaxScore  float64
+}
+
+type CustomerDuplicateUseCase interface {
+	FlagDuplicates(ctx context.Context, customer *entity.Customer) ([]*entity.CustomerDuplicate, error)
+	GetClusters(ctx context.Context, status entity.DuplicateStatus) ([]*DuplicateCluster, error)
+	ResolveDuplicate(ctx context.Context, id uint64, adminID uint64, status entity.DuplicateStatus, note string) (*entity.CustomerDuplicate, error)
+	CustomerOnHold(ctx context.Context, customerID uint64) (bool, error)
+}
+
+type customerDuplicateUseCase struct {
+	duplicateRepo repository.CustomerDuplicateRepository
+	customerRepo  repository.CustomerRepository
+	scorer        *service.DuplicateScorer
+}
+
+func NewCustomerDuplicateUseCase(duplicateRepo repository.CustomerDuplicateRepository, customerRepo repository.CustomerRepository, scorer *service.DuplicateScorer) CustomerDuplicateUseCase {
+	return &customerDuplicateUseCase{
+		duplicateRepo: duplicateRepo,
+		customerRepo:  customerRepo,
+		scorer:        scorer,
+	}
+}
+
+// FlagDuplicates scores a newly stored customer against the existing
+// customers sharing its birth date or NIK district and flags every likely
+// duplicate for admin review. Registration goes ahead; the open flags hold
+// the customer's KYC approval.
+func (uc *customerDuplicateUseCase) FlagDuplicates(ctx context.Context, customer *entity.Customer) ([]*entity.CustomerDuplicate, error) {
+	candidates, err := uc.customerRepo.GetDuplicateCandidates(ctx, customer, maxDuplicateCandidates)
+	if err != nil {
+		return nil, err
+	}
+
+	var flags []*entity.CustomerDuplicate
+	for _, candidate := range candidates {
+		score := uc.scorer.Score(customer, candidate)
+		if !uc.scorer.IsLikelyDuplicate(score) {
+			continue
+		}
+
+		existing, err := uc.duplicateRepo.GetByPair(ctx, customer.ID, candidate.ID)
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil {
+			continue
+		}
+
+		flag := &entity.CustomerDuplicate{
+			CustomerID:        customer.ID,
+			MatchedCustomerID: candidate.ID,
+			Score:             score.Total,
+			NameSimilarity:    score.NameSimilarity,
+			BirthDateMatch:    score.BirthDateMatch,
+			BirthPlaceMatch:   score.BirthPlaceMatch,
+			NIKDistance:       score.NIKDistance,
+			Reasons:           strings.Join(score.Reasons, "; "),
+			Status:            entity.DuplicateOpen,
+		}
+		if err := uc.duplicateRepo.Create(ctx, flag); err != nil {
+			return nil, err
+		}
+
+		logger.Info("Customer flagged as a likely duplicate", "customerID", customer.ID, "matchedCustomerID", candidate.ID, "score", score.Total)
+		flags = append(flags, flag)
+	}
+
+	return flags, nil
+}
+
+// GetClusters groups the flags in the status into clusters of customers
+// connected by them, highest scoring cluster first.
+func (uc *customerDuplicateUseCase) GetClusters(ctx context.Context, status entity.DuplicateStatus) ([]*DuplicateCluster, error) {
+	flags, err := uc.duplicateRepo.GetByStatus(ctx, status)
+	if err != nil {
+		return nil, err
+	}
+
+	parent := make(map[uint64]uint64)
+	var find func(id uint64) uint64
+	find = func(id uint64) uint64 {
+		if _, ok := parent[id]; !ok {
+			parent[id] = id
+		}
+		if parent[id] != id {
+			parent[id] = find(parent[id])
+		}
+		return parent[id]
+	}
+	for _, flag := range flags {
+		parent[find(flag.CustomerID)] = find(flag.MatchedCustomerID)
+	}
+
+	clusters := make(map[uint64]*DuplicateCluster)
+	seen := make(map[uint64]bool)
+	var order []uint64
+	for _, flag := range flags {
+		root := find(flag.CustomerID)
+		cluster, ok := clusters[root]
+		if !ok {
+			cluster = &DuplicateCluster{}
+			clusters[root] = cluster
+			order = append(order, root)
+		}
+
+		cluster.Flags = append(cluster.Flags, flag)
+		if flag.Score > cluster.MaxScore {
+			cluster.MaxScore = flag.Score
+		}
+		for _, customer := range []entity.Customer{flag.Customer, flag.MatchedCustomer} {
+			if customer.ID != 0 && !seen[customer.ID] {
+				seen[customer.ID] = true
+				c := customer
+				cluster.Customers = append(cluster.Customers, &c)
+			}
+		}
+	}
+
+	result := make([]*DuplicateCluster, 0, len(order))
+	for _, root := range order {
+		cluster := clusters[root]
+		sort.Slice(cluster.Customers, func(i, j int) bool {
+			return cluster.Customers[i].ID < cluster.Customers[j].ID
+		})
+		result = append(result, cluster)
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].MaxScore > result[j].MaxScore
+	})
+
+	return result, nil
+}
+
+// ResolveDuplicate closes an open flag. DISMISSED releases the hold on the
+// customer; CONFIRMED keeps it, and the admin rejects the customer's KYC.
+func (uc *customerDuplicateUseCase) ResolveDuplicate(ctx context.Context, id uint64, adminID uint64, status entity.DuplicateStatus, note string) (*entity.CustomerDuplicate, error) {
+	if status != entity.DuplicateConfirmed && status != entity.DuplicateDismissed {
+		return nil, fmt.Errorf("a duplicate flag can only be resolved as %s or %s", entity.DuplicateConfirmed, entity.DuplicateDismissed)
+	}
+	if strings.TrimSpace(note) == "" {
+		return nil, fmt.Errorf("a note is required to resolve a duplicate flag")
+	}
+
+	duplicate, err := uc.duplicateRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("duplicate flag not found: %w", err)
+	}
+	if duplicate.Status != entity.DuplicateOpen {
+		return nil, fmt.Errorf("duplicate flag is already %s", duplicate.Status)
+	}
+
+	resolvedAt := time.Now()
+	duplicate.Status = status
+	duplicate.ResolutionNote = note
+	duplicate.ResolvedBy = &adminID
+	duplicate.ResolvedAt = &resolvedAt
+
+	if err := uc.duplicateRepo.Update(ctx, duplicate); err != nil {
+		return nil, err
+	}
+
+	logger.Info("Duplicate flag resolved", "duplicateID", duplicate.ID, "status", status, "adminID", adminID)
+	return duplicate, nil
+}
+
+// CustomerOnHold reports whether open or confirmed duplicate flags hold the
+// customer.
+func (uc *customerDuplicateUseCase) CustomerOnHold(ctx context.Context, customerID uint64) (bool, error) {
+	count, err := uc.duplicateRepo.CountHoldsByCustomer(ctx, customerID)
+	return count > 0, err
+}
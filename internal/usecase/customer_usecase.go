@@ -5,8 +5,12 @@ import (
 	"fmt"
 	"pt-xyz-multifinance/internal/domain/entity"
 	"pt-xyz-multifinance/internal/domain/repository"
+	"pt-xyz-multifinance/internal/domain/service"
+	"pt-xyz-multifinance/internal/infrastructure/database"
 	"pt-xyz-multifinance/pkg/logger"
 	"pt-xyz-multifinance/pkg/utils"
+	"strings"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -19,23 +23,59 @@ type CustomerUseCase interface {
 	DeleteCustomer(ctx context.Context, id uint64) error
 	GetAllCustomers(ctx context.Context, limit, offset int) ([]*entity.Customer, error)
 	GetCustomerLimits(ctx context.Context, customerID uint64) ([]*entity.CustomerLimit, error)
+	GetLimitHistory(ctx context.Context, customerID uint64, tenorMonths int) (*entity.CustomerLimit, []*entity.LimitMovement, error)
+	AdjustUsedAmount(ctx context.Context, customerID uint64, tenorMonths int, amount float64, adminID uint64, reason string) (*entity.LimitMovement, error)
+	UpdateLimitAmount(ctx context.Context, customerID uint64, tenorMonths int, newAmount float64, adminID uint64, reason string) (*entity.LimitChange, error)
+	GetLimitChanges(ctx context.Context, customerID uint64, tenorMonths int) ([]*entity.LimitChange, error)
+	RecommendLimits(ctx context.Context, customerID uint64) (*service.LimitRecommendation, error)
 }
 
 type customerUseCase struct {
-	customerRepo repository.CustomerRepository
-	limitRepo    repository.LimitRepository
-	db           *gorm.DB
+	customerRepo     repository.CustomerRepository
+	limitRepo        repository.LimitRepository
+	limitRecommender service.LimitRecommender
+	nikParser        *service.NIKParser
+	watchlist        WatchlistUseCase
+	duplicates       CustomerDuplicateUseCase
+	productUseCase   ProductUseCase
+	db               *gorm.DB
 }
 
-func NewCustomerUseCase(customerRepo repository.CustomerRepository, limitRepo repository.LimitRepository, db *gorm.DB) CustomerUseCase {
+func NewCustomerUseCase(customerRepo repository.CustomerRepository, limitRepo repository.LimitRepository, limitRecommender service.LimitRecommender, nikParser *service.NIKParser, watchlist WatchlistUseCase, duplicates CustomerDuplicateUseCase, productUseCase ProductUseCase, db *gorm.DB) CustomerUseCase {
 	return &customerUseCase{
-		customerRepo: customerRepo,
-		limitRepo:    limitRepo,
-		db:           db,
+		customerRepo:     customerRepo,
+		limitRepo:        limitRepo,
+		limitRecommender: limitRecommender,
+		nikParser:        nikParser,
+		watchlist:        watchlist,
+		duplicates:       duplicates,
+		productUseCase:   productUseCase,
+		db:               db,
 	}
 }
 
+// CreateCustomer stores the customer with its tenor limits. When no limits are
+// given, the recommended limits for the customer's salary and age are used.
+// The NIK must decode to a valid region and the customer's birth date, the
+// customer's age must meet the age rules of an active product, and the NIK is
+// screened against the watchlist. Likely duplicates of existing customers are
+// flagged for admin review.
 func (uc *customerUseCase) CreateCustomer(ctx context.Context, customer *entity.Customer, limits []*entity.CustomerLimit) error {
+	nikInfo, err := validateNIK(uc.nikParser, customer.NIK, customer.BirthDate)
+	if err != nil {
+		return err
+	}
+	applyNIKInfo(customer, nikInfo)
+	customer.KYCStatus = entity.KYCUnverified
+
+	if err := uc.productUseCase.CheckApplicantAge(ctx, customer.BirthDate, time.Now()); err != nil {
+		return err
+	}
+
+	limits, recommendedTenors, err := withRecommendedLimits(uc.limitRecommender, customer, limits)
+	if err != nil {
+		return err
+	}
 
 	tenorLimits := make([]utils.TenorLimit, len(limits))
 	for i, limit := range limits {
@@ -60,8 +100,13 @@ func (uc *customerUseCase) CreateCustomer(ctx context.Context, customer *entity.
 		}
 	}
 
-	return uc.db.Transaction(func(tx *gorm.DB) error {
+	hits, err := uc.watchlist.Screen(ctx, entity.ScreeningCustomerCreation, customer.NIK, nil)
+	if err != nil {
+		return err
+	}
 
+	return uc.db.Transaction(func(tx *gorm.DB) error {
+		ctx := database.WithTx(ctx, tx)
 		existingCustomer, err := uc.customerRepo.GetByNIK(ctx, customer.NIK)
 		if err == nil && existingCustomer != nil {
 			return fmt.Errorf("customer with NIK %s already exists", customer.NIK)
@@ -72,6 +117,14 @@ func (uc *customerUseCase) CreateCustomer(ctx context.Context, customer *entity.
 			return fmt.Errorf("failed to create customer: %w", err)
 		}
 
+		if err := uc.watchlist.RecordHits(ctx, hits, customer.ID, nil); err != nil {
+			return err
+		}
+
+		if _, err := uc.duplicates.FlagDuplicates(ctx, customer); err != nil {
+			return err
+		}
+
 		for _, limit := range limits {
 			limit.CustomerID = customer.ID
 			if err := uc.limitRepo.Create(ctx, limit); err != nil {
@@ -80,14 +133,14 @@ func (uc *customerUseCase) CreateCustomer(ctx context.Context, customer *entity.
 			}
 		}
 
-		logger.Info("Customer created successfully with all required tenors", "customerID", customer.ID, "tenorsCount", len(limits))
+		logger.Info("Customer created successfully with all required tenors", "customerID", customer.ID, "tenorsCount", len(limits), "recommendedTenors", recommendedTenors)
 		return nil
 	})
 }
 
 func (uc *customerUseCase) DeleteCustomer(ctx context.Context, id uint64) error {
 	return uc.db.Transaction(func(tx *gorm.DB) error {
-
+		ctx := database.WithTx(ctx, tx)
 		limits, err := uc.limitRepo.GetByCustomerID(ctx, id)
 		if err != nil {
 			return fmt.Errorf("failed to get customer limits: %w", err)
@@ -127,3 +180,168 @@ func (uc *customerUseCase) GetAllCustomers(ctx context.Context, limit, offset in
 func (uc *customerUseCase) GetCustomerLimits(ctx context.Context, customerID uint64) ([]*entity.CustomerLimit, error) {
 	return uc.limitRepo.GetByCustomerID(ctx, customerID)
 }
+
+// GetLimitHistory returns the limit together with every ledger movement that
+// produced its current used amount, oldest first.
+func (uc *customerUseCase) GetLimitHistory(ctx context.Context, customerID uint64, tenorMonths int) (*entity.CustomerLimit, []*entity.LimitMovement, error) {
+	limit, err := uc.limitRepo.GetByCustomerAndTenor(ctx, customerID, tenorMonths)
+	if err != nil {
+		return nil, nil, fmt.Errorf("customer limit not found for tenor %d months: %w", tenorMonths, err)
+	}
+
+	movements, err := uc.limitRepo.GetMovements(ctx, customerID, tenorMonths)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return limit, movements, nil
+}
+
+// AdjustUsedAmount books a manual correction of the used amount by an admin.
+func (uc *customerUseCase) AdjustUsedAmount(ctx context.Context, customerID uint64, tenorMonths int, amount float64, adminID uint64, reason string) (*entity.LimitMovement, error) {
+	if amount == 0 {
+		return nil, fmt.Errorf("adjustment amount must not be 0")
+	}
+	if strings.TrimSpace(reason) == "" {
+		return nil, fmt.Errorf("a reason is required for manual limit adjustments")
+	}
+
+	movement := &entity.LimitMovement{
+		CustomerID:  customerID,
+		TenorMonths: tenorMonths,
+		Type:        entity.MovementManualAdjustment,
+		Amount:      amount,
+		AdminID:     &adminID,
+		Reason:      reason,
+	}
+
+	if err := uc.limitRepo.UpdateUsedAmount(ctx, movement); err != nil {
+		logger.Error("Failed to adjust used amount", "customerID", customerID, "tenorMonths", tenorMonths, "error", err)
+		return nil, err
+	}
+
+	logger.Info("Used amount adjusted manually",
+		"customerID", customerID,
+		"tenorMonths", tenorMonths,
+		"amount", amount,
+		"adminID", adminID)
+
+	return movement, nil
+}
+
+// UpdateLimitAmount changes the limit of one tenor on behalf of an admin. The
+// new limit may not be lower than what the customer already uses.
+func (uc *customerUseCase) UpdateLimitAmount(ctx context.Context, customerID uint64, tenorMonths int, newAmount float64, adminID uint64, reason string) (*entity.LimitChange, error) {
+	if err := utils.ValidateTenor(tenorMonths); err != nil {
+		return nil, err
+	}
+	if newAmount <= 0 {
+		return nil, fmt.Errorf("limit amount must be greater than 0")
+	}
+	if strings.TrimSpace(reason) == "" {
+		return nil, fmt.Errorf("a reason is required for limit changes")
+	}
+
+	change := &entity.LimitChange{
+		CustomerID:     customerID,
+		TenorMonths:    tenorMonths,
+		NewLimitAmount: newAmount,
+		ChangedBy:      adminID,
+		Reason:         reason,
+	}
+
+	if err := uc.limitRepo.UpdateLimitAmount(ctx, change); err != nil {
+		logger.Error("Failed to update limit amount", "customerID", customerID, "tenorMonths", tenorMonths, "error", err)
+		return nil, err
+	}
+
+	logger.Info("Customer limit changed",
+		"customerID", customerID,
+		"tenorMonths", tenorMonths,
+		"oldLimit", change.OldLimitAmount,
+		"newLimit", change.NewLimitAmount,
+		"adminID", adminID)
+
+	return change, nil
+}
+
+func (uc *customerUseCase) GetLimitChanges(ctx context.Context, customerID uint64, tenorMonths int) ([]*entity.LimitChange, error) {
+	return uc.limitRepo.GetLimitChanges(ctx, customerID, tenorMonths)
+}
+
+// RecommendLimits proposes limits for an existing customer without applying them.
+func (uc *customerUseCase) RecommendLimits(ctx context.Context, customerID uint64) (*service.LimitRecommendation, error) {
+	customer, err := uc.customerRepo.GetByID(ctx, customerID)
+	if err != nil {
+		return nil, fmt.Errorf("customer not found: %w", err)
+	}
+
+	return uc.limitRecommender.Recommend(customer.Salary, customer.BirthDate, time.Now())
+}
+
+func recommendedLimits(recommender service.LimitRecommender, customer *entity.Customer) ([]*entity.CustomerLimit, error) {
+	recommendation, err := recommender.Recommend(customer.Salary, customer.BirthDate, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to recommend limits: %w", err)
+	}
+
+	limits := make([]*entity.CustomerLimit, 0, len(recommendation.Limits))
+	for _, recommended := range recommendation.Limits {
+		limits = append(limits, &entity.CustomerLimit{
+			TenorMonths: recommended.TenorMonths,
+			LimitAmount: recommended.LimitAmount,
+		})
+	}
+
+	return limits, nil
+}
+
+// withRecommendedLimits adds the recommended limit for every offered tenor
+// the given limits leave out, so a tenor added to the catalog does not block
+// onboarding. It also returns the tenors it added, for the caller to log once
+// the customer has an ID.
+func withRecommendedLimits(recommender service.LimitRecommender, customer *entity.Customer, limits []*entity.CustomerLimit) ([]*entity.CustomerLimit, []int, error) {
+	supplied := make(map[int]bool, len(limits))
+	for _, limit := range limits {
+		supplied[limit.TenorMonths] = true
+	}
+
+	complete := true
+	for _, tenor := range utils.ValidTenors() {
+		if !supplied[tenor] {
+			complete = false
+		}
+	}
+	if complete {
+		return limits, nil, nil
+	}
+
+	recommended, err := recommendedLimits(recommender, customer)
+	if err != nil {
+		return nil, nil, err
+	}
+	var recommendedTenors []int
+	for _, limit := range recommended {
+		if !supplied[limit.TenorMonths] {
+			limits = append(limits, limit)
+			recommendedTenors = append(recommendedTenors, limit.TenorMonths)
+		}
+	}
+	return limits, recommendedTenors, nil
+}
+
+func validateNIK(parser *service.NIKParser, nik string, birthDate time.Time) (*service.NIKInfo, error) {
+	info, err := parser.Validate(nik, birthDate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid NIK: %w", err)
+	}
+	return info, nil
+}
+
+// applyNIKInfo records the sex and region decoded from the NIK for reporting.
+func applyNIKInfo(customer *entity.Customer, info *service.NIKInfo) {
+	customer.Sex = info.Sex
+	customer.ProvinceCode = info.ProvinceCode
+	customer.RegencyCode = info.RegencyCode
+	customer.DistrictCode = info.DistrictCode
+}
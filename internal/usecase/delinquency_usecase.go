@@ -0,0 +1,248 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"pt-xyz-multifinance/internal/domain/entity"
+	"pt-xyz-multifinance/internal/domain/repository"
+	"pt-xyz-multifinance/internal/infrastructure/database"
+	"pt-xyz-multifinance/pkg/logger"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// DelinquencyPolicy holds the late-fee and default rules applied to overdue installments.
+type DelinquencyPolicy struct {
+	GracePeriodDays      int
+	LateFeeDailyRate     float64 // fraction of the overdue installment charged per day
+	LateFeeCapRate       float64 // maximum penalty as a fraction of the installment amount
+	DefaultThresholdDays int
+}
+
+type DelinquencyRunResult struct {
+	AsOf                  time.Time `json:"as_of"`
+	TransactionsAssessed  int       `json:"transactions_assessed"`
+	TransactionsOverdue   int       `json:"transactions_overdue"`
+	TransactionsDefaulted int       `json:"transactions_defaulted"`
+}
+
+type DelinquencyUseCase interface {
+	AssessInstallments(transaction *entity.Transaction, installments []*entity.InstallmentSchedule, asOf time.Time)
+	AssessTransaction(ctx context.Context, transactionID uint64, asOf time.Time) (*entity.Transaction, error)
+	RunAssessment(ctx context.Context, asOf time.Time) (*DelinquencyRunResult, error)
+}
+
+type delinquencyUseCase struct {
+	transactionRepo repository.TransactionRepository
+	scheduleRepo    repository.InstallmentScheduleRepository
+	stateMachine    TransactionStateMachine
+	policy          DelinquencyPolicy
+	db              *gorm.DB
+}
+
+func NewDelinquencyUseCase(
+	transactionRepo repository.TransactionRepository,
+	scheduleRepo repository.InstallmentScheduleRepository,
+	stateMachine TransactionStateMachine,
+	policy DelinquencyPolicy,
+	db *gorm.DB,
+) DelinquencyUseCase {
+	return &delinquencyUseCase{
+		transactionRepo: transactionRepo,
+		scheduleRepo:    scheduleRepo,
+		stateMachine:    stateMachine,
+		policy:          policy,
+		db:              db,
+	}
+}
+
+// AssessInstallments recomputes days-past-due and late penalties for every open
+// installment as of the given date and rolls the result up to the transaction.
+// Penalties never decrease, so running it several times on the same day is safe.
+// Days before the contract was activated are never counted.
+func (uc *delinquencyUseCase) AssessInstallments(transaction *entity.Transaction, installments []*entity.InstallmentSchedule, asOf time.Time) {
+	maxDPD := 0
+	accruedPenalty := 0.0
+
+	for _, installment := range installments {
+		if installment.IsOpen() {
+			installment.DaysPastDue = daysBetween(overdueFrom(transaction, installment), asOf)
+
+			penalty := uc.calculatePenalty(installment)
+			if penalty > installment.PenaltyAmount {
+				installment.PenaltyAmount = penalty
+			}
+
+			if installment.DaysPastDue > maxDPD {
+				maxDPD = installment.DaysPastDue
+			}
+		}
+		accruedPenalty += installment.PenaltyAmount
+	}
+
+	transaction.DaysPastDue = maxDPD
+	transaction.AccruedPenalty = roundAmount(accruedPenalty)
+}
+
+func (uc *delinquencyUseCase) calculatePenalty(installment *entity.InstallmentSchedule) float64 {
+	chargeableDays := installment.DaysPastDue - uc.policy.GracePeriodDays
+	if chargeableDays <= 0 || uc.policy.LateFeeDailyRate <= 0 {
+		return 0
+	}
+
+	overdueAmount := installment.AmountDue - installment.PrincipalPaid - installment.InterestPaid - installment.AdminFeePaid
+	if overdueAmount <= 0 {
+		return 0
+	}
+
+	penalty := overdueAmount * uc.policy.LateFeeDailyRate * float64(chargeableDays)
+	if uc.policy.LateFeeCapRate > 0 {
+		penalty = math.Min(penalty, installment.AmountDue*uc.policy.LateFeeCapRate)
+	}
+
+	return roundAmount(penalty)
+}
+
+func (uc *delinquencyUseCase) AssessTransaction(ctx context.Context, transactionID uint64, asOf time.Time) (*entity.Transaction, error) {
+	var transaction *entity.Transaction
+
+	err := uc.db.Transaction(func(tx *gorm.DB) error {
+		ctx := database.WithTx(ctx, tx)
+		var err error
+		transaction, _, err = uc.assess(ctx, transactionID, asOf)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return transaction, nil
+}
+
+func (uc *delinquencyUseCase) RunAssessment(ctx context.Context, asOf time.Time) (*DelinquencyRunResult, error) {
+	transactions, err := uc.transactionRepo.GetByStatuses(ctx, []entity.TransactionStatus{entity.StatusActive, entity.StatusDefaulted})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get open transactions: %w", err)
+	}
+
+	result := &DelinquencyRunResult{AsOf: asOf}
+	for _, open := range transactions {
+		var transaction *entity.Transaction
+		var defaulted bool
+		err := uc.db.Transaction(func(tx *gorm.DB) error {
+			ctx := database.WithTx(ctx, tx)
+			var err error
+			transaction, defaulted, err = uc.assess(ctx, open.ID, asOf)
+			return err
+		})
+		if err != nil {
+			logger.Error("Failed to assess transaction delinquency", "transactionID", open.ID, "error", err)
+			continue
+		}
+		if !tracksDelinquency(transaction.Status) {
+			continue
+		}
+
+		result.TransactionsAssessed++
+		if transaction.DaysPastDue > 0 {
+			result.TransactionsOverdue++
+		}
+		if defaulted {
+			result.TransactionsDefaulted++
+		}
+	}
+
+	logger.Info("Delinquency assessment finished",
+		"asOf", asOf.Format("2006-01-02"),
+		"assessed", result.TransactionsAssessed,
+		"overdue", result.TransactionsOverdue,
+		"defaulted", result.TransactionsDefaulted)
+
+	return result, nil
+}
+
+// assess persists the delinquency state of one transaction and reports whether
+// it was moved to DEFAULTED by this run. The contract and its installments are
+// read locked, so a payment recorded meanwhile is never overwritten; a
+// contract that payment completed is left as it is.
+func (uc *delinquencyUseCase) assess(ctx context.Context, transactionID uint64, asOf time.Time) (*entity.Transaction, bool, error) {
+	transaction, err := uc.transactionRepo.GetByIDForUpdate(ctx, transactionID)
+	if err != nil {
+		return nil, false, fmt.Errorf("transaction not found: %w", err)
+	}
+	if !tracksDelinquency(transaction.Status) {
+		return transaction, false, nil
+	}
+
+	installments, err := uc.scheduleRepo.GetByTransactionIDForUpdate(ctx, transaction.ID)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get installment schedule: %w", err)
+	}
+
+	uc.AssessInstallments(transaction, installments, asOf)
+
+	for _, installment := range installments {
+		if !installment.IsOpen() {
+			continue
+		}
+		if err := uc.scheduleRepo.Update(ctx, installment); err != nil {
+			return nil, false, fmt.Errorf("failed to update installment: %w", err)
+		}
+	}
+
+	defaulted := false
+	if transaction.Status == entity.StatusActive &&
+		uc.policy.DefaultThresholdDays > 0 &&
+		transaction.DaysPastDue > uc.policy.DefaultThresholdDays {
+		reason := fmt.Sprintf("days past due %d exceeded threshold of %d days", transaction.DaysPastDue, uc.policy.DefaultThresholdDays)
+		if err := uc.stateMachine.Transition(ctx, transaction, entity.StatusDefaulted, reason); err != nil {
+			return nil, false, err
+		}
+		defaulted = true
+	}
+
+	if err := uc.transactionRepo.Update(ctx, transaction); err != nil {
+		return nil, false, fmt.Errorf("failed to update transaction delinquency: %w", err)
+	}
+
+	if defaulted {
+		logger.Info("Transaction defaulted",
+			"transactionID", transaction.ID,
+			"contractNumber", transaction.ContractNumber,
+			"daysPastDue", transaction.DaysPastDue)
+	}
+
+	return transaction, defaulted, nil
+}
+
+// tracksDelinquency reports whether days past due and penalties are assessed
+// for contracts in the status.
+func tracksDelinquency(status entity.TransactionStatus) bool {
+	return status == entity.StatusActive || status == entity.StatusDefaulted
+}
+
+// overdueFrom returns the date an installment starts counting days past due:
+// its due date, or the activation date when the contract waited for approval
+// or disbursement past it.
+func overdueFrom(transaction *entity.Transaction, installment *entity.InstallmentSchedule) time.Time {
+	if transaction.ActivatedAt != nil && transaction.ActivatedAt.After(installment.DueDate) {
+		return *transaction.ActivatedAt
+	}
+	return installment.DueDate
+}
+
+// daysBetween returns the whole calendar days from due to asOf, or 0 when not yet due.
+func daysBetween(due, asOf time.Time) int {
+	days := int(calendarDate(asOf).Sub(calendarDate(due)).Hours() / 24)
+	if days < 0 {
+		return 0
+	}
+	return days
+}
+
+// calendarDate drops the time of day, keeping the date as written.
+func calendarDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,63 @@
+package usecase
+
+import (
+	"fmt"
+	"pt-xyz-multifinance/internal/domain/entity"
+	"strconv"
+	"strings"
+)
+
+// DownPaymentPolicy sets the minimum down payment, in percent of OTR, for each
+// asset type. Asset types without an entry need no down payment.
+type DownPaymentPolicy struct {
+	MinPercent map[entity.AssetType]float64
+}
+
+func (p DownPaymentPolicy) MinimumFor(assetType entity.AssetType, otrAmount float64) float64 {
+	return roundAmount(otrAmount * p.MinPercent[assetType] / 100)
+}
+
+// apply checks the down payment of a new contract and sets its financed amount.
+func (p DownPaymentPolicy) apply(transaction *entity.Transaction) error {
+	if transaction.DownPaymentAmount < 0 {
+		return fmt.Errorf("down payment cannot be negative")
+	}
+	if transaction.DownPaymentAmount >= transaction.OTRAmount {
+		return fmt.Errorf("down payment %.2f must be less than the OTR amount %.2f", transaction.DownPaymentAmount, transaction.OTRAmount)
+	}
+
+	minimum := p.MinimumFor(transaction.AssetType, transaction.OTRAmount)
+	if transaction.DownPaymentAmount < minimum {
+		return fmt.Errorf("down payment %.2f is below the minimum %.2f (%.2f%% of OTR) for %s",
+			transaction.DownPaymentAmount, minimum, p.MinPercent[transaction.AssetType], transaction.AssetType)
+	}
+
+	transaction.FinancedAmount = roundAmount(transaction.OTRAmount - transaction.DownPaymentAmount)
+	return nil
+}
+
+// ParseDownPaymentMinimums reads "ASSET_TYPE:percent" pairs such as "MOTOR:10,MOBIL:20".
+func ParseDownPaymentMinimums(pairs []string) (map[entity.AssetType]float64, error) {
+	minimums := make(map[entity.AssetType]float64, len(pairs))
+	for _, pair := range pairs {
+		parts := strings.SplitN(pair, ":", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid down payment minimum %q, expected ASSET_TYPE:percent", pair)
+		}
+
+		assetType := entity.AssetType(strings.ToUpper(strings.TrimSpace(parts[0])))
+		switch assetType {
+		case entity.AssetWhiteGoods, entity.AssetMotor, entity.AssetMobil:
+		default:
+			return nil, fmt.Errorf("invalid asset type in down payment minimum %q", pair)
+		}
+
+		percent, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+		if err != nil || percent < 0 || percent >= 100 {
+			return nil, fmt.Errorf("invalid percent in down payment minimum %q", pair)
+		}
+
+		minimums[assetType] = percent
+	}
+	return minimums, nil
+}
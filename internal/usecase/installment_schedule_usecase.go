@@ -0,0 +1,128 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"pt-xyz-multifinance/internal/domain/entity"
+	"pt-xyz-multifinance/internal/domain/repository"
+	"pt-xyz-multifinance/internal/domain/service"
+	"pt-xyz-multifinance/pkg/logger"
+	"time"
+)
+
+type InstallmentScheduleUseCase interface {
+	GenerateSchedule(ctx context.Context, transaction *entity.Transaction) ([]*entity.InstallmentSchedule, error)
+	GetScheduleByTransactionID(ctx context.Context, transactionID uint64) ([]*entity.InstallmentSchedule, error)
+}
+
+type installmentScheduleUseCase struct {
+	scheduleRepo repository.InstallmentScheduleRepository
+}
+
+func NewInstallmentScheduleUseCase(scheduleRepo repository.InstallmentScheduleRepository) InstallmentScheduleUseCase {
+	return &installmentScheduleUseCase{
+		scheduleRepo: scheduleRepo,
+	}
+}
+
+// GenerateSchedule splits the contract into one installment per tenor month and
+// persists the rows. Rounding differences are absorbed by the last installment
+// so the schedule always adds up to the contract totals.
+func (uc *installmentScheduleUseCase) GenerateSchedule(ctx context.Context, transaction *entity.Transaction) ([]*entity.InstallmentSchedule, error) {
+	if transaction.TenorMonths <= 0 {
+		return nil, fmt.Errorf("invalid tenor %d months for installment schedule", transaction.TenorMonths)
+	}
+
+	startDate := transaction.CreatedAt
+	if startDate.IsZero() {
+		startDate = time.Now()
+	}
+
+	periods, err := interestPeriods(transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	tenor := transaction.TenorMonths
+	adminFeePart := roundAmount(transaction.AdminFee / float64(tenor))
+
+	installments := make([]*entity.InstallmentSchedule, 0, tenor)
+	for i, period := range periods {
+		adminFee := adminFeePart
+		if i == tenor-1 {
+			adminFee = roundAmount(transaction.AdminFee - adminFeePart*float64(tenor-1))
+		}
+
+		installments = append(installments, &entity.InstallmentSchedule{
+			TransactionID:     transaction.ID,
+			InstallmentNumber: i + 1,
+			DueDate:           addMonths(startDate, i+1),
+			PrincipalAmount:   period.Principal,
+			InterestAmount:    period.Interest,
+			AdminFeeAmount:    adminFee,
+			AmountDue:         roundAmount(period.Principal + period.Interest + adminFee),
+			Status:            entity.InstallmentUnpaid,
+		})
+	}
+
+	if err := uc.scheduleRepo.CreateBatch(ctx, installments); err != nil {
+		logger.Error("Failed to create installment schedule", "transactionID", transaction.ID, "error", err)
+		return nil, fmt.Errorf("failed to create installment schedule: %w", err)
+	}
+
+	return installments, nil
+}
+
+// interestPeriods returns the principal/interest split of every month. Contracts
+// without a recorded interest method spread the stored interest evenly.
+func interestPeriods(transaction *entity.Transaction) ([]service.InterestPeriod, error) {
+	if transaction.InterestMethod != "" {
+		calculator, err := service.NewInterestCalculator(transaction.InterestMethod)
+		if err != nil {
+			return nil, err
+		}
+
+		result, err := calculator.Calculate(transaction.FinancedPrincipal(), transaction.InterestRate, transaction.TenorMonths)
+		if err != nil {
+			return nil, fmt.Errorf("failed to calculate interest: %w", err)
+		}
+		return result.Periods, nil
+	}
+
+	tenor := transaction.TenorMonths
+	principal := transaction.FinancedPrincipal()
+	principalPart := roundAmount(principal / float64(tenor))
+	interestPart := roundAmount(transaction.InterestAmount / float64(tenor))
+
+	periods := make([]service.InterestPeriod, tenor)
+	for i := range periods {
+		periods[i] = service.InterestPeriod{Principal: principalPart, Interest: interestPart}
+	}
+	periods[tenor-1] = service.InterestPeriod{
+		Principal: roundAmount(principal - principalPart*float64(tenor-1)),
+		Interest:  roundAmount(transaction.InterestAmount - interestPart*float64(tenor-1)),
+	}
+
+	return periods, nil
+}
+
+func (uc *installmentScheduleUseCase) GetScheduleByTransactionID(ctx context.Context, transactionID uint64) ([]*entity.InstallmentSchedule, error) {
+	return uc.scheduleRepo.GetByTransactionID(ctx, transactionID)
+}
+
+// addMonths moves the date forward by the given number of months, clamping to
+// the last day of the target month (Jan 31 + 1 month = Feb 28/29).
+func addMonths(date time.Time, months int) time.Time {
+	year, month, day := date.Date()
+	firstOfTarget := time.Date(year, month+time.Month(months), 1, 0, 0, 0, 0, date.Location())
+	lastDay := firstOfTarget.AddDate(0, 1, -1).Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return time.Date(firstOfTarget.Year(), firstOfTarget.Month(), day, 0, 0, 0, 0, date.Location())
+}
+
+func roundAmount(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
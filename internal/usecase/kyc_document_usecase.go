@@ -0,0 +1,415 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"pt-xyz-multifinance/internal/domain/entity"
+	"pt-xyz-multifinance/internal/domain/repository"
+	"pt-xyz-multifinance/internal/infrastructure/database"
+	"pt-xyz-multifinance/pkg/logger"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrInvalidFileLink is returned for signed URLs that are forged or expired.
+var ErrInvalidFileLink = errors.New("invalid file link")
+
+// KYCUploadPolicy limits what may be uploaded as a KYC image.
+type KYCUploadPolicy struct {
+	MaxSize          int64
+	AllowedMIMETypes []string
+	URLTTL           time.Duration
+}
+
+// Validate checks the policy at startup; only image types with a known file
+// extension can be stored.
+func (p KYCUploadPolicy) Validate() error {
+	if p.MaxSize <= 0 {
+		return fmt.Errorf("maximum upload size must be positive")
+	}
+	if p.URLTTL <= 0 {
+		return fmt.Errorf("signed URL lifetime must be positive")
+	}
+	if len(p.AllowedMIMETypes) == 0 {
+		return fmt.Errorf("at least one upload MIME type is required")
+	}
+	for _, mimeType := range p.AllowedMIMETypes {
+		if _, ok := kycFileExtensions[strings.ToLower(mimeType)]; !ok {
+			return fmt.Errorf("unsupported upload MIME type %s", mimeType)
+		}
+	}
+	return nil
+}
+
+func mimeTypeAllowed(allowedTypes []string, mimeType string) bool {
+	for _, allowed := range allowedTypes {
+		if strings.EqualFold(allowed, mimeType) {
+			return true
+		}
+	}
+	return false
+}
+
+// KYCDocumentLink is a stored KYC image with a signed download URL.
+type KYCDocumentLink struct {
+	DocumentType entity.KYCDocumentType
+	ObjectKey    string
+	Checksum     string
+	UploadedAt   *time.Time
+	URL          string
+	ExpiresAt    time.Time
+}
+
+// KYCReviewItem is a customer in the review queue with links to the images.
+type KYCReviewItem struct {
+	Customer  *entity.Customer
+	Documents []*KYCDocumentLink
+}
+
+type KYCDocumentUseCase interface {
+	UploadDocument(ctx context.Context, customerID uint64, docType entity.KYCDocumentType, content io.Reader, size int64) (*KYCDocumentLink, error)
+	GetDocumentLinks(ctx context.Context, customerID uint64) ([]*KYCDocumentLink, error)
+	OpenSignedDocument(ctx context.Context, key string, expires int64, signature string) (io.ReadCloser, string, error)
+	GetReviewQueue(ctx context.Context, status entity.KYCStatus, limit, offset int) ([]*KYCReviewItem, error)
+	ApproveCustomer(ctx context.Context, customerID uint64, adminID uint64, reason string) (*entity.Customer, error)
+	RejectCustomer(ctx context.Context, customerID uint64, adminID uint64, reason string) (*entity.Customer, error)
+}
+
+type kycDocumentUseCase struct {
+	customerRepo repository.CustomerRepository
+	storage      repository.FileStorage
+	watchlist    WatchlistUseCase
+	duplicates   CustomerDuplicateUseCase
+	policy       KYCUploadPolicy
+	db           *gorm.DB
+}
+
+func NewKYCDocumentUseCase(customerRepo repository.CustomerRepository, storage repository.FileStorage, watchlist WatchlistUseCase, duplicates CustomerDuplicateUseCase, policy KYCUploadPolicy, db *gorm.DB) KYCDocumentUseCase {
+	return &kycDocumentUseCase{
+		customerRepo: customerRepo,
+		storage:      storage,
+		watchlist:    watchlist,
+		duplicates:   duplicates,
+		policy:       policy,
+		db:           db,
+	}
+}
+
+var kycFileExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+}
+
+// UploadDocument stores a KTP or selfie image and records its key, checksum
+// and upload time on the customer. The type is sniffed from the content, not
+// taken from the client, and the replaced image is removed afterwards. Once
+// both images are present the customer is queued for KYC review.
+func (uc *kycDocumentUseCase) UploadDocument(ctx context.Context, customerID uint64, docType entity.KYCDocumentType, content io.Reader, size int64) (*KYCDocumentLink, error) {
+	if !docType.IsValid() {
+		return nil, fmt.Errorf("invalid KYC document type: %s", docType)
+	}
+	if size <= 0 {
+		return nil, fmt.Errorf("file is empty")
+	}
+	if size > uc.policy.MaxSize {
+		return nil, fmt.Errorf("file is %d bytes, the maximum is %d bytes", size, uc.policy.MaxSize)
+	}
+
+	if _, err := uc.customerRepo.GetByID(ctx, customerID); err != nil {
+		return nil, fmt.Errorf("customer not found: %w", err)
+	}
+
+	key, checksum, written, err := storeUpload(ctx, uc.storage, uc.policy.MaxSize, uc.policy.AllowedMIMETypes, content, func(mimeType string) (string, error) {
+		return newKYCObjectKey(customerID, docType, mimeType)
+	})
+	if err != nil {
+		logger.Error("Failed to store KYC document", "customerID", customerID, "type", docType, "error", err)
+		return nil, err
+	}
+
+	// The row is locked so that concurrent KTP and selfie uploads each keep
+	// the other's key
+	var customer *entity.Customer
+	var previousKey string
+	err = uc.db.Transaction(func(tx *gorm.DB) error {
+		ctx := database.WithTx(ctx, tx)
+
+		var err error
+		customer, err = uc.customerRepo.GetByIDForUpdate(ctx, customerID)
+		if err != nil {
+			return err
+		}
+
+		previousKey, _, _ = customer.KYCDocument(docType)
+		customer.SetKYCDocument(docType, key, checksum, time.Now())
+
+		// New images reopen the review, also after a rejection or a verification
+		if customer.HasKYCDocuments() {
+			submittedAt := time.Now()
+			customer.KYCStatus = entity.KYCPendingReview
+			customer.KYCSubmittedAt = &submittedAt
+		}
+
+		return uc.customerRepo.Update(ctx, customer)
+	})
+	if err != nil {
+		uc.removeObject(ctx, key)
+		return nil, fmt.Errorf("failed to record KYC document: %w", err)
+	}
+
+	if previousKey != "" && previousKey != key {
+		uc.removeObject(ctx, previousKey)
+	}
+
+	logger.Info("KYC document uploaded", "customerID", customerID, "type", docType, "key", key, "size", written)
+	return uc.link(customer, docType)
+}
+
+// GetDocumentLinks returns signed URLs for every KYC image of the customer.
+func (uc *kycDocumentUseCase) GetDocumentLinks(ctx context.Context, customerID uint64) ([]*KYCDocumentLink, error) {
+	customer, err := uc.customerRepo.GetByID(ctx, customerID)
+	if err != nil {
+		return nil, fmt.Errorf("customer not found: %w", err)
+	}
+
+	return uc.documentLinks(customer)
+}
+
+// GetReviewQueue lists the customers in a KYC status, longest waiting first,
+// with signed links to their images.
+func (uc *kycDocumentUseCase) GetReviewQueue(ctx context.Context, status entity.KYCStatus, limit, offset int) ([]*KYCReviewItem, error) {
+	if !status.IsValid() {
+		return nil, fmt.Errorf("invalid KYC status: %s", status)
+	}
+
+	customers, err := uc.customerRepo.GetByKYCStatus(ctx, status, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*KYCReviewItem, 0, len(customers))
+	for _, customer := range customers {
+		links, err := uc.documentLinks(customer)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, &KYCReviewItem{Customer: customer, Documents: links})
+	}
+	return items, nil
+}
+
+// ApproveCustomer verifies a customer waiting for review whose watchlist hits
+// have been cleared and whose duplicate flags have been dismissed.
+func (uc *kycDocumentUseCase) ApproveCustomer(ctx context.Context, customerID uint64, adminID uint64, reason string) (*entity.Customer, error) {
+	return uc.review(ctx, customerID, entity.KYCVerified, adminID, reason, func(ctx context.Context, customer *entity.Customer) error {
+		if !customer.HasKYCDocuments() {
+			return fmt.Errorf("customer has not uploaded both the KTP and the selfie")
+		}
+
+		onHold, err := uc.watchlist.CustomerOnHold(ctx, customer.ID)
+		if err != nil {
+			return err
+		}
+		if onHold {
+			return fmt.Errorf("customer is held by a watchlist hit that has not been cleared")
+		}
+
+		if onHold, err = uc.duplicates.CustomerOnHold(ctx, customer.ID); err != nil {
+			return err
+		}
+		if onHold {
+			return fmt.Errorf("customer is flagged as a likely duplicate that has not been dismissed")
+		}
+		return nil
+	})
+}
+
+// RejectCustomer rejects a customer waiting for review. The customer may
+// upload new images afterwards.
+func (uc *kycDocumentUseCase) RejectCustomer(ctx context.Context, customerID uint64, adminID uint64, reason string) (*entity.Customer, error) {
+	if strings.TrimSpace(reason) == "" {
+		return nil, fmt.Errorf("a reason is required to reject a KYC review")
+	}
+
+	return uc.review(ctx, customerID, entity.KYCRejected, adminID, reason, nil)
+}
+
+// review records the outcome on a customer still waiting for review. The row
+// is locked, as uploads lock it, so a review never overwrites a new image key
+// and is refused once another review has moved the customer on.
+func (uc *kycDocumentUseCase) review(ctx context.Context, customerID uint64, status entity.KYCStatus, adminID uint64, reason string, check func(ctx context.Context, customer *entity.Customer) error) (*entity.Customer, error) {
+	var customer *entity.Customer
+	err := uc.db.Transaction(func(tx *gorm.DB) error {
+		ctx := database.WithTx(ctx, tx)
+
+		var err error
+		customer, err = uc.customerRepo.GetByIDForUpdate(ctx, customerID)
+		if err != nil {
+			return fmt.Errorf("customer not found: %w", err)
+		}
+		if customer.KYCStatus != entity.KYCPendingReview {
+			return fmt.Errorf("customer KYC is %s, only %s can be reviewed", customer.KYCStatus, entity.KYCPendingReview)
+		}
+		if check != nil {
+			if err := check(ctx, customer); err != nil {
+				return err
+			}
+		}
+
+		reviewedAt := time.Now()
+		customer.KYCStatus = status
+		customer.KYCReviewReason = reason
+		customer.KYCReviewedBy = &adminID
+		customer.KYCReviewedAt = &reviewedAt
+
+		if err := uc.customerRepo.Update(ctx, customer); err != nil {
+			return fmt.Errorf("failed to record KYC review: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("KYC reviewed", "customerID", customer.ID, "from", entity.KYCPendingReview, "to", status, "adminID", adminID)
+	return customer, nil
+}
+
+func (uc *kycDocumentUseCase) documentLinks(customer *entity.Customer) ([]*KYCDocumentLink, error) {
+	links := make([]*KYCDocumentLink, 0, 2)
+	for _, docType := range []entity.KYCDocumentType{entity.KYCDocumentKTP, entity.KYCDocumentSelfie} {
+		if key, _, _ := customer.KYCDocument(docType); key == "" {
+			continue
+		}
+		link, err := uc.link(customer, docType)
+		if err != nil {
+			return nil, err
+		}
+		links = append(links, link)
+	}
+	return links, nil
+}
+
+// OpenSignedDocument serves an object for a signed URL. The signature is
+// the only credential, so it is checked before the storage is touched.
+func (uc *kycDocumentUseCase) OpenSignedDocument(ctx context.Context, key string, expires int64, signature string) (io.ReadCloser, string, error) {
+	if err := uc.storage.VerifySignature(key, expires, signature); err != nil {
+		return nil, "", fmt.Errorf("%w: %v", ErrInvalidFileLink, err)
+	}
+
+	file, err := uc.storage.Open(ctx, key)
+	if err != nil {
+		logger.Error("Failed to open signed file", "key", key, "error", err)
+		return nil, "", fmt.Errorf("file not found")
+	}
+
+	return file, contentTypeOf(key), nil
+}
+
+func (uc *kycDocumentUseCase) link(customer *entity.Customer, docType entity.KYCDocumentType) (*KYCDocumentLink, error) {
+	key, checksum, uploadedAt := customer.KYCDocument(docType)
+	url, expiresAt, err := uc.storage.SignedURL(key, uc.policy.URLTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &KYCDocumentLink{
+		DocumentType: docType,
+		ObjectKey:    key,
+		Checksum:     checksum,
+		UploadedAt:   uploadedAt,
+		URL:          url,
+		ExpiresAt:    expiresAt,
+	}, nil
+}
+
+func (uc *kycDocumentUseCase) removeObject(ctx context.Context, key string) {
+	removeStoredObject(ctx, uc.storage, key)
+}
+
+// storeUpload saves an upload under the key newKey builds for its type and
+// returns the key, SHA-256 checksum and size. The type is sniffed from the
+// content, not taken from the client, and the size is counted while storing.
+func storeUpload(ctx context.Context, storage repository.FileStorage, maxSize int64, allowedTypes []string, content io.Reader, newKey func(mimeType string) (string, error)) (string, string, int64, error) {
+	head := make([]byte, 512)
+	n, err := io.ReadFull(content, head)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return "", "", 0, fmt.Errorf("failed to read upload: %w", err)
+	}
+	head = head[:n]
+
+	mimeType := http.DetectContentType(head)
+	if !mimeTypeAllowed(allowedTypes, mimeType) {
+		return "", "", 0, fmt.Errorf("file type %s is not allowed, allowed: %s", mimeType, strings.Join(allowedTypes, ", "))
+	}
+
+	key, err := newKey(mimeType)
+	if err != nil {
+		return "", "", 0, err
+	}
+
+	// Never trust the declared size; stop one byte past the limit
+	hash := sha256.New()
+	reader := io.TeeReader(io.LimitReader(io.MultiReader(bytes.NewReader(head), content), maxSize+1), hash)
+	written, err := storage.Save(ctx, key, reader)
+	if err != nil {
+		return "", "", 0, err
+	}
+	if written > maxSize {
+		removeStoredObject(ctx, storage, key)
+		return "", "", 0, fmt.Errorf("file exceeds the maximum of %d bytes", maxSize)
+	}
+
+	return key, hex.EncodeToString(hash.Sum(nil)), written, nil
+}
+
+func removeStoredObject(ctx context.Context, storage repository.FileStorage, key string) {
+	if err := storage.Delete(ctx, key); err != nil {
+		logger.Error("Failed to remove stored file", "key", key, "error", err)
+	}
+}
+
+// contentTypeOf maps a stored object back to the type it was accepted as.
+func contentTypeOf(key string) string {
+	for _, extensions := range []map[string]string{kycFileExtensions, limitDocumentExtensions} {
+		for mimeType, ext := range extensions {
+			if path.Ext(key) == ext {
+				return mimeType
+			}
+		}
+	}
+	return "application/octet-stream"
+}
+
+// newObjectKey builds an unguessable key for an upload of the given type
+// under prefix, e.g. kyc/42/ktp-20240131T101500-9f2c4e1a.jpg
+func newObjectKey(prefix, mimeType string, extensions map[string]string) (string, error) {
+	ext, ok := extensions[mimeType]
+	if !ok {
+		return "", fmt.Errorf("unsupported file type %s", mimeType)
+	}
+
+	random := make([]byte, 8)
+	if _, err := rand.Read(random); err != nil {
+		return "", fmt.Errorf("failed to generate object key: %w", err)
+	}
+
+	return fmt.Sprintf("%s-%s-%s%s", prefix, time.Now().UTC().Format("20060102T150405"), hex.EncodeToString(random), ext), nil
+}
+
+// newKYCObjectKey builds an unguessable key per upload, e.g.
+// kyc/42/ktp-20240131T101500-9f2c4e1a.jpg
+func newKYCObjectKey(customerID uint64, docType entity.KYCDocumentType, mimeType string) (string, error) {
+	return newObjectKey(fmt.Sprintf("kyc/%d/%s", customerID, strings.ToLower(string(docType))), mimeType, kycFileExtensions)
+}
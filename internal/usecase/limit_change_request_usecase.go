@@ -0,0 +1,334 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"pt-xyz-multifinance/internal/domain/entity"
+	"pt-xyz-multifinance/internal/domain/repository"
+	"pt-xyz-multifinance/internal/infrastructure/database"
+	"pt-xyz-multifinance/pkg/logger"
+	"pt-xyz-multifinance/pkg/utils"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// LimitDocumentPolicy limits what may be uploaded as the supporting document
+// of a limit change request. Unlike KYC images, payslips and bank statements
+// usually arrive as PDFs.
+type LimitDocumentPolicy struct {
+	MaxSize          int64
+	AllowedMIMETypes []string
+	URLTTL           time.Duration
+}
+
+// Validate checks the policy at startup; only types with a known file
+// extension can be stored.
+func (p LimitDocumentPolicy) Validate() error {
+	if p.MaxSize <= 0 {
+		return fmt.Errorf("maximum limit document size must be positive")
+	}
+	if p.URLTTL <= 0 {
+		return fmt.Errorf("signed URL lifetime must be positive")
+	}
+	if len(p.AllowedMIMETypes) == 0 {
+		return fmt.Errorf("at least one limit document MIME type is required")
+	}
+	for _, mimeType := range p.AllowedMIMETypes {
+		if _, ok := limitDocumentExtensions[strings.ToLower(mimeType)]; !ok {
+			return fmt.Errorf("unsupported limit document MIME type %s", mimeType)
+		}
+	}
+	return nil
+}
+
+var limitDocumentExtensions = map[string]string{
+	"application/pdf": ".pdf",
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+}
+
+type LimitChangeRequestUseCase interface {
+	SubmitRequest(ctx context.Context, request *entity.LimitChangeRequest) error
+	UploadDocument(ctx context.Context, customerID uint64, requestID uint64, content io.Reader, size int64) (*entity.LimitChangeRequest, error)
+	DocumentURL(request *entity.LimitChangeRequest) (string, time.Time, error)
+	GetRequestByID(ctx context.Context, id uint64) (*entity.LimitChangeRequest, error)
+	GetRequestsByCustomerID(ctx context.Context, customerID uint64) ([]*entity.LimitChangeRequest, error)
+	GetRequestsByStatus(ctx context.Context, status entity.LimitChangeRequestStatus, limit, offset int) ([]*entity.LimitChangeRequest, error)
+	ApproveRequest(ctx context.Context, id uint64, adminID uint64, approvedAmount float64, reason string) (*entity.LimitChangeRequest, error)
+	RejectRequest(ctx context.Context, id uint64, adminID uint64, reason string) (*entity.LimitChangeRequest, error)
+}
+
+type limitChangeRequestUseCase struct {
+	requestRepo  repository.LimitChangeRequestRepository
+	limitRepo    repository.LimitRepository
+	customerRepo repository.CustomerRepository
+	storage      repository.FileStorage
+	uploadPolicy LimitDocumentPolicy
+	db           *gorm.DB
+}
+
+func NewLimitChangeRequestUseCase(
+	requestRepo repository.LimitChangeRequestRepository,
+	limitRepo repository.LimitRepository,
+	customerRepo repository.CustomerRepository,
+	storage repository.FileStorage,
+	uploadPolicy LimitDocumentPolicy,
+	db *gorm.DB,
+) LimitChangeRequestUseCase {
+	return &limitChangeRequestUseCase{
+		requestRepo:  requestRepo,
+		limitRepo:    limitRepo,
+		customerRepo: customerRepo,
+		storage:      storage,
+		uploadPolicy: uploadPolicy,
+		db:           db,
+	}
+}
+
+// SubmitRequest queues a customer's request for a higher limit. Only one
+// request per tenor may be pending at a time.
+func (uc *limitChangeRequestUseCase) SubmitRequest(ctx context.Context, request *entity.LimitChangeRequest) error {
+	if err := utils.ValidateTenor(request.TenorMonths); err != nil {
+		return err
+	}
+	if request.UpdatedSalary != nil && *request.UpdatedSalary <= 0 {
+		return fmt.Errorf("updated salary must be greater than 0")
+	}
+
+	limit, err := uc.limitRepo.GetByCustomerAndTenor(ctx, request.CustomerID, request.TenorMonths)
+	if err != nil {
+		return fmt.Errorf("customer limit not found for tenor %d months", request.TenorMonths)
+	}
+
+	if request.RequestedLimitAmount <= limit.LimitAmount {
+		return fmt.Errorf("requested limit %.2f must be higher than the current limit %.2f", request.RequestedLimitAmount, limit.LimitAmount)
+	}
+
+	existing, err := uc.requestRepo.GetByCustomerID(ctx, request.CustomerID)
+	if err != nil {
+		return err
+	}
+	for _, other := range existing {
+		if other.TenorMonths == request.TenorMonths && other.Status == entity.LimitRequestPending {
+			return fmt.Errorf("a limit change request for tenor %d months is already pending", request.TenorMonths)
+		}
+	}
+
+	request.CurrentLimitAmount = limit.LimitAmount
+	request.Status = entity.LimitRequestPending
+
+	if err := uc.requestRepo.Create(ctx, request); err != nil {
+		logger.Error("Failed to create limit change request", "customerID", request.CustomerID, "error", err)
+		return err
+	}
+
+	logger.Info("Limit change request submitted",
+		"requestID", request.ID,
+		"customerID", request.CustomerID,
+		"tenorMonths", request.TenorMonths,
+		"requestedLimit", request.RequestedLimitAmount)
+
+	return nil
+}
+
+// UploadDocument stores the supporting document of one of the customer's
+// pending requests, such as a payslip, and records its key, checksum and
+// upload time on the request. A new upload replaces the previous document.
+func (uc *limitChangeRequestUseCase) UploadDocument(ctx context.Context, customerID uint64, requestID uint64, content io.Reader, size int64) (*entity.LimitChangeRequest, error) {
+	if size <= 0 {
+		return nil, fmt.Errorf("file is empty")
+	}
+	if size > uc.uploadPolicy.MaxSize {
+		return nil, fmt.Errorf("file is %d bytes, the maximum is %d bytes", size, uc.uploadPolicy.MaxSize)
+	}
+
+	if request, err := uc.requestRepo.GetByID(ctx, requestID); err != nil || request.CustomerID != customerID {
+		return nil, fmt.Errorf("limit change request not found")
+	}
+
+	key, checksum, written, err := storeUpload(ctx, uc.storage, uc.uploadPolicy.MaxSize, uc.uploadPolicy.AllowedMIMETypes, content, func(mimeType string) (string, error) {
+		return newObjectKey(fmt.Sprintf("limit-requests/%d/%d", customerID, requestID), mimeType, limitDocumentExtensions)
+	})
+	if err != nil {
+		logger.Error("Failed to store limit change request document", "requestID", requestID, "error", err)
+		return nil, err
+	}
+
+	var request *entity.LimitChangeRequest
+	var previousKey string
+	err = uc.db.Transaction(func(tx *gorm.DB) error {
+		ctx := database.WithTx(ctx, tx)
+
+		var err error
+		request, err = uc.pendingRequest(ctx, requestID)
+		if err != nil {
+			return err
+		}
+		if request.CustomerID != customerID {
+			return fmt.Errorf("limit change request not found")
+		}
+
+		previousKey = request.DocumentKey
+		uploadedAt := time.Now()
+		request.DocumentKey = key
+		request.DocumentChecksum = checksum
+		request.DocumentUploadedAt = &uploadedAt
+		return uc.requestRepo.Update(ctx, request)
+	})
+	if err != nil {
+		removeStoredObject(ctx, uc.storage, key)
+		return nil, err
+	}
+
+	if previousKey != "" && previousKey != key {
+		removeStoredObject(ctx, uc.storage, previousKey)
+	}
+
+	logger.Info("Limit change request document uploaded", "requestID", request.ID, "customerID", customerID, "key", key, "size", written)
+	return request, nil
+}
+
+// DocumentURL returns a signed download URL for the request's document, or an
+// empty URL when none was uploaded.
+func (uc *limitChangeRequestUseCase) DocumentURL(request *entity.LimitChangeRequest) (string, time.Time, error) {
+	if request.DocumentKey == "" {
+		return "", time.Time{}, nil
+	}
+	return uc.storage.SignedURL(request.DocumentKey, uc.uploadPolicy.URLTTL)
+}
+
+// ApproveRequest applies the approved amount to the customer's limit, along
+// with the updated salary when the customer supplied one. An approved amount
+// of 0 grants the requested amount. The amount is bounded by the limit as it
+// stands now, which may have changed since the request was submitted.
+func (uc *limitChangeRequestUseCase) ApproveRequest(ctx context.Context, id uint64, adminID uint64, approvedAmount float64, reason string) (*entity.LimitChangeRequest, error) {
+	var request *entity.LimitChangeRequest
+
+	err := uc.db.Transaction(func(tx *gorm.DB) error {
+		ctx := database.WithTx(ctx, tx)
+
+		var err error
+		request, err = uc.pendingRequest(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		limit, err := uc.limitRepo.GetByCustomerAndTenorForUpdate(ctx, request.CustomerID, request.TenorMonths)
+		if err != nil {
+			return fmt.Errorf("customer limit not found for tenor %d months", request.TenorMonths)
+		}
+
+		if approvedAmount <= 0 {
+			approvedAmount = request.RequestedLimitAmount
+		}
+		if approvedAmount <= limit.LimitAmount || approvedAmount > request.RequestedLimitAmount {
+			return fmt.Errorf("approved amount must be above the current limit %.2f and at most the requested %.2f",
+				limit.LimitAmount, request.RequestedLimitAmount)
+		}
+
+		change := &entity.LimitChange{
+			CustomerID:     request.CustomerID,
+			TenorMonths:    request.TenorMonths,
+			NewLimitAmount: approvedAmount,
+			ChangedBy:      adminID,
+			Reason:         fmt.Sprintf("limit change request #%d approved", request.ID),
+		}
+		if reason != "" {
+			change.Reason += ": " + reason
+		}
+		if err := uc.limitRepo.UpdateLimitAmount(ctx, change); err != nil {
+			return err
+		}
+
+		if request.UpdatedSalary != nil {
+			customer, err := uc.customerRepo.GetByID(ctx, request.CustomerID)
+			if err != nil {
+				return fmt.Errorf("customer not found: %w", err)
+			}
+			customer.Salary = *request.UpdatedSalary
+			if err := uc.customerRepo.Update(ctx, customer); err != nil {
+				return fmt.Errorf("failed to update customer salary: %w", err)
+			}
+		}
+
+		request.ApprovedLimitAmount = approvedAmount
+		uc.markReviewed(request, entity.LimitRequestApproved, adminID, reason)
+		return uc.requestRepo.Update(ctx, request)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("Limit change request approved",
+		"requestID", request.ID,
+		"customerID", request.CustomerID,
+		"approvedLimit", request.ApprovedLimitAmount,
+		"adminID", adminID)
+
+	return request, nil
+}
+
+func (uc *limitChangeRequestUseCase) RejectRequest(ctx context.Context, id uint64, adminID uint64, reason string) (*entity.LimitChangeRequest, error) {
+	if strings.TrimSpace(reason) == "" {
+		return nil, fmt.Errorf("a reason is required to reject a limit change request")
+	}
+
+	var request *entity.LimitChangeRequest
+
+	err := uc.db.Transaction(func(tx *gorm.DB) error {
+		ctx := database.WithTx(ctx, tx)
+
+		var err error
+		request, err = uc.pendingRequest(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		uc.markReviewed(request, entity.LimitRequestRejected, adminID, reason)
+		return uc.requestRepo.Update(ctx, request)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("Limit change request rejected", "requestID", request.ID, "customerID", request.CustomerID, "adminID", adminID)
+	return request, nil
+}
+
+// pendingRequest locks the request, so that of two concurrent reviews only the
+// first sees it pending. ctx must carry a database transaction.
+func (uc *limitChangeRequestUseCase) pendingRequest(ctx context.Context, id uint64) (*entity.LimitChangeRequest, error) {
+	request, err := uc.requestRepo.GetByIDForUpdate(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("limit change request not found: %w", err)
+	}
+	if request.Status != entity.LimitRequestPending {
+		return nil, fmt.Errorf("limit change request is already %s", request.Status)
+	}
+	return request, nil
+}
+
+func (uc *limitChangeRequestUseCase) markReviewed(request *entity.LimitChangeRequest, status entity.LimitChangeRequestStatus, adminID uint64, reason string) {
+	now := time.Now()
+	request.Status = status
+	request.ReviewReason = reason
+	request.ReviewedBy = &adminID
+	request.ReviewedAt = &now
+}
+
+func (uc *limitChangeRequestUseCase) GetRequestByID(ctx context.Context, id uint64) (*entity.LimitChangeRequest, error) {
+	return uc.requestRepo.GetByID(ctx, id)
+}
+
+func (uc *limitChangeRequestUseCase) GetRequestsByCustomerID(ctx context.Context, customerID uint64) ([]*entity.LimitChangeRequest, error) {
+	return uc.requestRepo.GetByCustomerID(ctx, customerID)
+}
+
+func (uc *limitChangeRequestUseCase) GetRequestsByStatus(ctx context.Context, status entity.LimitChangeRequestStatus, limit, offset int) ([]*entity.LimitChangeRequest, error) {
+	return uc.requestRepo.GetByStatus(ctx, status, limit, offset)
+}
@@ -0,0 +1,77 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"pt-xyz-multifinance/internal/domain/entity"
+	"pt-xyz-multifinance/internal/domain/repository"
+	"pt-xyz-multifinance/pkg/logger"
+)
+
+// LimitPolicy decides which tenors give limit back as contracts are repaid.
+// Tenors are revolving unless listed as non-revolving; those keep the reserved
+// amount used until the contract completes.
+type LimitPolicy struct {
+	NonRevolvingTenors []int
+}
+
+func (p LimitPolicy) IsRevolving(tenorMonths int) bool {
+	for _, tenor := range p.NonRevolvingTenors {
+		if tenor == tenorMonths {
+			return false
+		}
+	}
+	return true
+}
+
+// restorable returns how much of amount may still be given back for the
+// contract. Every contract gets its remainder back once completed, and none
+// restores more than its financed amount in total.
+func (p LimitPolicy) restorable(transaction *entity.Transaction, amount float64, completed bool) float64 {
+	if !completed && !p.IsRevolving(transaction.TenorMonths) {
+		return 0
+	}
+
+	remaining := roundAmount(transaction.FinancedPrincipal() - transaction.LimitRestored)
+	if amount > remaining {
+		amount = remaining
+	}
+	if amount <= 0 {
+		return 0
+	}
+	return roundAmount(amount)
+}
+
+// restoreLimit gives up to amount of the contract's reserved limit back to the
+// customer, recording the payment that caused it when there is one, and returns
+// what was actually restored.
+func restoreLimit(ctx context.Context, limitRepo repository.LimitRepository, policy LimitPolicy, transaction *entity.Transaction, amount float64, completed bool, paymentID *uint64, reason string) (float64, error) {
+	amount = policy.restorable(transaction, amount, completed)
+	if amount <= 0 {
+		return 0, nil
+	}
+
+	transactionID := transaction.ID
+	movement := &entity.LimitMovement{
+		CustomerID:    transaction.CustomerID,
+		TenorMonths:   transaction.TenorMonths,
+		Type:          entity.MovementRepaymentRestore,
+		Amount:        -amount,
+		TransactionID: &transactionID,
+		PaymentID:     paymentID,
+		Reason:        reason,
+	}
+	if err := limitRepo.UpdateUsedAmount(ctx, movement); err != nil {
+		return 0, fmt.Errorf("failed to restore used amount: %w", err)
+	}
+	transaction.LimitRestored = roundAmount(transaction.LimitRestored + amount)
+
+	logger.Info("Customer limit restored",
+		"transactionID", transaction.ID,
+		"customerID", transaction.CustomerID,
+		"tenorMonths", transaction.TenorMonths,
+		"amount", amount,
+		"totalRestored", transaction.LimitRestored)
+
+	return amount, nil
+}
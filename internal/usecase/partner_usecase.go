@@ -0,0 +1,313 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"pt-xyz-multifinance/internal/domain/entity"
+	"pt-xyz-multifinance/internal/domain/repository"
+	"pt-xyz-multifinance/internal/infrastructure/database"
+	"pt-xyz-multifinance/pkg/logger"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrInvalidPartnerCredentials does not say whether the key or the secret was
+// wrong.
+var ErrInvalidPartnerCredentials = errors.New("invalid partner credentials")
+
+// ErrPartnerNotActive refuses requests from a suspended or terminated partner.
+var ErrPartnerNotActive = errors.New("partner is not active")
+
+// ErrCustomerNotLinked refuses a partner acting for a customer who has not
+// been linked to it.
+var ErrCustomerNotLinked = errors.New("customer is not linked to this partner")
+
+// PartnerCredentialPolicy sets how long credentials keep working after they
+// are rotated out, so the partner can deploy the new pair.
+type PartnerCredentialPolicy struct {
+	RotationGrace time.Duration
+}
+
+// IssuedPartnerCredential carries the plain secret of a new credential. It is
+// not stored and cannot be retrieved later.
+type IssuedPartnerCredential struct {
+	Credential *entity.PartnerCredential
+	Secret     string
+}
+
+type PartnerUseCase interface {
+	CreatePartner(ctx context.Context, partner *entity.Partner) error
+	GetPartnerByID(ctx context.Context, id uint64) (*entity.Partner, error)
+	GetPartners(ctx context.Context, limit, offset int) ([]*entity.Partner, error)
+	UpdatePartner(ctx context.Context, partner *entity.Partner) error
+	RotateCredentials(ctx context.Context, partnerID uint64, adminID uint64) (*IssuedPartnerCredential, error)
+	GetCredentials(ctx context.Context, partnerID uint64) ([]*entity.PartnerCredential, error)
+	RevokeCredential(ctx context.Context, partnerID, credentialID uint64) (*entity.PartnerCredential, error)
+	Authenticate(ctx context.Context, apiKey, secret string) (*entity.Partner, error)
+	LinkCustomer(ctx context.Context, partnerID, customerID, userID uint64) (*entity.PartnerCustomer, error)
+	UnlinkCustomer(ctx context.Context, partnerID, customerID uint64) error
+	GetCustomerLinks(ctx context.Context, customerID uint64) ([]*entity.PartnerCustomer, error)
+	CheckCustomerLink(ctx context.Context, partnerID, customerID uint64) error
+}
+
+type partnerUseCase struct {
+	partnerRepo    repository.PartnerRepository
+	credentialRepo repository.PartnerCredentialRepository
+	customerRepo   repository.CustomerRepository
+	policy         PartnerCredentialPolicy
+	db             *gorm.DB
+}
+
+func NewPartnerUseCase(partnerRepo repository.PartnerRepository, credentialRepo repository.PartnerCredentialRepository, customerRepo repository.CustomerRepository, policy PartnerCredentialPolicy, db *gorm.DB) PartnerUseCase {
+	return &partnerUseCase{
+		partnerRepo:    partnerRepo,
+		credentialRepo: credentialRepo,
+		customerRepo:   customerRepo,
+		policy:         policy,
+		db:             db,
+	}
+}
+
+func (uc *partnerUseCase) CreatePartner(ctx context.Context, partner *entity.Partner) error {
+	if partner.Status == "" {
+		partner.Status = entity.PartnerActive
+	}
+	if err := validatePartner(partner); err != nil {
+		return err
+	}
+
+	if err := uc.partnerRepo.Create(ctx, partner); err != nil {
+		logger.Error("Failed to create partner", "name", partner.Name, "error", err)
+		return err
+	}
+
+	logger.Info("Partner created", "partnerID", partner.ID, "type", partner.Type)
+	return nil
+}
+
+func (uc *partnerUseCase) GetPartnerByID(ctx context.Context, id uint64) (*entity.Partner, error) {
+	return uc.partnerRepo.GetByID(ctx, id)
+}
+
+func (uc *partnerUseCase) GetPartners(ctx context.Context, limit, offset int) ([]*entity.Partner, error) {
+	return uc.partnerRepo.GetAll(ctx, limit, offset)
+}
+
+func (uc *partnerUseCase) UpdatePartner(ctx context.Context, partner *entity.Partner) error {
+	if err := validatePartner(partner); err != nil {
+		return err
+	}
+
+	if err := uc.partnerRepo.Update(ctx, partner); err != nil {
+		logger.Error("Failed to update partner", "partnerID", partner.ID, "error", err)
+		return err
+	}
+
+	logger.Info("Partner updated", "partnerID", partner.ID, "status", partner.Status)
+	return nil
+}
+
+// RotateCredentials issues a new API key and secret. The partner's existing
+// credentials keep working for the rotation grace period and then expire.
+func (uc *partnerUseCase) RotateCredentials(ctx context.Context, partnerID uint64, adminID uint64) (*IssuedPartnerCredential, error) {
+	partner, err := uc.partnerRepo.GetByID(ctx, partnerID)
+	if err != nil {
+		return nil, fmt.Errorf("partner not found: %w", err)
+	}
+	if partner.Status == entity.PartnerTerminated {
+		return nil, fmt.Errorf("credentials cannot be issued to a terminated partner")
+	}
+
+	apiKey, err := randomToken("pk_", 12)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := randomToken("sk_", 32)
+	if err != nil {
+		return nil, err
+	}
+
+	credential := &entity.PartnerCredential{
+		PartnerID:  partnerID,
+		APIKey:     apiKey,
+		SecretHash: hashPartnerSecret(secret),
+		CreatedBy:  &adminID,
+	}
+
+	// The old credentials only expire if the new one is stored
+	err = uc.db.Transaction(func(tx *gorm.DB) error {
+		ctx := database.WithTx(ctx, tx)
+		if err := uc.credentialRepo.ExpireActive(ctx, partnerID, time.Now().Add(uc.policy.RotationGrace)); err != nil {
+			return err
+		}
+		return uc.credentialRepo.Create(ctx, credential)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("Partner credentials rotated", "partnerID", partnerID, "credentialID", credential.ID, "adminID", adminID)
+	return &IssuedPartnerCredential{Credential: credential, Secret: secret}, nil
+}
+
+func (uc *partnerUseCase) GetCredentials(ctx context.Context, partnerID uint64) ([]*entity.PartnerCredential, error) {
+	return uc.credentialRepo.GetByPartnerID(ctx, partnerID)
+}
+
+// RevokeCredential stops a credential at once, e.g. after it leaked.
+func (uc *partnerUseCase) RevokeCredential(ctx context.Context, partnerID, credentialID uint64) (*entity.PartnerCredential, error) {
+	credential, err := uc.credentialRepo.GetByID(ctx, credentialID)
+	if err != nil || credential.PartnerID != partnerID {
+		return nil, fmt.Errorf("partner credential not found")
+	}
+	if credential.RevokedAt != nil {
+		return nil, fmt.Errorf("partner credential is already revoked")
+	}
+
+	revokedAt := time.Now()
+	credential.RevokedAt = &revokedAt
+	if err := uc.credentialRepo.Update(ctx, credential); err != nil {
+		return nil, err
+	}
+
+	logger.Info("Partner credential revoked", "partnerID", partnerID, "credentialID", credentialID)
+	return credential, nil
+}
+
+// Authenticate returns the partner owning the API key when the secret matches
+// and both the credential and the partner are active.
+func (uc *partnerUseCase) Authenticate(ctx context.Context, apiKey, secret string) (*entity.Partner, error) {
+	if apiKey == "" || secret == "" {
+		return nil, ErrInvalidPartnerCredentials
+	}
+
+	credential, err := uc.credentialRepo.GetByAPIKey(ctx, apiKey)
+	if err != nil {
+		return nil, err
+	}
+	if credential == nil {
+		return nil, ErrInvalidPartnerCredentials
+	}
+
+	if subtle.ConstantTimeCompare([]byte(hashPartnerSecret(secret)), []byte(credential.SecretHash)) != 1 {
+		return nil, ErrInvalidPartnerCredentials
+	}
+
+	now := time.Now()
+	if !credential.IsActiveAt(now) {
+		return nil, ErrInvalidPartnerCredentials
+	}
+	if credential.Partner.Status != entity.PartnerActive {
+		return nil, ErrPartnerNotActive
+	}
+
+	// Losing the last-used time is not worth failing the request for
+	if err := uc.credentialRepo.TouchLastUsed(ctx, credential.ID, now); err != nil {
+		logger.Error("Failed to record partner credential use", "credentialID", credential.ID, "error", err)
+	}
+
+	return &credential.Partner, nil
+}
+
+// LinkCustomer records the customer's consent to the partner originating
+// contracts for them. userID is the customer's own user or the admin acting
+// on their behalf.
+func (uc *partnerUseCase) LinkCustomer(ctx context.Context, partnerID, customerID, userID uint64) (*entity.PartnerCustomer, error) {
+	partner, err := uc.partnerRepo.GetByID(ctx, partnerID)
+	if err != nil {
+		return nil, fmt.Errorf("partner not found: %w", err)
+	}
+	if partner.Status != entity.PartnerActive {
+		return nil, ErrPartnerNotActive
+	}
+	if _, err := uc.customerRepo.GetByID(ctx, customerID); err != nil {
+		return nil, fmt.Errorf("customer not found: %w", err)
+	}
+
+	link := &entity.PartnerCustomer{
+		PartnerID:  partnerID,
+		CustomerID: customerID,
+		LinkedBy:   userID,
+	}
+	if err := uc.partnerRepo.LinkCustomer(ctx, link); err != nil {
+		return nil, err
+	}
+	link.Partner = *partner
+
+	logger.Info("Customer linked to partner", "partnerID", partnerID, "customerID", customerID, "linkedBy", userID)
+	return link, nil
+}
+
+// UnlinkCustomer withdraws the consent; contracts already originated stay.
+func (uc *partnerUseCase) UnlinkCustomer(ctx context.Context, partnerID, customerID uint64) error {
+	if err := uc.partnerRepo.UnlinkCustomer(ctx, partnerID, customerID); err != nil {
+		return err
+	}
+
+	logger.Info("Customer unlinked from partner", "partnerID", partnerID, "customerID", customerID)
+	return nil
+}
+
+func (uc *partnerUseCase) GetCustomerLinks(ctx context.Context, customerID uint64) ([]*entity.PartnerCustomer, error) {
+	return uc.partnerRepo.GetCustomerLinks(ctx, customerID)
+}
+
+// CheckCustomerLink returns ErrCustomerNotLinked unless the customer has been
+// linked to the partner.
+func (uc *partnerUseCase) CheckCustomerLink(ctx context.Context, partnerID, customerID uint64) error {
+	linked, err := uc.partnerRepo.IsCustomerLinked(ctx, partnerID, customerID)
+	if err != nil {
+		return err
+	}
+	if !linked {
+		return ErrCustomerNotLinked
+	}
+	return nil
+}
+
+func validatePartner(partner *entity.Partner) error {
+	if !partner.Type.IsValid() {
+		return fmt.Errorf("invalid partner type: %s", partner.Type)
+	}
+	if !partner.Status.IsValid() {
+		return fmt.Errorf("invalid partner status: %s", partner.Status)
+	}
+
+	partner.Name = strings.TrimSpace(partner.Name)
+	if partner.Name == "" {
+		return fmt.Errorf("partner name is required")
+	}
+
+	partner.SettlementAccountNumber = strings.TrimSpace(partner.SettlementAccountNumber)
+	if strings.TrimSpace(partner.SettlementBankName) == "" || partner.SettlementAccountNumber == "" || strings.TrimSpace(partner.SettlementAccountName) == "" {
+		return fmt.Errorf("settlement bank, account number and account name are required")
+	}
+	for _, r := range partner.SettlementAccountNumber {
+		if r < '0' || r > '9' {
+			return fmt.Errorf("settlement account number must contain digits only")
+		}
+	}
+	return nil
+}
+
+// hashPartnerSecret uses a plain SHA-256: the secrets are 32 random bytes, so
+// unlike passwords they need no slow hash.
+func hashPartnerSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+func randomToken(prefix string, size int) (string, error) {
+	random := make([]byte, size)
+	if _, err := rand.Read(random); err != nil {
+		return "", fmt.Errorf("failed to generate partner credential: %w", err)
+	}
+	return prefix + hex.EncodeToString(random), nil
+}
@@ -0,0 +1,279 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"pt-xyz-multifinance/internal/domain/entity"
+	"pt-xyz-multifinance/internal/domain/repository"
+	"pt-xyz-multifinance/internal/infrastructure/database"
+	"pt-xyz-multifinance/pkg/logger"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type PaymentUseCase interface {
+	RecordPayment(ctx context.Context, payment *entity.Payment) error
+	GetPaymentByID(ctx context.Context, id uint64) (*entity.Payment, error)
+	GetPaymentsByTransactionID(ctx context.Context, transactionID uint64) ([]*entity.Payment, error)
+}
+
+type paymentUseCase struct {
+	paymentRepo     repository.PaymentRepository
+	transactionRepo repository.TransactionRepository
+	scheduleRepo    repository.InstallmentScheduleRepository
+	customerRepo    repository.CustomerRepository
+	limitRepo       repository.LimitRepository
+	delinquency     DelinquencyUseCase
+	stateMachine    TransactionStateMachine
+	allocationOrder []entity.PaymentComponent
+	limitPolicy     LimitPolicy
+	db              *gorm.DB
+}
+
+func NewPaymentUseCase(
+	paymentRepo repository.PaymentRepository,
+	transactionRepo repository.TransactionRepository,
+	scheduleRepo repository.InstallmentScheduleRepository,
+	customerRepo repository.CustomerRepository,
+	limitRepo repository.LimitRepository,
+	delinquency DelinquencyUseCase,
+	stateMachine TransactionStateMachine,
+	allocationOrder []entity.PaymentComponent,
+	limitPolicy LimitPolicy,
+	db *gorm.DB,
+) PaymentUseCase {
+	if len(allocationOrder) == 0 {
+		allocationOrder = entity.DefaultAllocationOrder
+	}
+
+	return &paymentUseCase{
+		paymentRepo:     paymentRepo,
+		transactionRepo: transactionRepo,
+		scheduleRepo:    scheduleRepo,
+		customerRepo:    customerRepo,
+		limitRepo:       limitRepo,
+		delinquency:     delinquency,
+		stateMachine:    stateMachine,
+		allocationOrder: allocationOrder,
+		limitPolicy:     limitPolicy,
+		db:              db,
+	}
+}
+
+func (uc *paymentUseCase) RecordPayment(ctx context.Context, payment *entity.Payment) error {
+	if payment.Amount <= 0 {
+		return fmt.Errorf("payment amount must be greater than 0")
+	}
+
+	if payment.PaymentDate.IsZero() {
+		payment.PaymentDate = time.Now()
+	}
+
+	return uc.db.Transaction(func(tx *gorm.DB) error {
+		ctx := database.WithTx(ctx, tx)
+
+		// Concurrent payments on the contract wait here, so each allocates
+		// against the balance the previous one left
+		transaction, err := uc.transactionRepo.GetByIDForUpdate(ctx, payment.TransactionID)
+		if err != nil {
+			return fmt.Errorf("transaction not found: %w", err)
+		}
+
+		if transaction.Status != entity.StatusActive && transaction.Status != entity.StatusDefaulted {
+			return fmt.Errorf("payments can only be recorded for ACTIVE or DEFAULTED transactions, current status: %s", transaction.Status)
+		}
+
+		if err := uc.checkPaymentDate(ctx, transaction, payment.PaymentDate); err != nil {
+			return err
+		}
+
+		installments, err := uc.scheduleRepo.GetByTransactionIDForUpdate(ctx, transaction.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get installment schedule: %w", err)
+		}
+
+		var openInstallments []*entity.InstallmentSchedule
+		for _, installment := range installments {
+			if installment.IsOpen() {
+				openInstallments = append(openInstallments, installment)
+			}
+		}
+
+		// Bring penalties up to the payment date so they are settled first
+		uc.delinquency.AssessInstallments(transaction, installments, payment.PaymentDate)
+
+		payment.CustomerID = transaction.CustomerID
+		remaining := uc.allocate(payment, installments)
+
+		uc.delinquency.AssessInstallments(transaction, installments, payment.PaymentDate)
+
+		for _, installment := range openInstallments {
+			if err := uc.scheduleRepo.Update(ctx, installment); err != nil {
+				return fmt.Errorf("failed to update installment: %w", err)
+			}
+		}
+
+		// Repaid principal goes back to a revolving limit; the final payment
+		// gives back whatever the contract still holds, revolving or not.
+		completed := allInstallmentsPaid(installments)
+		restoreAmount := payment.AllocatedPrincipal
+		if completed {
+			restoreAmount = transaction.FinancedPrincipal()
+		}
+
+		payment.ExcessAmount = remaining
+		payment.LimitRestored = uc.limitPolicy.restorable(transaction, restoreAmount, completed)
+		if err := uc.paymentRepo.Create(ctx, payment); err != nil {
+			logger.Error("Failed to create payment", "error", err)
+			return fmt.Errorf("failed to record payment: %w", err)
+		}
+
+		paymentID := payment.ID
+		if _, err := restoreLimit(ctx, uc.limitRepo, uc.limitPolicy, transaction, payment.LimitRestored, completed, &paymentID, fmt.Sprintf("payment %d", payment.ID)); err != nil {
+			return err
+		}
+
+		if completed {
+			if err := uc.stateMachine.Transition(ctx, transaction, entity.StatusCompleted, "all installments paid"); err != nil {
+				return err
+			}
+		}
+
+		if remaining > 0 {
+			if err := uc.customerRepo.AddCreditBalance(ctx, transaction.CustomerID, remaining); err != nil {
+				return fmt.Errorf("failed to store overpayment as customer credit: %w", err)
+			}
+		}
+
+		if err := uc.transactionRepo.Update(ctx, transaction); err != nil {
+			return fmt.Errorf("failed to update transaction: %w", err)
+		}
+
+		if completed {
+			logger.Info("Transaction completed after final payment", "transactionID", transaction.ID, "contractNumber", transaction.ContractNumber)
+		}
+
+		logger.Info("Payment recorded",
+			"paymentID", payment.ID,
+			"transactionID", transaction.ID,
+			"amount", payment.Amount,
+			"excessAmount", payment.ExcessAmount,
+			"limitRestored", payment.LimitRestored)
+
+		return nil
+	})
+}
+
+// checkPaymentDate refuses payment dates that would skew penalties, which are
+// assessed as of the payment date and never decrease: dates after today, before
+// the contract was activated, or before the last payment recorded on it.
+func (uc *paymentUseCase) checkPaymentDate(ctx context.Context, transaction *entity.Transaction, paymentDate time.Time) error {
+	date := calendarDate(paymentDate)
+	if date.After(calendarDate(time.Now())) {
+		return fmt.Errorf("payment date %s is in the future", date.Format("2006-01-02"))
+	}
+
+	if transaction.ActivatedAt != nil && date.Before(calendarDate(*transaction.ActivatedAt)) {
+		return fmt.Errorf("payment date %s is before the contract was activated on %s",
+			date.Format("2006-01-02"), transaction.ActivatedAt.Format("2006-01-02"))
+	}
+
+	payments, err := uc.paymentRepo.GetByTransactionID(ctx, transaction.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get previous payments: %w", err)
+	}
+	if len(payments) > 0 {
+		last := payments[len(payments)-1].PaymentDate
+		if date.Before(calendarDate(last)) {
+			return fmt.Errorf("payment date %s is before the last payment on %s",
+				date.Format("2006-01-02"), last.Format("2006-01-02"))
+		}
+	}
+
+	return nil
+}
+
+// allocate spreads the payment over open installments, oldest first, settling
+// components of each installment in the configured order. It returns the part
+// of the payment that could not be allocated.
+func (uc *paymentUseCase) allocate(payment *entity.Payment, installments []*entity.InstallmentSchedule) float64 {
+	remaining := roundAmount(payment.Amount)
+
+	for _, installment := range installments {
+		if remaining <= 0 {
+			break
+		}
+		if !installment.IsOpen() {
+			continue
+		}
+
+		allocation := entity.PaymentAllocation{InstallmentID: installment.ID}
+		for _, component := range uc.allocationOrder {
+			outstanding := roundAmount(installment.OutstandingFor(component))
+			if outstanding <= 0 || remaining <= 0 {
+				continue
+			}
+
+			applied := outstanding
+			if remaining < applied {
+				applied = remaining
+			}
+			remaining = roundAmount(remaining - applied)
+
+			switch component {
+			case entity.ComponentPenalty:
+				installment.PenaltyPaid = roundAmount(installment.PenaltyPaid + applied)
+				allocation.Penalty = applied
+				payment.AllocatedPenalty = roundAmount(payment.AllocatedPenalty + applied)
+			case entity.ComponentInterest:
+				installment.InterestPaid = roundAmount(installment.InterestPaid + applied)
+				allocation.Interest = applied
+				payment.AllocatedInterest = roundAmount(payment.AllocatedInterest + applied)
+			case entity.ComponentAdminFee:
+				installment.AdminFeePaid = roundAmount(installment.AdminFeePaid + applied)
+				allocation.AdminFee = applied
+				payment.AllocatedAdminFee = roundAmount(payment.AllocatedAdminFee + applied)
+			case entity.ComponentPrincipal:
+				installment.PrincipalPaid = roundAmount(installment.PrincipalPaid + applied)
+				allocation.Principal = applied
+				payment.AllocatedPrincipal = roundAmount(payment.AllocatedPrincipal + applied)
+			}
+		}
+
+		if allocation.Total() == 0 {
+			continue
+		}
+
+		if roundAmount(installment.Outstanding()) <= 0 {
+			paidAt := payment.PaymentDate
+			installment.Status = entity.InstallmentPaid
+			installment.PaidAt = &paidAt
+		} else {
+			installment.Status = entity.InstallmentPartiallyPaid
+		}
+		payment.Allocations = append(payment.Allocations, allocation)
+	}
+
+	return remaining
+}
+
+func (uc *paymentUseCase) GetPaymentByID(ctx context.Context, id uint64) (*entity.Payment, error) {
+	return uc.paymentRepo.GetByID(ctx, id)
+}
+
+func (uc *paymentUseCase) GetPaymentsByTransactionID(ctx context.Context, transactionID uint64) ([]*entity.Payment, error) {
+	return uc.paymentRepo.GetByTransactionID(ctx, transactionID)
+}
+
+func allInstallmentsPaid(installments []*entity.InstallmentSchedule) bool {
+	if len(installments) == 0 {
+		return false
+	}
+	for _, installment := range installments {
+		if installment.IsOpen() {
+			return false
+		}
+	}
+	return true
+}
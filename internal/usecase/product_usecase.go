@@ -0,0 +1,443 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"pt-xyz-multifinance/internal/domain/entity"
+	"pt-xyz-multifinance/internal/domain/repository"
+	"pt-xyz-multifinance/internal/domain/service"
+	"pt-xyz-multifinance/internal/infrastructure/database"
+	"pt-xyz-multifinance/pkg/constants"
+	"pt-xyz-multifinance/pkg/logger"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type ProductUseCase interface {
+	CreateProduct(ctx context.Context, product *entity.Product) error
+	GetProductByID(ctx context.Context, id uint64) (*entity.Product, error)
+	GetAllProducts(ctx context.Context) ([]*entity.Product, error)
+	UpdateProduct(ctx context.Context, product *entity.Product) error
+	DeleteProduct(ctx context.Context, id uint64) error
+	ResolveProduct(ctx context.Context, productID uint64, tenorMonths int, assetType entity.AssetType, otrAmount float64) (*entity.Product, error)
+	EligibleProducts(ctx context.Context, productID uint64, assetType entity.AssetType, otrAmount float64) ([]*entity.Product, error)
+	CheckApplicantAge(ctx context.Context, birthDate, asOf time.Time) error
+	EnsureDefaultProduct(ctx context.Context, annualRate float64) error
+	RefreshCatalog(ctx context.Context) error
+	OfferedTenors() []int
+}
+
+type productUseCase struct {
+	productRepo  repository.ProductRepository
+	rateCardRepo repository.RateCardRepository
+	db           *gorm.DB
+
+	mu            sync.RWMutex
+	offeredTenors []int
+}
+
+func NewProductUseCase(productRepo repository.ProductRepository, rateCardRepo repository.RateCardRepository, db *gorm.DB) ProductUseCase {
+	return &productUseCase{
+		productRepo:  productRepo,
+		rateCardRepo: rateCardRepo,
+		db:           db,
+	}
+}
+
+// CreateProduct stores the product together with its opening rate cards, so
+// that it can price every tenor and asset type it offers right away.
+func (uc *productUseCase) CreateProduct(ctx context.Context, product *entity.Product) error {
+	if err := validateProduct(product); err != nil {
+		return err
+	}
+
+	if existing, err := uc.productRepo.GetByCode(ctx, product.Code); err == nil && existing != nil {
+		return fmt.Errorf("product with code %s already exists", product.Code)
+	}
+
+	err := uc.db.Transaction(func(tx *gorm.DB) error {
+		ctx := database.WithTx(ctx, tx)
+		if err := uc.productRepo.Create(ctx, product); err != nil {
+			return err
+		}
+		return uc.openRateCards(ctx, product)
+	})
+	if err != nil {
+		logger.Error("Failed to create product", "code", product.Code, "error", err)
+		return err
+	}
+
+	logger.Info("Product created", "productID", product.ID, "code", product.Code)
+	return uc.RefreshCatalog(ctx)
+}
+
+// UpdateProduct saves the product and, when its interest rate or admin fee
+// changed, publishes new rate card versions at that price so that contracts
+// created from now on are charged what the product shows.
+func (uc *productUseCase) UpdateProduct(ctx context.Context, product *entity.Product) error {
+	if err := validateProduct(product); err != nil {
+		return err
+	}
+
+	if existing, err := uc.productRepo.GetByCode(ctx, product.Code); err == nil && existing.ID != product.ID {
+		return fmt.Errorf("product with code %s already exists", product.Code)
+	}
+
+	stored, err := uc.productRepo.GetByID(ctx, product.ID)
+	if err != nil {
+		return fmt.Errorf("product not found: %w", err)
+	}
+	repriced := stored.InterestRate != product.InterestRate ||
+		stored.AdminFeeType != product.AdminFeeType ||
+		stored.AdminFeeValue != product.AdminFeeValue
+
+	err = uc.db.Transaction(func(tx *gorm.DB) error {
+		ctx := database.WithTx(ctx, tx)
+		if err := uc.productRepo.Update(ctx, product); err != nil {
+			return err
+		}
+		if repriced {
+			return uc.repriceRateCards(ctx, product)
+		}
+		return uc.openRateCards(ctx, product)
+	})
+	if err != nil {
+		logger.Error("Failed to update product", "productID", product.ID, "error", err)
+		return err
+	}
+
+	logger.Info("Product updated", "productID", product.ID, "code", product.Code)
+	return uc.RefreshCatalog(ctx)
+}
+
+func (uc *productUseCase) DeleteProduct(ctx context.Context, id uint64) error {
+	if err := uc.productRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	logger.Info("Product deleted", "productID", id)
+	return uc.RefreshCatalog(ctx)
+}
+
+func (uc *productUseCase) GetProductByID(ctx context.Context, id uint64) (*entity.Product, error) {
+	return uc.productRepo.GetByID(ctx, id)
+}
+
+func (uc *productUseCase) GetAllProducts(ctx context.Context) ([]*entity.Product, error) {
+	return uc.productRepo.GetAll(ctx)
+}
+
+// ResolveProduct returns the product that finances the given tenor, asset and
+// OTR amount. With a product ID that product must accept them; without one the
+// first active product that does is used.
+func (uc *productUseCase) ResolveProduct(ctx context.Context, productID uint64, tenorMonths int, assetType entity.AssetType, otrAmount float64) (*entity.Product, error) {
+	if productID != 0 {
+		product, err := uc.productRepo.GetByID(ctx, productID)
+		if err != nil {
+			return nil, fmt.Errorf("product not found: %w", err)
+		}
+		if err := checkProductEligibility(product, tenorMonths, assetType, otrAmount); err != nil {
+			return nil, err
+		}
+		return product, nil
+	}
+
+	products, err := uc.productRepo.GetActive(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, product := range products {
+		if checkProductEligibility(product, tenorMonths, assetType, otrAmount) == nil {
+			return product, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no active product finances %s for %d months with OTR %.2f", assetType, tenorMonths, otrAmount)
+}
+
+// EligibleProducts returns the active products that finance the asset type and
+// OTR amount at any tenor, limited to one product when productID is set.
+func (uc *productUseCase) EligibleProducts(ctx context.Context, productID uint64, assetType entity.AssetType, otrAmount float64) ([]*entity.Product, error) {
+	var products []*entity.Product
+	if productID != 0 {
+		product, err := uc.productRepo.GetByID(ctx, productID)
+		if err != nil {
+			return nil, fmt.Errorf("product not found: %w", err)
+		}
+		products = []*entity.Product{product}
+	} else {
+		active, err := uc.productRepo.GetActive(ctx)
+		if err != nil {
+			return nil, err
+		}
+		products = active
+	}
+
+	eligible := make([]*entity.Product, 0, len(products))
+	for _, product := range products {
+		if product.IsActive && product.AllowsAssetType(assetType) && product.AllowsOTR(otrAmount) {
+			eligible = append(eligible, product)
+		}
+	}
+	return eligible, nil
+}
+
+// CheckApplicantAge accepts an applicant whose age meets the rules of at
+// least one active product at one of its tenors. Otherwise it returns the
+// refusal of the first active product.
+func (uc *productUseCase) CheckApplicantAge(ctx context.Context, birthDate, asOf time.Time) error {
+	products, err := uc.productRepo.GetActive(ctx)
+	if err != nil {
+		return err
+	}
+
+	var refusal error
+	for _, product := range products {
+		for _, tenor := range product.AllowedTenors {
+			err := service.CheckAgeEligibility(product, birthDate, asOf, tenor)
+			if err == nil {
+				return nil
+			}
+			if refusal == nil {
+				refusal = err
+			}
+		}
+	}
+	return refusal
+}
+
+// EnsureDefaultProduct seeds the catalog with the built-in tenors and every
+// asset type when it is empty, with a rate card at annualRate for each, so a
+// fresh installation keeps working.
+func (uc *productUseCase) EnsureDefaultProduct(ctx context.Context, annualRate float64) error {
+	products, err := uc.productRepo.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(products) == 0 {
+		product := &entity.Product{
+			Code:               "DEFAULT",
+			Name:               "Default Financing",
+			AllowedTenors:      constants.GetValidTenors(),
+			InterestRate:       annualRate,
+			AdminFeeType:       entity.AdminFeeFlat,
+			EligibleAssetTypes: []entity.AssetType{entity.AssetWhiteGoods, entity.AssetMotor, entity.AssetMobil},
+			IsActive:           true,
+		}
+		err := uc.db.Transaction(func(tx *gorm.DB) error {
+			ctx := database.WithTx(ctx, tx)
+			if err := uc.productRepo.Create(ctx, product); err != nil {
+				return err
+			}
+			return uc.openRateCards(ctx, product)
+		})
+		if err != nil {
+			return err
+		}
+		logger.Info("Default product created", "productID", product.ID, "interestRate", annualRate)
+	}
+
+	return uc.RefreshCatalog(ctx)
+}
+
+type rateCardKey struct {
+	tenorMonths int
+	assetType   entity.AssetType
+}
+
+// openRateCards creates a card at the product's interest rate and admin fee
+// for every tenor and asset type of the product that has never had one.
+// Pricing changes after that are made with new rate card versions, either
+// through the rate card endpoints or by repricing the product.
+func (uc *productUseCase) openRateCards(ctx context.Context, product *entity.Product) error {
+	cards, err := uc.rateCardRepo.GetByProductID(ctx, product.ID)
+	if err != nil {
+		return err
+	}
+
+	priced := make(map[rateCardKey]bool, len(cards))
+	for _, card := range cards {
+		priced[rateCardKey{card.TenorMonths, card.AssetType}] = true
+	}
+
+	now := time.Now()
+	for _, tenor := range product.AllowedTenors {
+		for _, assetType := range product.EligibleAssetTypes {
+			if priced[rateCardKey{tenor, assetType}] {
+				continue
+			}
+			card := &entity.RateCard{
+				ProductID:     product.ID,
+				TenorMonths:   tenor,
+				AssetType:     assetType,
+				InterestRate:  product.InterestRate,
+				AdminFeeType:  product.AdminFeeType,
+				AdminFeeValue: product.AdminFeeValue,
+				EffectiveFrom: now,
+			}
+			if err := uc.rateCardRepo.CreateVersion(ctx, card); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// repriceRateCards gives every tenor and asset type of the product a card at
+// its interest rate and admin fee, adding a new version wherever the card in
+// force charges something else or there is none.
+func (uc *productUseCase) repriceRateCards(ctx context.Context, product *entity.Product) error {
+	now := time.Now()
+	for _, tenor := range product.AllowedTenors {
+		for _, assetType := range product.EligibleAssetTypes {
+			current, err := uc.rateCardRepo.GetEffective(ctx, product.ID, tenor, assetType, now)
+			if err != nil {
+				return err
+			}
+			if current != nil && current.InterestRate == product.InterestRate &&
+				current.AdminFeeType == product.AdminFeeType && current.AdminFeeValue == product.AdminFeeValue {
+				continue
+			}
+			card := &entity.RateCard{
+				ProductID:     product.ID,
+				TenorMonths:   tenor,
+				AssetType:     assetType,
+				InterestRate:  product.InterestRate,
+				AdminFeeType:  product.AdminFeeType,
+				AdminFeeValue: product.AdminFeeValue,
+				EffectiveFrom: now,
+			}
+			if err := uc.rateCardRepo.CreateVersion(ctx, card); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// RefreshCatalog reloads the tenors offered by active products.
+func (uc *productUseCase) RefreshCatalog(ctx context.Context) error {
+	products, err := uc.productRepo.GetActive(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load product catalog: %w", err)
+	}
+
+	seen := make(map[int]bool)
+	var tenors []int
+	for _, product := range products {
+		for _, tenor := range product.AllowedTenors {
+			if !seen[tenor] {
+				seen[tenor] = true
+				tenors = append(tenors, tenor)
+			}
+		}
+	}
+	sort.Ints(tenors)
+
+	uc.mu.Lock()
+	uc.offeredTenors = tenors
+	uc.mu.Unlock()
+
+	logger.Info("Product catalog loaded", "products", len(products), "tenors", tenors)
+	return nil
+}
+
+// OfferedTenors returns every tenor offered by at least one active product.
+func (uc *productUseCase) OfferedTenors() []int {
+	uc.mu.RLock()
+	defer uc.mu.RUnlock()
+	return uc.offeredTenors
+}
+
+func checkProductEligibility(product *entity.Product, tenorMonths int, assetType entity.AssetType, otrAmount float64) error {
+	if !product.IsActive {
+		return fmt.Errorf("product %s is not active", product.Code)
+	}
+	if !product.AllowsTenor(tenorMonths) {
+		return fmt.Errorf("product %s does not offer a %d month tenor, allowed: %v", product.Code, tenorMonths, product.AllowedTenors)
+	}
+	if !product.AllowsAssetType(assetType) {
+		return fmt.Errorf("product %s does not finance %s, eligible: %v", product.Code, assetType, product.EligibleAssetTypes)
+	}
+	if !product.AllowsOTR(otrAmount) {
+		if product.MaxOTR > 0 {
+			return fmt.Errorf("OTR %.2f is outside the range %.2f - %.2f of product %s", otrAmount, product.MinOTR, product.MaxOTR, product.Code)
+		}
+		return fmt.Errorf("OTR %.2f is below the minimum %.2f of product %s", otrAmount, product.MinOTR, product.Code)
+	}
+	return nil
+}
+
+func validateProduct(product *entity.Product) error {
+	product.Code = strings.ToUpper(strings.TrimSpace(product.Code))
+	if product.Code == "" {
+		return fmt.Errorf("product code is required")
+	}
+
+	if len(product.AllowedTenors) == 0 {
+		return fmt.Errorf("at least one tenor is required")
+	}
+	seen := make(map[int]bool)
+	for _, tenor := range product.AllowedTenors {
+		if tenor <= 0 {
+			return fmt.Errorf("invalid tenor %d months", tenor)
+		}
+		if seen[tenor] {
+			return fmt.Errorf("duplicate tenor %d months", tenor)
+		}
+		seen[tenor] = true
+	}
+	sort.Ints(product.AllowedTenors)
+
+	if product.InterestRate < 0 {
+		return fmt.Errorf("interest rate cannot be negative")
+	}
+
+	if err := validateAdminFee(product.AdminFeeType, product.AdminFeeValue); err != nil {
+		return err
+	}
+
+	if product.MinOTR < 0 || (product.MaxOTR > 0 && product.MaxOTR < product.MinOTR) {
+		return fmt.Errorf("invalid OTR range %.2f - %.2f", product.MinOTR, product.MaxOTR)
+	}
+
+	if product.MinAge < 0 || product.MaxAgeAtTenorEnd < 0 {
+		return fmt.Errorf("age limits cannot be negative")
+	}
+	if product.MaxAgeAtTenorEnd > 0 && product.MaxAgeAtTenorEnd <= product.MinAge {
+		return fmt.Errorf("maximum age at tenor end %d must be greater than the minimum age %d", product.MaxAgeAtTenorEnd, product.MinAge)
+	}
+
+	if len(product.EligibleAssetTypes) == 0 {
+		return fmt.Errorf("at least one eligible asset type is required")
+	}
+	for _, assetType := range product.EligibleAssetTypes {
+		switch assetType {
+		case entity.AssetWhiteGoods, entity.AssetMotor, entity.AssetMobil:
+		default:
+			return fmt.Errorf("invalid asset type: %s", assetType)
+		}
+	}
+
+	return nil
+}
+
+func validateAdminFee(feeType entity.AdminFeeType, value float64) error {
+	switch feeType {
+	case entity.AdminFeeFlat, entity.AdminFeePercentage:
+	default:
+		return fmt.Errorf("unsupported admin fee type: %s", feeType)
+	}
+	if value < 0 {
+		return fmt.Errorf("admin fee value cannot be negative")
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"pt-xyz-multifinance/internal/domain/entity"
+	"pt-xyz-multifinance/internal/domain/repository"
+	"pt-xyz-multifinance/pkg/logger"
+	"time"
+)
+
+// ErrNoEffectiveRateCard refuses to price a tenor and asset type that has no
+// rate card in force.
+var ErrNoEffectiveRateCard = errors.New("no rate card is in effect")
+
+type RateCardUseCase interface {
+	CreateRateCard(ctx context.Context, card *entity.RateCard) error
+	GetRateCardByID(ctx context.Context, id uint64) (*entity.RateCard, error)
+	GetRateCardsByProductID(ctx context.Context, productID uint64) ([]*entity.RateCard, error)
+	GetEffectiveRateCard(ctx context.Context, productID uint64, tenorMonths int, assetType entity.AssetType, at time.Time) (*entity.RateCard, error)
+}
+
+type rateCardUseCase struct {
+	rateCardRepo repository.RateCardRepository
+	productRepo  repository.ProductRepository
+}
+
+func NewRateCardUseCase(rateCardRepo repository.RateCardRepository, productRepo repository.ProductRepository) RateCardUseCase {
+	return &rateCardUseCase{
+		rateCardRepo: rateCardRepo,
+		productRepo:  productRepo,
+	}
+}
+
+// CreateRateCard publishes a new price for a product, tenor and asset type.
+// Cards may not take effect in the past, so contracts already created keep
+// the card they were priced with.
+func (uc *rateCardUseCase) CreateRateCard(ctx context.Context, card *entity.RateCard) error {
+	product, err := uc.productRepo.GetByID(ctx, card.ProductID)
+	if err != nil {
+		return fmt.Errorf("product not found: %w", err)
+	}
+
+	if !product.AllowsTenor(card.TenorMonths) {
+		return fmt.Errorf("product %s does not offer a %d month tenor", product.Code, card.TenorMonths)
+	}
+	if !product.AllowsAssetType(card.AssetType) {
+		return fmt.Errorf("product %s does not finance %s", product.Code, card.AssetType)
+	}
+	if card.InterestRate < 0 {
+		return fmt.Errorf("interest rate cannot be negative")
+	}
+	if err := validateAdminFee(card.AdminFeeType, card.AdminFeeValue); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if card.EffectiveFrom.IsZero() {
+		card.EffectiveFrom = now
+	} else if card.EffectiveFrom.Before(now) {
+		return fmt.Errorf("rate cards cannot take effect in the past")
+	}
+	if card.EffectiveTo != nil && !card.EffectiveTo.After(card.EffectiveFrom) {
+		return fmt.Errorf("effective_to must be after effective_from")
+	}
+
+	if err := uc.rateCardRepo.CreateVersion(ctx, card); err != nil {
+		logger.Error("Failed to create rate card", "productID", card.ProductID, "tenorMonths", card.TenorMonths, "error", err)
+		return err
+	}
+
+	logger.Info("Rate card created",
+		"rateCardID", card.ID,
+		"productID", card.ProductID,
+		"tenorMonths", card.TenorMonths,
+		"assetType", card.AssetType,
+		"interestRate", card.InterestRate,
+		"effectiveFrom", card.EffectiveFrom)
+
+	return nil
+}
+
+func (uc *rateCardUseCase) GetRateCardByID(ctx context.Context, id uint64) (*entity.RateCard, error) {
+	return uc.rateCardRepo.GetByID(ctx, id)
+}
+
+func (uc *rateCardUseCase) GetRateCardsByProductID(ctx context.Context, productID uint64) ([]*entity.RateCard, error) {
+	return uc.rateCardRepo.GetByProductID(ctx, productID)
+}
+
+// GetEffectiveRateCard returns the card in force at the given time, or nil
+// when there is none.
+func (uc *rateCardUseCase) GetEffectiveRateCard(ctx context.Context, productID uint64, tenorMonths int, assetType entity.AssetType, at time.Time) (*entity.RateCard, error) {
+	return uc.rateCardRepo.GetEffective(ctx, productID, tenorMonths, assetType, at)
+}
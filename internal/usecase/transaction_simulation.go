@@ -0,0 +1,138 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"pt-xyz-multifinance/internal/domain/entity"
+	"pt-xyz-multifinance/internal/domain/service"
+	"time"
+)
+
+// SimulationRequest describes a purchase to be quoted before it is applied for.
+type SimulationRequest struct {
+	CustomerID        uint64
+	ProductID         uint64 // optional, 0 quotes every eligible product
+	OTRAmount         float64
+	DownPaymentAmount float64
+	AssetType         entity.AssetType
+	TransactionSource entity.TransactionSource
+}
+
+// SimulationOption is the price of one product and tenor, priced exactly as
+// CreateTransaction would price it at this moment.
+type SimulationOption struct {
+	ProductID         uint64
+	ProductCode       string
+	TenorMonths       int
+	RateCardID        *uint64
+	InterestMethod    entity.InterestMethod
+	InterestRate      float64
+	DownPaymentAmount float64
+	FinancedAmount    float64
+	AdminFee          float64
+	InterestAmount    float64
+	InstallmentAmount float64
+	TotalPayable      float64 // financed amount + interest + admin fee
+	AvailableLimit    float64
+	LimitSufficient   bool
+	DebtToIncomeRatio float64 // with this installment added to those of open contracts, see existingInstallments
+	Affordable        bool
+}
+
+// SimulateTransaction quotes every eligible tenor for a purchase without
+// creating a contract or touching the customer's limits. Tenors the
+// customer's age rules out are not quoted.
+func (uc *transactionUseCase) SimulateTransaction(ctx context.Context, req *SimulationRequest) ([]*SimulationOption, error) {
+	if req.OTRAmount <= 0 {
+		return nil, fmt.Errorf("OTR amount must be greater than 0")
+	}
+
+	customer, err := uc.customerRepo.GetByID(ctx, req.CustomerID)
+	if err != nil {
+		return nil, fmt.Errorf("customer not found: %w", err)
+	}
+
+	products, err := uc.productUseCase.EligibleProducts(ctx, req.ProductID, req.AssetType, req.OTRAmount)
+	if err != nil {
+		return nil, err
+	}
+	if len(products) == 0 {
+		return nil, fmt.Errorf("no active product finances %s with OTR %.2f", req.AssetType, req.OTRAmount)
+	}
+
+	limits, err := uc.limitRepo.GetByCustomerID(ctx, req.CustomerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get customer limits: %w", err)
+	}
+	available := make(map[int]float64, len(limits))
+	for _, limit := range limits {
+		available[limit.TenorMonths] = limit.AvailableAmount()
+	}
+
+	transactions, err := uc.transactionRepo.GetByCustomerID(ctx, req.CustomerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get customer transactions: %w", err)
+	}
+	existing := existingInstallments(transactions)
+
+	now := time.Now()
+	options := make([]*SimulationOption, 0)
+	for _, product := range products {
+		for _, tenor := range product.AllowedTenors {
+			if service.CheckAgeEligibility(product, customer.BirthDate, now, tenor) != nil {
+				continue
+			}
+
+			quote := &entity.Transaction{
+				CustomerID:        req.CustomerID,
+				ProductID:         product.ID,
+				TenorMonths:       tenor,
+				OTRAmount:         req.OTRAmount,
+				DownPaymentAmount: req.DownPaymentAmount,
+				AssetType:         req.AssetType,
+				TransactionSource: req.TransactionSource,
+			}
+
+			if err := uc.downPayment.apply(quote); err != nil {
+				return nil, err
+			}
+
+			// A tenor without a rate card in force cannot be offered yet
+			annualRate, err := uc.priceTransaction(ctx, quote, product)
+			if errors.Is(err, ErrNoEffectiveRateCard) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			if err := uc.applyInterest(quote, annualRate); err != nil {
+				return nil, err
+			}
+
+			ratio, unaffordable := uc.affordability.check(customer.Salary, existing, quote.InstallmentAmount)
+
+			options = append(options, &SimulationOption{
+				ProductID:         product.ID,
+				ProductCode:       product.Code,
+				TenorMonths:       tenor,
+				RateCardID:        quote.RateCardID,
+				InterestMethod:    quote.InterestMethod,
+				InterestRate:      quote.InterestRate,
+				DownPaymentAmount: quote.DownPaymentAmount,
+				FinancedAmount:    quote.FinancedAmount,
+				AdminFee:          quote.AdminFee,
+				InterestAmount:    quote.InterestAmount,
+				InstallmentAmount: quote.InstallmentAmount,
+				TotalPayable:      roundAmount(quote.FinancedAmount + quote.InterestAmount + quote.AdminFee),
+				AvailableLimit:    available[tenor],
+				LimitSufficient:   quote.FinancedAmount <= available[tenor],
+				DebtToIncomeRatio: ratio,
+				Affordable:        unaffordable == nil,
+			})
+		}
+	}
+
+	return options, nil
+}
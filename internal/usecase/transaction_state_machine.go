@@ -0,0 +1,164 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"pt-xyz-multifinance/internal/domain/entity"
+	"pt-xyz-multifinance/internal/domain/repository"
+	"pt-xyz-multifinance/pkg/logger"
+	"time"
+)
+
+// TransitionHook runs when a transaction enters a status. The transaction
+// already carries the new status; from is the status it is leaving.
+type TransitionHook func(ctx context.Context, transaction *entity.Transaction, from entity.TransactionStatus, reason string) error
+
+// TransactionStateMachine enforces the transaction status transition table and
+// runs side effects for every transition. Callers persist the transaction.
+type TransactionStateMachine interface {
+	Transition(ctx context.Context, transaction *entity.Transaction, next entity.TransactionStatus, reason string) error
+	RegisterHook(status entity.TransactionStatus, hook TransitionHook)
+}
+
+type transactionStateMachine struct {
+	limitRepo   repository.LimitRepository
+	limitPolicy LimitPolicy
+	contracts   ContractDocumentUseCase
+	hooks       map[entity.TransactionStatus][]TransitionHook
+}
+
+func NewTransactionStateMachine(limitRepo repository.LimitRepository, limitPolicy LimitPolicy, contracts ContractDocumentUseCase) TransactionStateMachine {
+	sm := &transactionStateMachine{
+		limitRepo:   limitRepo,
+		limitPolicy: limitPolicy,
+		contracts:   contracts,
+		hooks:       make(map[entity.TransactionStatus][]TransitionHook),
+	}
+
+	sm.RegisterHook(entity.StatusApproved, stampTransition(func(t *entity.Transaction, now *time.Time) { t.ApprovedAt = now }))
+	sm.RegisterHook(entity.StatusApproved, sm.issueContract)
+	sm.RegisterHook(entity.StatusRejected, stampTransition(func(t *entity.Transaction, now *time.Time) { t.RejectedAt = now }))
+	sm.RegisterHook(entity.StatusActive, stampTransition(func(t *entity.Transaction, now *time.Time) { t.ActivatedAt = now }))
+	sm.RegisterHook(entity.StatusCompleted, stampTransition(func(t *entity.Transaction, now *time.Time) { t.CompletedAt = now }))
+	sm.RegisterHook(entity.StatusDefaulted, stampTransition(func(t *entity.Transaction, now *time.Time) { t.DefaultedAt = now }))
+	sm.RegisterHook(entity.StatusRejected, sm.releaseLimit)
+	sm.RegisterHook(entity.StatusCompleted, sm.restoreRemainingLimit)
+
+	return sm
+}
+
+func (sm *transactionStateMachine) RegisterHook(status entity.TransactionStatus, hook TransitionHook) {
+	sm.hooks[status] = append(sm.hooks[status], hook)
+}
+
+func (sm *transactionStateMachine) Transition(ctx context.Context, transaction *entity.Transaction, next entity.TransactionStatus, reason string) error {
+	if !next.IsValid() {
+		return fmt.Errorf("invalid transaction status: %s", next)
+	}
+
+	from := transaction.Status
+	if !from.CanTransitionTo(next) {
+		return &entity.InvalidStatusTransitionError{
+			From:    from,
+			To:      next,
+			Allowed: from.AllowedTransitions(),
+		}
+	}
+
+	// Hooks may fail after earlier ones stamped the transaction; it is then
+	// put back exactly as it was
+	previous := transitionFields{
+		status:        from,
+		statusReason:  transaction.StatusReason,
+		approvedAt:    transaction.ApprovedAt,
+		rejectedAt:    transaction.RejectedAt,
+		activatedAt:   transaction.ActivatedAt,
+		completedAt:   transaction.CompletedAt,
+		defaultedAt:   transaction.DefaultedAt,
+		limitRestored: transaction.LimitRestored,
+	}
+
+	transaction.Status = next
+	transaction.StatusReason = reason
+
+	for _, hook := range sm.hooks[next] {
+		if err := hook(ctx, transaction, from, reason); err != nil {
+			previous.restore(transaction)
+			return err
+		}
+	}
+
+	logger.Info("Transaction status changed",
+		"transactionID", transaction.ID,
+		"contractNumber", transaction.ContractNumber,
+		"oldStatus", from,
+		"newStatus", next,
+		"reason", reason)
+
+	return nil
+}
+
+// issueContract generates the contract document on approval, whichever path
+// approved the transaction. The approval stands even if rendering fails; the
+// download retries it.
+func (sm *transactionStateMachine) issueContract(ctx context.Context, transaction *entity.Transaction, from entity.TransactionStatus, reason string) error {
+	if _, err := sm.contracts.IssueContract(ctx, transaction); err != nil {
+		logger.Error("Failed to generate contract document", "transactionID", transaction.ID, "error", err)
+	}
+	return nil
+}
+
+// releaseLimit gives back the limit reserved when the contract was created.
+func (sm *transactionStateMachine) releaseLimit(ctx context.Context, transaction *entity.Transaction, from entity.TransactionStatus, reason string) error {
+	transactionID := transaction.ID
+	movement := &entity.LimitMovement{
+		CustomerID:    transaction.CustomerID,
+		TenorMonths:   transaction.TenorMonths,
+		Type:          entity.MovementRelease,
+		Amount:        -transaction.FinancedPrincipal(),
+		TransactionID: &transactionID,
+		Reason:        reason,
+	}
+	if err := sm.limitRepo.UpdateUsedAmount(ctx, movement); err != nil {
+		return fmt.Errorf("failed to release used amount: %w", err)
+	}
+	return nil
+}
+
+// restoreRemainingLimit gives back whatever part of the limit was not already
+// restored by principal repayments, for revolving and non-revolving tenors alike.
+func (sm *transactionStateMachine) restoreRemainingLimit(ctx context.Context, transaction *entity.Transaction, from entity.TransactionStatus, reason string) error {
+	_, err := restoreLimit(ctx, sm.limitRepo, sm.limitPolicy, transaction, transaction.FinancedPrincipal(), true, nil, "contract completed")
+	return err
+}
+
+// transitionFields holds what transitions and their hooks change on a transaction.
+type transitionFields struct {
+	status        entity.TransactionStatus
+	statusReason  string
+	approvedAt    *time.Time
+	rejectedAt    *time.Time
+	activatedAt   *time.Time
+	completedAt   *time.Time
+	defaultedAt   *time.Time
+	limitRestored float64
+}
+
+func (f transitionFields) restore(transaction *entity.Transaction) {
+	transaction.Status = f.status
+	transaction.StatusReason = f.statusReason
+	transaction.ApprovedAt = f.approvedAt
+	transaction.RejectedAt = f.rejectedAt
+	transaction.ActivatedAt = f.activatedAt
+	transaction.CompletedAt = f.completedAt
+	transaction.DefaultedAt = f.defaultedAt
+	transaction.LimitRestored = f.limitRestored
+}
+
+func stampTransition(set func(transaction *entity.Transaction, now *time.Time)) TransitionHook {
+	return func(ctx context.Context, transaction *entity.Transaction, from entity.TransactionStatus, reason string) error {
+		now := time.Now()
+		set(transaction, &now)
+		return nil
+	}
+}
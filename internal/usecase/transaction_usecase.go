@@ -2,10 +2,13 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"pt-xyz-multifinance/internal/domain/entity"
 	"pt-xyz-multifinance/internal/domain/repository"
+	"pt-xyz-multifinance/internal/domain/service"
+	"pt-xyz-multifinance/internal/infrastructure/database"
 	"pt-xyz-multifinance/pkg/logger"
 	"pt-xyz-multifinance/pkg/utils"
 	"time"
@@ -13,22 +16,47 @@ import (
 	"gorm.io/gorm"
 )
 
+// ErrTransactionNotFound is returned when a status change names no contract.
+var ErrTransactionNotFound = errors.New("transaction not found")
+
+// ErrTransactionOnHold refuses an approval while a watchlist hit on the
+// contract has not been cleared.
+var ErrTransactionOnHold = errors.New("transaction is held by a watchlist hit that has not been cleared")
+
 type TransactionUseCase interface {
 	CreateTransaction(ctx context.Context, transaction *entity.Transaction) error
+	SimulateTransaction(ctx context.Context, req *SimulationRequest) ([]*SimulationOption, error)
 	GetTransactionByID(ctx context.Context, id uint64) (*entity.Transaction, error)
 	GetTransactionByContractNumber(ctx context.Context, contractNumber string) (*entity.Transaction, error)
 	GetTransactionsByCustomerID(ctx context.Context, customerID uint64) ([]*entity.Transaction, error)
-	UpdateTransactionStatus(ctx context.Context, id uint64, status entity.TransactionStatus) error
+	UpdateTransactionStatus(ctx context.Context, id uint64, status entity.TransactionStatus, reason string) error
 	GetAllTransactions(ctx context.Context, limit, offset int) ([]*entity.Transaction, error)
-	ValidateTransactionLimit(ctx context.Context, customerID uint64, tenorMonths int, amount float64) error
+	ValidateTransactionLimit(ctx context.Context, customerID uint64, tenorMonths int, financedAmount float64) error
 	ApproveTransaction(ctx context.Context, id uint64) error
 	RejectTransaction(ctx context.Context, id uint64, reason string) error
 }
 
+// InterestPolicy selects how interest is charged on new contracts. The rate
+// itself comes from the product the contract is financed under.
+type InterestPolicy struct {
+	Method entity.InterestMethod
+}
+
 type transactionUseCase struct {
 	transactionRepo repository.TransactionRepository
 	customerRepo    repository.CustomerRepository
 	limitRepo       repository.LimitRepository
+	scheduleUseCase InstallmentScheduleUseCase
+	stateMachine    TransactionStateMachine
+	contractNumbers ContractNumberGenerator
+	productUseCase  ProductUseCase
+	rateCardUseCase RateCardUseCase
+	adminFeeUseCase AdminFeeRuleUseCase
+	watchlist       WatchlistUseCase
+	interestPolicy  InterestPolicy
+	adminFeePolicy  AdminFeePolicy
+	downPayment     DownPaymentPolicy
+	affordability   AffordabilityPolicy
 	db              *gorm.DB
 }
 
@@ -36,12 +64,34 @@ func NewTransactionUseCase(
 	transactionRepo repository.TransactionRepository,
 	customerRepo repository.CustomerRepository,
 	limitRepo repository.LimitRepository,
+	scheduleUseCase InstallmentScheduleUseCase,
+	stateMachine TransactionStateMachine,
+	contractNumbers ContractNumberGenerator,
+	productUseCase ProductUseCase,
+	rateCardUseCase RateCardUseCase,
+	adminFeeUseCase AdminFeeRuleUseCase,
+	watchlist WatchlistUseCase,
+	interestPolicy InterestPolicy,
+	adminFeePolicy AdminFeePolicy,
+	downPayment DownPaymentPolicy,
+	affordability AffordabilityPolicy,
 	db *gorm.DB,
 ) TransactionUseCase {
 	return &transactionUseCase{
 		transactionRepo: transactionRepo,
 		customerRepo:    customerRepo,
 		limitRepo:       limitRepo,
+		scheduleUseCase: scheduleUseCase,
+		stateMachine:    stateMachine,
+		contractNumbers: contractNumbers,
+		productUseCase:  productUseCase,
+		rateCardUseCase: rateCardUseCase,
+		adminFeeUseCase: adminFeeUseCase,
+		watchlist:       watchlist,
+		interestPolicy:  interestPolicy,
+		adminFeePolicy:  adminFeePolicy,
+		downPayment:     downPayment,
+		affordability:   affordability,
 		db:              db,
 	}
 }
@@ -51,26 +101,62 @@ func (uc *transactionUseCase) CreateTransaction(ctx context.Context, transaction
 		return err
 	}
 
+	customer, err := uc.customerRepo.GetByID(ctx, transaction.CustomerID)
+	if err != nil {
+		return fmt.Errorf("customer not found: %w", err)
+	}
+	if !customer.IsKYCVerified() {
+		return fmt.Errorf("customer KYC is %s, only %s customers can create transactions", customer.KYCStatus, entity.KYCVerified)
+	}
+
+	// Screened before the transaction opens, so the hits of a blocked
+	// application are not rolled back with it
+	watchlistHits, err := uc.watchlist.Screen(ctx, entity.ScreeningTransaction, customer.NIK, &customer.ID)
+	if err != nil {
+		return err
+	}
+
 	return uc.db.Transaction(func(tx *gorm.DB) error {
+		ctx := database.WithTx(ctx, tx)
+		product, err := uc.productUseCase.ResolveProduct(ctx, transaction.ProductID, transaction.TenorMonths, transaction.AssetType, transaction.OTRAmount)
+		if err != nil {
+			return err
+		}
+		transaction.ProductID = product.ID
+
+		if err := service.CheckAgeEligibility(product, customer.BirthDate, time.Now(), transaction.TenorMonths); err != nil {
+			return err
+		}
+
+		if err := uc.downPayment.apply(transaction); err != nil {
+			return err
+		}
+
+		if err := uc.ValidateTransactionLimit(ctx, transaction.CustomerID, transaction.TenorMonths, transaction.FinancedAmount); err != nil {
+			return err
+		}
 
-		_, err := uc.customerRepo.GetByID(ctx, transaction.CustomerID)
+		annualRate, err := uc.priceTransaction(ctx, transaction, product)
 		if err != nil {
-			return fmt.Errorf("customer not found: %w", err)
+			return err
+		}
+
+		if err := uc.applyInterest(transaction, annualRate); err != nil {
+			return err
 		}
 
-		if err := uc.ValidateTransactionLimit(ctx, transaction.CustomerID, transaction.TenorMonths, transaction.OTRAmount); err != nil {
+		if err := uc.checkAffordability(ctx, customer, transaction); err != nil {
 			return err
 		}
 
-		transaction.ContractNumber = uc.generateContractNumber()
+		contractNumber, err := uc.contractNumbers.Next(ctx)
+		if err != nil {
+			return err
+		}
+		transaction.ContractNumber = contractNumber
 
-		if transaction.InstallmentAmount == 0 {
-			transaction.InstallmentAmount = uc.calculateInstallmentAmount(
-				transaction.OTRAmount,
-				transaction.AdminFee,
-				transaction.InterestAmount,
-				transaction.TenorMonths,
-			)
+		if len(watchlistHits) > 0 {
+			transaction.StatusReason = "held for watchlist review"
 		}
 
 		if err := uc.transactionRepo.Create(ctx, transaction); err != nil {
@@ -78,7 +164,24 @@ func (uc *transactionUseCase) CreateTransaction(ctx context.Context, transaction
 			return fmt.Errorf("failed to create transaction: %w", err)
 		}
 
-		if err := uc.limitRepo.UpdateUsedAmount(ctx, transaction.CustomerID, transaction.TenorMonths, transaction.OTRAmount); err != nil {
+		transactionID := transaction.ID
+		if err := uc.watchlist.RecordHits(ctx, watchlistHits, customer.ID, &transactionID); err != nil {
+			return err
+		}
+
+		if _, err := uc.scheduleUseCase.GenerateSchedule(ctx, transaction); err != nil {
+			return err
+		}
+
+		reservation := &entity.LimitMovement{
+			CustomerID:    transaction.CustomerID,
+			TenorMonths:   transaction.TenorMonths,
+			Type:          entity.MovementReserve,
+			Amount:        transaction.FinancedAmount,
+			TransactionID: &transactionID,
+			Reason:        fmt.Sprintf("contract %s created", transaction.ContractNumber),
+		}
+		if err := uc.limitRepo.UpdateUsedAmount(ctx, reservation); err != nil {
 			logger.Error("Failed to update used amount", "error", err)
 			return fmt.Errorf("failed to update used amount: %w", err)
 		}
@@ -89,97 +192,137 @@ func (uc *transactionUseCase) CreateTransaction(ctx context.Context, transaction
 }
 
 func (uc *transactionUseCase) ApproveTransaction(ctx context.Context, id uint64) error {
+	return uc.UpdateTransactionStatus(ctx, id, entity.StatusApproved, "")
+}
+
+func (uc *transactionUseCase) RejectTransaction(ctx context.Context, id uint64, reason string) error {
+	return uc.UpdateTransactionStatus(ctx, id, entity.StatusRejected, reason)
+}
+
+func (uc *transactionUseCase) UpdateTransactionStatus(ctx context.Context, id uint64, status entity.TransactionStatus, reason string) error {
 	return uc.db.Transaction(func(tx *gorm.DB) error {
-		transaction, err := uc.transactionRepo.GetByID(ctx, id)
+		ctx := database.WithTx(ctx, tx)
+		transaction, err := uc.transactionRepo.GetByIDForUpdate(ctx, id)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrTransactionNotFound
+		}
 		if err != nil {
-			return fmt.Errorf("transaction not found: %w", err)
+			return err
 		}
 
-		if transaction.Status != entity.StatusPending {
-			return fmt.Errorf("transaction cannot be approved, current status: %s", transaction.Status)
+		if status == entity.StatusApproved {
+			onHold, err := uc.watchlist.TransactionOnHold(ctx, transaction.ID)
+			if err != nil {
+				return err
+			}
+			if onHold {
+				return ErrTransactionOnHold
+			}
+		}
+
+		if err := uc.stateMachine.Transition(ctx, transaction, status, reason); err != nil {
+			return err
 		}
 
-		oldStatus := transaction.Status
-		transaction.Status = entity.StatusApproved
 		if err := uc.transactionRepo.Update(ctx, transaction); err != nil {
 			return fmt.Errorf("failed to update transaction status: %w", err)
 		}
 
-		logger.Info("Transaction approved",
-			"transactionID", id,
-			"oldStatus", oldStatus,
-			"newStatus", transaction.Status,
-			"contractNumber", transaction.ContractNumber)
-
 		return nil
 	})
 }
 
-func (uc *transactionUseCase) RejectTransaction(ctx context.Context, id uint64, reason string) error {
-	return uc.db.Transaction(func(tx *gorm.DB) error {
-		transaction, err := uc.transactionRepo.GetByID(ctx, id)
-		if err != nil {
-			return fmt.Errorf("transaction not found: %w", err)
-		}
-
-		if transaction.Status != entity.StatusPending {
-			return fmt.Errorf("transaction cannot be rejected, current status: %s", transaction.Status)
-		}
+// ValidateTransactionLimit checks the financed amount, not the OTR, against
+// the available limit of the tenor.
+func (uc *transactionUseCase) ValidateTransactionLimit(ctx context.Context, customerID uint64, tenorMonths int, financedAmount float64) error {
+	limit, err := uc.limitRepo.GetByCustomerAndTenor(ctx, customerID, tenorMonths)
+	if err != nil {
+		return fmt.Errorf("customer limit not found for tenor %d months", tenorMonths)
+	}
 
-		oldStatus := transaction.Status
-		transaction.Status = entity.StatusRejected
-		if err := uc.transactionRepo.Update(ctx, transaction); err != nil {
-			return fmt.Errorf("failed to update transaction status: %w", err)
-		}
+	availableAmount := limit.AvailableAmount()
+	if financedAmount > availableAmount {
+		return fmt.Errorf("transaction amount exceeds available limit. Available: %.2f, Requested: %.2f",
+			availableAmount, financedAmount)
+	}
 
-		rollbackAmount := -transaction.OTRAmount
-		if err := uc.limitRepo.UpdateUsedAmount(ctx, transaction.CustomerID, transaction.TenorMonths, rollbackAmount); err != nil {
-			return fmt.Errorf("failed to rollback used amount: %w", err)
-		}
+	return nil
+}
 
-		logger.Info("Transaction rejected with limit rollback",
-			"transactionID", id,
-			"oldStatus", oldStatus,
-			"newStatus", transaction.Status,
-			"reason", reason,
-			"rollbackAmount", transaction.OTRAmount)
+// checkAffordability stores the customer's debt-to-income ratio with the new
+// contract on it and rejects the contract when the ratio is over the cap.
+func (uc *transactionUseCase) checkAffordability(ctx context.Context, customer *entity.Customer, transaction *entity.Transaction) error {
+	transactions, err := uc.transactionRepo.GetByCustomerID(ctx, customer.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get customer transactions: %w", err)
+	}
 
-		return nil
-	})
+	ratio, err := uc.affordability.check(customer.Salary, existingInstallments(transactions), transaction.InstallmentAmount)
+	transaction.DebtToIncomeRatio = ratio
+	if err != nil {
+		logger.Info("Transaction refused as unaffordable", "customerID", customer.ID, "ratio", ratio)
+	}
+	return err
 }
 
-func (uc *transactionUseCase) UpdateTransactionStatus(ctx context.Context, id uint64, status entity.TransactionStatus) error {
-	transaction, err := uc.transactionRepo.GetByID(ctx, id)
+// priceTransaction sets the admin fee of a new contract and returns its annual
+// rate. Both come from the rate card in force now, which is then referenced
+// from the contract; admin fee rules override the fee. A client-supplied fee
+// never decides the price.
+func (uc *transactionUseCase) priceTransaction(ctx context.Context, transaction *entity.Transaction, product *entity.Product) (float64, error) {
+	card, err := uc.rateCardUseCase.GetEffectiveRateCard(ctx, product.ID, transaction.TenorMonths, transaction.AssetType, time.Now())
 	if err != nil {
-		return err
+		return 0, err
 	}
 
-	transaction.Status = status
-	return uc.transactionRepo.Update(ctx, transaction)
-}
+	if card == nil {
+		return 0, fmt.Errorf("%w for product %s, %d months, %s", ErrNoEffectiveRateCard, product.Code, transaction.TenorMonths, transaction.AssetType)
+	}
+	rateCardID := card.ID
+	transaction.RateCardID = &rateCardID
+	annualRate := card.InterestRate
 
-func (uc *transactionUseCase) ValidateTransactionLimit(ctx context.Context, customerID uint64, tenorMonths int, amount float64) error {
-	limit, err := uc.limitRepo.GetByCustomerAndTenor(ctx, customerID, tenorMonths)
+	quote, err := uc.adminFeeUseCase.QuoteAdminFee(ctx, product, card, transaction.TransactionSource, transaction.OTRAmount)
 	if err != nil {
-		return fmt.Errorf("customer limit not found for tenor %d months", tenorMonths)
+		return 0, err
 	}
 
-	availableAmount := limit.AvailableAmount()
-	if amount > availableAmount {
-		return fmt.Errorf("transaction amount exceeds available limit. Available: %.2f, Requested: %.2f",
-			availableAmount, amount)
+	if transaction.ClientAdminFee != nil && math.Abs(*transaction.ClientAdminFee-quote.Amount) >= 0.005 {
+		if uc.adminFeePolicy.OnMismatch == AdminFeeMismatchReject {
+			return 0, &entity.AdminFeeMismatchError{Expected: quote.Amount, Provided: *transaction.ClientAdminFee}
+		}
+		logger.Info("Ignoring client admin fee", "customerID", transaction.CustomerID, "provided", *transaction.ClientAdminFee, "computed", quote.Amount)
 	}
 
-	return nil
-}
+	transaction.AdminFee = quote.Amount
+	transaction.AdminFeeRuleID = nil
+	if quote.Rule != nil {
+		ruleID := quote.Rule.ID
+		transaction.AdminFeeRuleID = &ruleID
+	}
 
-func (uc *transactionUseCase) generateContractNumber() string {
-	return fmt.Sprintf("XYZ%d", time.Now().Unix())
+	return annualRate, nil
 }
 
-func (uc *transactionUseCase) calculateInstallmentAmount(otr, adminFee, interest float64, tenor int) float64 {
-	totalAmount := otr + adminFee + interest
-	return math.Round((totalAmount/float64(tenor))*100) / 100
+// applyInterest computes the interest and monthly installment on the server and
+// records the method and rate used, ignoring any client-supplied amounts.
+func (uc *transactionUseCase) applyInterest(transaction *entity.Transaction, annualRate float64) error {
+	calculator, err := service.NewInterestCalculator(uc.interestPolicy.Method)
+	if err != nil {
+		return err
+	}
+
+	result, err := calculator.Calculate(transaction.FinancedAmount, annualRate, transaction.TenorMonths)
+	if err != nil {
+		return fmt.Errorf("failed to calculate interest: %w", err)
+	}
+
+	transaction.InterestMethod = calculator.Method()
+	transaction.InterestRate = annualRate
+	transaction.InterestAmount = result.TotalInterest
+	transaction.InstallmentAmount = roundAmount(result.InstallmentAmount + transaction.AdminFee/float64(transaction.TenorMonths))
+
+	return nil
 }
 
 func (uc *transactionUseCase) GetTransactionByID(ctx context.Context, id uint64) (*entity.Transaction, error) {
@@ -187,6 +330,9 @@ func (uc *transactionUseCase) GetTransactionByID(ctx context.Context, id uint64)
 }
 
 func (uc *transactionUseCase) GetTransactionByContractNumber(ctx context.Context, contractNumber string) (*entity.Transaction, error) {
+	if err := uc.contractNumbers.Validate(contractNumber); err != nil {
+		return nil, err
+	}
 	return uc.transactionRepo.GetByContractNumber(ctx, contractNumber)
 }
 
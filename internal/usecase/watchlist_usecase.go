@@ -0,0 +1,340 @@
+package usecase
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"pt-xyz-multifinance/internal/domain/entity"
+	"pt-xyz-multifinance/internal/domain/repository"
+	"pt-xyz-multifinance/internal/infrastructure/database"
+	"pt-xyz-multifinance/pkg/logger"
+	"pt-xyz-multifinance/pkg/utils"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrWatchlistBlocked refuses an application after a watchlist hit. It does
+// not say why; the hit itself is recorded for admins.
+var ErrWatchlistBlocked = errors.New("the application cannot be processed, please contact customer service")
+
+// WatchlistPolicy decides what a watchlist hit does: BLOCK refuses the
+// application, REVIEW lets it through but holds its approval until an admin
+// clears the hit.
+type WatchlistPolicy struct {
+	OnHit entity.WatchlistAction
+}
+
+// WatchlistImportResult counts the entries a CSV import created and updated.
+type WatchlistImportResult struct {
+	Created int
+	Updated int
+}
+
+type WatchlistUseCase interface {
+	CreateEntry(ctx context.Context, entry *entity.Watchlist) error
+	GetEntryByID(ctx context.Context, id uint64) (*entity.Watchlist, error)
+	GetEntries(ctx context.Context, limit, offset int) ([]*entity.Watchlist, error)
+	UpdateEntry(ctx context.Context, entry *entity.Watchlist) error
+	DeleteEntry(ctx context.Context, id uint64) error
+	ImportCSV(ctx context.Context, r io.Reader, adminID uint64) (*WatchlistImportResult, error)
+	Screen(ctx context.Context, stage entity.ScreeningStage, nik string, customerID *uint64) ([]*entity.WatchlistHit, error)
+	RecordHits(ctx context.Context, hits []*entity.WatchlistHit, customerID uint64, transactionID *uint64) error
+	GetHits(ctx context.Context, status entity.WatchlistHitStatus, limit, offset int) ([]*entity.WatchlistHit, error)
+	ResolveHit(ctx context.Context, id uint64, adminID uint64, status entity.WatchlistHitStatus, note string) (*entity.WatchlistHit, error)
+	CustomerOnHold(ctx context.Context, customerID uint64) (bool, error)
+	TransactionOnHold(ctx context.Context, transactionID uint64) (bool, error)
+}
+
+type watchlistUseCase struct {
+	watchlistRepo repository.WatchlistRepository
+	hitRepo       repository.WatchlistHitRepository
+	policy        WatchlistPolicy
+	db            *gorm.DB
+}
+
+func NewWatchlistUseCase(watchlistRepo repository.WatchlistRepository, hitRepo repository.WatchlistHitRepository, policy WatchlistPolicy, db *gorm.DB) WatchlistUseCase {
+	return &watchlistUseCase{
+		watchlistRepo: watchlistRepo,
+		hitRepo:       hitRepo,
+		policy:        policy,
+		db:            db,
+	}
+}
+
+func (uc *watchlistUseCase) CreateEntry(ctx context.Context, entry *entity.Watchlist) error {
+	if err := validateWatchlistEntry(entry); err != nil {
+		return err
+	}
+
+	if err := uc.watchlistRepo.Create(ctx, entry); err != nil {
+		logger.Error("Failed to create watchlist entry", "source", entry.Source, "error", err)
+		return err
+	}
+
+	logger.Info("Watchlist entry created", "entryID", entry.ID, "source", entry.Source)
+	return nil
+}
+
+func (uc *watchlistUseCase) GetEntryByID(ctx context.Context, id uint64) (*entity.Watchlist, error) {
+	return uc.watchlistRepo.GetByID(ctx, id)
+}
+
+func (uc *watchlistUseCase) GetEntries(ctx context.Context, limit, offset int) ([]*entity.Watchlist, error) {
+	return uc.watchlistRepo.GetAll(ctx, limit, offset)
+}
+
+func (uc *watchlistUseCase) UpdateEntry(ctx context.Context, entry *entity.Watchlist) error {
+	if err := validateWatchlistEntry(entry); err != nil {
+		return err
+	}
+
+	if err := uc.watchlistRepo.Update(ctx, entry); err != nil {
+		logger.Error("Failed to update watchlist entry", "entryID", entry.ID, "error", err)
+		return err
+	}
+
+	logger.Info("Watchlist entry updated", "entryID", entry.ID, "source", entry.Source)
+	return nil
+}
+
+func (uc *watchlistUseCase) DeleteEntry(ctx context.Context, id uint64) error {
+	if err := uc.watchlistRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	logger.Info("Watchlist entry deleted", "entryID", id)
+	return nil
+}
+
+// ImportCSV loads nik,name,reason,source,expires_at rows; a header row is
+// skipped and expires_at (YYYY-MM-DD) may be empty. Every row is validated
+// before anything is written, the rows are written in one transaction, and a
+// row whose NIK and source are already listed updates that entry.
+func (uc *watchlistUseCase) ImportCSV(ctx context.Context, r io.Reader, adminID uint64) (*WatchlistImportResult, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var entries []*entity.Watchlist
+	var problems []string
+	seen := make(map[string]int)
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid CSV at line %d: %w", line, err)
+		}
+		if line == 1 && strings.EqualFold(strings.TrimSpace(record[0]), "nik") {
+			continue
+		}
+
+		entry, err := parseWatchlistRecord(record)
+		if err == nil {
+			err = validateWatchlistEntry(entry)
+		}
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("line %d: %v", line, err))
+			continue
+		}
+
+		key := entry.NIK + "|" + entry.Source
+		if previous, ok := seen[key]; ok {
+			problems = append(problems, fmt.Sprintf("line %d: duplicates line %d", line, previous))
+			continue
+		}
+		seen[key] = line
+		entry.CreatedBy = &adminID
+		entries = append(entries, entry)
+	}
+
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("watchlist import rejected, nothing was imported: %s", strings.Join(problems, "; "))
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("the file contains no watchlist entries")
+	}
+
+	result := &WatchlistImportResult{}
+	err := uc.db.Transaction(func(tx *gorm.DB) error {
+		ctx := database.WithTx(ctx, tx)
+
+		for _, entry := range entries {
+			existing, err := uc.watchlistRepo.GetByNIKAndSource(ctx, entry.NIK, entry.Source)
+			if err != nil {
+				return err
+			}
+
+			if existing == nil {
+				if err := uc.watchlistRepo.Create(ctx, entry); err != nil {
+					return err
+				}
+				result.Created++
+				continue
+			}
+
+			existing.Name = entry.Name
+			existing.Reason = entry.Reason
+			existing.ExpiresAt = entry.ExpiresAt
+			if err := uc.watchlistRepo.Update(ctx, existing); err != nil {
+				return err
+			}
+			result.Updated++
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Error("Watchlist import rolled back", "rows", len(entries), "error", err)
+		return nil, fmt.Errorf("watchlist import failed, nothing was imported: %w", err)
+	}
+
+	logger.Info("Watchlist imported", "created", result.Created, "updated", result.Updated, "adminID", adminID)
+	return result, nil
+}
+
+// Screen checks a NIK against the unexpired watchlist entries. Under the
+// BLOCK policy every hit is recorded and ErrWatchlistBlocked returned. Under
+// REVIEW the hits are returned unsaved, so the caller can record them with
+// RecordHits once the customer or transaction they hold exists.
+func (uc *watchlistUseCase) Screen(ctx context.Context, stage entity.ScreeningStage, nik string, customerID *uint64) ([]*entity.WatchlistHit, error) {
+	entries, err := uc.watchlistRepo.GetActiveByNIK(ctx, nik, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, nil
+	}
+
+	hits := make([]*entity.WatchlistHit, 0, len(entries))
+	for _, entry := range entries {
+		hits = append(hits, &entity.WatchlistHit{
+			WatchlistID: entry.ID,
+			NIK:         nik,
+			Stage:       stage,
+			Action:      uc.policy.OnHit,
+			CustomerID:  customerID,
+			Status:      entity.WatchlistHitOpen,
+		})
+	}
+
+	if uc.policy.OnHit == entity.WatchlistReview {
+		return hits, nil
+	}
+
+	for _, hit := range hits {
+		hit.Status = entity.WatchlistHitBlocked
+		if err := uc.hitRepo.Create(ctx, hit); err != nil {
+			return nil, err
+		}
+	}
+	logger.Info("Application blocked by watchlist", "stage", stage, "hits", len(hits), "entryID", hits[0].WatchlistID)
+	return nil, ErrWatchlistBlocked
+}
+
+// RecordHits stores review hits against the customer and, at transaction
+// stage, the transaction whose approval they hold.
+func (uc *watchlistUseCase) RecordHits(ctx context.Context, hits []*entity.WatchlistHit, customerID uint64, transactionID *uint64) error {
+	for _, hit := range hits {
+		hit.CustomerID = &customerID
+		hit.TransactionID = transactionID
+		if err := uc.hitRepo.Create(ctx, hit); err != nil {
+			return err
+		}
+		logger.Info("Watchlist hit sent to review", "hitID", hit.ID, "stage", hit.Stage, "customerID", customerID, "entryID", hit.WatchlistID)
+	}
+	return nil
+}
+
+func (uc *watchlistUseCase) GetHits(ctx context.Context, status entity.WatchlistHitStatus, limit, offset int) ([]*entity.WatchlistHit, error) {
+	return uc.hitRepo.GetByStatus(ctx, status, limit, offset)
+}
+
+// ResolveHit closes a review hit. CLEARED releases the hold; CONFIRMED keeps
+// it, and the admin rejects the KYC or the transaction.
+func (uc *watchlistUseCase) ResolveHit(ctx context.Context, id uint64, adminID uint64, status entity.WatchlistHitStatus, note string) (*entity.WatchlistHit, error) {
+	if status != entity.WatchlistHitCleared && status != entity.WatchlistHitConfirmed {
+		return nil, fmt.Errorf("a watchlist hit can only be resolved as %s or %s", entity.WatchlistHitCleared, entity.WatchlistHitConfirmed)
+	}
+	if strings.TrimSpace(note) == "" {
+		return nil, fmt.Errorf("a note is required to resolve a watchlist hit")
+	}
+
+	hit, err := uc.hitRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("watchlist hit not found: %w", err)
+	}
+	if hit.Status != entity.WatchlistHitOpen {
+		return nil, fmt.Errorf("watchlist hit is already %s", hit.Status)
+	}
+
+	resolvedAt := time.Now()
+	hit.Status = status
+	hit.ResolutionNote = note
+	hit.ResolvedBy = &adminID
+	hit.ResolvedAt = &resolvedAt
+
+	if err := uc.hitRepo.Update(ctx, hit); err != nil {
+		return nil, err
+	}
+
+	logger.Info("Watchlist hit resolved", "hitID", hit.ID, "status", status, "adminID", adminID)
+	return hit, nil
+}
+
+// CustomerOnHold reports whether open or confirmed hits hold the customer.
+func (uc *watchlistUseCase) CustomerOnHold(ctx context.Context, customerID uint64) (bool, error) {
+	count, err := uc.hitRepo.CountHoldsByCustomer(ctx, customerID)
+	return count > 0, err
+}
+
+// TransactionOnHold reports whether open or confirmed hits hold the transaction.
+func (uc *watchlistUseCase) TransactionOnHold(ctx context.Context, transactionID uint64) (bool, error) {
+	count, err := uc.hitRepo.CountHoldsByTransaction(ctx, transactionID)
+	return count > 0, err
+}
+
+func parseWatchlistRecord(record []string) (*entity.Watchlist, error) {
+	field := func(i int) string {
+		if i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	entry := &entity.Watchlist{
+		NIK:    field(0),
+		Name:   field(1),
+		Reason: field(2),
+		Source: field(3),
+	}
+	if expires := field(4); expires != "" {
+		expiresAt, err := time.Parse("2006-01-02", expires)
+		if err != nil {
+			return nil, fmt.Errorf("expires_at must be YYYY-MM-DD")
+		}
+		entry.ExpiresAt = &expiresAt
+	}
+	return entry, nil
+}
+
+func validateWatchlistEntry(entry *entity.Watchlist) error {
+	entry.NIK = strings.TrimSpace(entry.NIK)
+	if !utils.IsValidNIK(entry.NIK) {
+		return fmt.Errorf("NIK must be exactly 16 digits")
+	}
+
+	entry.Source = strings.ToUpper(strings.TrimSpace(entry.Source))
+	if entry.Source == "" {
+		return fmt.Errorf("source is required")
+	}
+
+	if strings.TrimSpace(entry.Reason) == "" {
+		return fmt.Errorf("reason is required")
+	}
+	return nil
+}
@@ -15,6 +15,171 @@
 /*!40101 SET @OLD_SQL_MODE=@@SQL_MODE, SQL_MODE='NO_AUTO_VALUE_ON_ZERO' */;
 /*!40111 SET @OLD_SQL_NOTES=@@SQL_NOTES, SQL_NOTES=0 */;
 
+--
+-- Table structure for table `admin_fee_rules`
+--
+
+DROP TABLE IF EXISTS `admin_fee_rules`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!50503 SET character_set_client = utf8mb4 */;
+CREATE TABLE `admin_fee_rules` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `name` varchar(255) NOT NULL,
+  `product_id` bigint unsigned DEFAULT NULL,
+  `transaction_source` varchar(20) DEFAULT NULL,
+  `type` varchar(20) NOT NULL,
+  `value` decimal(15,4) DEFAULT '0.0000',
+  `tiers` text,
+  `min_fee` decimal(15,2) DEFAULT '0.00',
+  `max_fee` decimal(15,2) DEFAULT '0.00',
+  `is_active` tinyint(1) DEFAULT NULL,
+  `created_at` datetime(3) DEFAULT NULL,
+  `updated_at` datetime(3) DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  KEY `idx_admin_fee_rules_product_id` (`product_id`),
+  KEY `idx_admin_fee_rules_transaction_source` (`transaction_source`),
+  KEY `idx_admin_fee_rules_is_active` (`is_active`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Dumping data for table `admin_fee_rules`
+--
+
+LOCK TABLES `admin_fee_rules` WRITE;
+/*!40000 ALTER TABLE `admin_fee_rules` DISABLE KEYS */;
+/*!40000 ALTER TABLE `admin_fee_rules` ENABLE KEYS */;
+UNLOCK TABLES;
+
+--
+-- Table structure for table `contract_documents`
+--
+
+DROP TABLE IF EXISTS `contract_documents`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!50503 SET character_set_client = utf8mb4 */;
+CREATE TABLE `contract_documents` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `transaction_id` bigint unsigned NOT NULL,
+  `template_id` bigint unsigned NOT NULL,
+  `template_version` bigint NOT NULL,
+  `file_name` varchar(255) NOT NULL,
+  `content_type` varchar(100) NOT NULL,
+  `content` longblob NOT NULL,
+  `sha256` char(64) NOT NULL,
+  `size` bigint NOT NULL,
+  `generated_at` datetime(3) NOT NULL,
+  `created_at` datetime(3) DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_contract_documents_transaction_id` (`transaction_id`),
+  KEY `idx_contract_documents_template_id` (`template_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Dumping data for table `contract_documents`
+--
+
+LOCK TABLES `contract_documents` WRITE;
+/*!40000 ALTER TABLE `contract_documents` DISABLE KEYS */;
+/*!40000 ALTER TABLE `contract_documents` ENABLE KEYS */;
+UNLOCK TABLES;
+
+--
+-- Table structure for table `contract_sequences`
+--
+
+DROP TABLE IF EXISTS `contract_sequences`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!50503 SET character_set_client = utf8mb4 */;
+CREATE TABLE `contract_sequences` (
+  `scope` varchar(100) NOT NULL,
+  `value` bigint unsigned NOT NULL DEFAULT '0',
+  `updated_at` datetime(3) DEFAULT NULL,
+  PRIMARY KEY (`scope`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Dumping data for table `contract_sequences`
+--
+
+LOCK TABLES `contract_sequences` WRITE;
+/*!40000 ALTER TABLE `contract_sequences` DISABLE KEYS */;
+/*!40000 ALTER TABLE `contract_sequences` ENABLE KEYS */;
+UNLOCK TABLES;
+
+--
+-- Table structure for table `contract_templates`
+--
+
+DROP TABLE IF EXISTS `contract_templates`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!50503 SET character_set_client = utf8mb4 */;
+CREATE TABLE `contract_templates` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `version` bigint NOT NULL,
+  `name` varchar(255) NOT NULL,
+  `body` text NOT NULL,
+  `is_active` tinyint(1) DEFAULT '1',
+  `created_by` bigint unsigned DEFAULT NULL,
+  `created_at` datetime(3) DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_contract_templates_version` (`version`),
+  KEY `idx_contract_templates_is_active` (`is_active`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Dumping data for table `contract_templates`
+--
+
+LOCK TABLES `contract_templates` WRITE;
+/*!40000 ALTER TABLE `contract_templates` DISABLE KEYS */;
+/*!40000 ALTER TABLE `contract_templates` ENABLE KEYS */;
+UNLOCK TABLES;
+
+--
+-- Table structure for table `customer_duplicates`
+--
+
+DROP TABLE IF EXISTS `customer_duplicates`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!50503 SET character_set_client = utf8mb4 */;
+CREATE TABLE `customer_duplicates` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `customer_id` bigint unsigned NOT NULL,
+  `matched_customer_id` bigint unsigned NOT NULL,
+  `score` decimal(5,4) NOT NULL,
+  `name_similarity` decimal(5,4) NOT NULL,
+  `birth_date_match` tinyint(1) DEFAULT NULL,
+  `birth_place_match` tinyint(1) DEFAULT NULL,
+  `nik_distance` bigint DEFAULT NULL,
+  `reasons` varchar(500) DEFAULT NULL,
+  `status` varchar(20) NOT NULL DEFAULT 'OPEN',
+  `resolution_note` varchar(500) DEFAULT NULL,
+  `resolved_by` bigint unsigned DEFAULT NULL,
+  `resolved_at` datetime(3) DEFAULT NULL,
+  `created_at` datetime(3) DEFAULT NULL,
+  `updated_at` datetime(3) DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_duplicate_pair` (`customer_id`,`matched_customer_id`),
+  KEY `idx_customer_duplicates_matched_customer_id` (`matched_customer_id`),
+  KEY `idx_customer_duplicates_status` (`status`),
+  CONSTRAINT `fk_customer_duplicates_customer` FOREIGN KEY (`customer_id`) REFERENCES `customers` (`id`),
+  CONSTRAINT `fk_customer_duplicates_matched_customer` FOREIGN KEY (`matched_customer_id`) REFERENCES `customers` (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Dumping data for table `customer_duplicates`
+--
+
+LOCK TABLES `customer_duplicates` WRITE;
+/*!40000 ALTER TABLE `customer_duplicates` DISABLE KEYS */;
+/*!40000 ALTER TABLE `customer_duplicates` ENABLE KEYS */;
+UNLOCK TABLES;
+
 --
 -- Table structure for table `customer_limits`
 --
@@ -73,6 +238,22 @@ CREATE TABLE `customers` (
   `updated_at` datetime(3) DEFAULT NULL,
   `deleted_at` datetime(3) DEFAULT NULL,
   `user_id` bigint unsigned NOT NULL,
+  `credit_balance` decimal(15,2) DEFAULT '0.00',
+  `ktp_object_key` varchar(255) DEFAULT NULL,
+  `ktp_checksum` char(64) DEFAULT NULL,
+  `ktp_uploaded_at` datetime(3) DEFAULT NULL,
+  `selfie_object_key` varchar(255) DEFAULT NULL,
+  `selfie_checksum` char(64) DEFAULT NULL,
+  `selfie_uploaded_at` datetime(3) DEFAULT NULL,
+  `sex` varchar(10) DEFAULT NULL,
+  `province_code` char(2) DEFAULT NULL,
+  `regency_code` char(4) DEFAULT NULL,
+  `district_code` char(6) DEFAULT NULL,
+  `kyc_status` varchar(20) NOT NULL DEFAULT 'UNVERIFIED',
+  `kyc_submitted_at` datetime(3) DEFAULT NULL,
+  `kyc_review_reason` varchar(500) DEFAULT NULL,
+  `kyc_reviewed_by` bigint unsigned DEFAULT NULL,
+  `kyc_reviewed_at` datetime(3) DEFAULT NULL,
   PRIMARY KEY (`id`),
   UNIQUE KEY `uni_customers_nik` (`nik`),
   UNIQUE KEY `uni_customers_user_id` (`user_id`),
@@ -81,6 +262,10 @@ CREATE TABLE `customers` (
   KEY `idx_customers_deleted_at` (`deleted_at`),
   KEY `idx_customers_nik` (`nik`),
   KEY `idx_customers_user_id` (`user_id`),
+  KEY `idx_customers_sex` (`sex`),
+  KEY `idx_customers_province_code` (`province_code`),
+  KEY `idx_customers_regency_code` (`regency_code`),
+  KEY `idx_customers_kyc_status` (`kyc_status`),
   CONSTRAINT `fk_customers_user` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE
 ) ENGINE=InnoDB AUTO_INCREMENT=11 DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
 /*!40101 SET character_set_client = @saved_cs_client */;
@@ -91,10 +276,401 @@ CREATE TABLE `customers` (
 
 LOCK TABLES `customers` WRITE;
 /*!40000 ALTER TABLE `customers` DISABLE KEYS */;
-INSERT INTO `customers` VALUES (10,'3174012345678901','Ralfi Wardhana','Ralfi Wardhana','Jakarta','1996-01-01',9000000.00,'/uploads/ktp/ralfi_ktp_20250714.jpg','/uploads/selfie/ralfi_selfie_20250714.jpg','2025-07-14 10:06:33.735','2025-07-14 10:06:33.735',NULL,4);
+INSERT INTO `customers` (`id`, `nik`, `full_name`, `legal_name`, `birth_place`, `birth_date`, `salary`, `ktp_photo_path`, `selfie_photo_path`, `created_at`, `updated_at`, `deleted_at`, `user_id`) VALUES (10,'3174012345678901','Ralfi Wardhana','Ralfi Wardhana','Jakarta','1996-01-01',9000000.00,'/uploads/ktp/ralfi_ktp_20250714.jpg','/uploads/selfie/ralfi_selfie_20250714.jpg','2025-07-14 10:06:33.735','2025-07-14 10:06:33.735',NULL,4);
 /*!40000 ALTER TABLE `customers` ENABLE KEYS */;
 UNLOCK TABLES;
 
+--
+-- Table structure for table `installment_schedules`
+--
+
+DROP TABLE IF EXISTS `installment_schedules`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!50503 SET character_set_client = utf8mb4 */;
+CREATE TABLE `installment_schedules` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `transaction_id` bigint unsigned NOT NULL,
+  `installment_number` bigint NOT NULL,
+  `due_date` date NOT NULL,
+  `principal_amount` decimal(15,2) NOT NULL,
+  `interest_amount` decimal(15,2) NOT NULL,
+  `admin_fee_amount` decimal(15,2) NOT NULL,
+  `amount_due` decimal(15,2) NOT NULL,
+  `status` varchar(20) NOT NULL DEFAULT 'UNPAID',
+  `created_at` datetime(3) DEFAULT NULL,
+  `updated_at` datetime(3) DEFAULT NULL,
+  `penalty_amount` decimal(15,2) DEFAULT '0.00',
+  `principal_paid` decimal(15,2) DEFAULT '0.00',
+  `interest_paid` decimal(15,2) DEFAULT '0.00',
+  `admin_fee_paid` decimal(15,2) DEFAULT '0.00',
+  `penalty_paid` decimal(15,2) DEFAULT '0.00',
+  `paid_at` datetime(3) DEFAULT NULL,
+  `days_past_due` bigint DEFAULT '0',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_transaction_installment` (`transaction_id`,`installment_number`),
+  KEY `idx_installment_schedules_due_date` (`due_date`),
+  KEY `idx_installment_schedules_status` (`status`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Dumping data for table `installment_schedules`
+--
+
+LOCK TABLES `installment_schedules` WRITE;
+/*!40000 ALTER TABLE `installment_schedules` DISABLE KEYS */;
+/*!40000 ALTER TABLE `installment_schedules` ENABLE KEYS */;
+UNLOCK TABLES;
+
+--
+-- Table structure for table `limit_change_requests`
+--
+
+DROP TABLE IF EXISTS `limit_change_requests`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!50503 SET character_set_client = utf8mb4 */;
+CREATE TABLE `limit_change_requests` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `customer_id` bigint unsigned NOT NULL,
+  `tenor_months` bigint NOT NULL,
+  `current_limit_amount` decimal(15,2) NOT NULL,
+  `requested_limit_amount` decimal(15,2) NOT NULL,
+  `approved_limit_amount` decimal(15,2) DEFAULT '0.00',
+  `updated_salary` decimal(15,2) DEFAULT NULL,
+  `document_key` varchar(255) DEFAULT NULL,
+  `document_checksum` varchar(64) DEFAULT NULL,
+  `document_uploaded_at` datetime(3) DEFAULT NULL,
+  `customer_note` varchar(500) DEFAULT NULL,
+  `status` varchar(20) DEFAULT 'PENDING',
+  `review_reason` varchar(500) DEFAULT NULL,
+  `reviewed_by` bigint unsigned DEFAULT NULL,
+  `reviewed_at` datetime(3) DEFAULT NULL,
+  `created_at` datetime(3) DEFAULT NULL,
+  `updated_at` datetime(3) DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  KEY `idx_limit_change_requests_customer_id` (`customer_id`),
+  KEY `idx_limit_change_requests_status` (`status`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Dumping data for table `limit_change_requests`
+--
+
+LOCK TABLES `limit_change_requests` WRITE;
+/*!40000 ALTER TABLE `limit_change_requests` DISABLE KEYS */;
+/*!40000 ALTER TABLE `limit_change_requests` ENABLE KEYS */;
+UNLOCK TABLES;
+
+--
+-- Table structure for table `limit_changes`
+--
+
+DROP TABLE IF EXISTS `limit_changes`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!50503 SET character_set_client = utf8mb4 */;
+CREATE TABLE `limit_changes` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `customer_limit_id` bigint unsigned NOT NULL,
+  `customer_id` bigint unsigned NOT NULL,
+  `tenor_months` bigint NOT NULL,
+  `old_limit_amount` decimal(15,2) NOT NULL,
+  `new_limit_amount` decimal(15,2) NOT NULL,
+  `changed_by` bigint unsigned NOT NULL,
+  `reason` varchar(500) NOT NULL,
+  `created_at` datetime(3) DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  KEY `idx_limit_changes_customer_limit_id` (`customer_limit_id`),
+  KEY `idx_limit_change_customer_tenor` (`customer_id`,`tenor_months`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Dumping data for table `limit_changes`
+--
+
+LOCK TABLES `limit_changes` WRITE;
+/*!40000 ALTER TABLE `limit_changes` DISABLE KEYS */;
+/*!40000 ALTER TABLE `limit_changes` ENABLE KEYS */;
+UNLOCK TABLES;
+
+--
+-- Table structure for table `limit_movements`
+--
+
+DROP TABLE IF EXISTS `limit_movements`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!50503 SET character_set_client = utf8mb4 */;
+CREATE TABLE `limit_movements` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `customer_limit_id` bigint unsigned NOT NULL,
+  `customer_id` bigint unsigned NOT NULL,
+  `tenor_months` bigint NOT NULL,
+  `type` varchar(30) NOT NULL,
+  `amount` decimal(15,2) NOT NULL,
+  `used_amount_after` decimal(15,2) NOT NULL,
+  `transaction_id` bigint unsigned DEFAULT NULL,
+  `payment_id` bigint unsigned DEFAULT NULL,
+  `admin_id` bigint unsigned DEFAULT NULL,
+  `reason` varchar(500) DEFAULT NULL,
+  `created_at` datetime(3) DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  KEY `idx_limit_movements_customer_limit_id` (`customer_limit_id`),
+  KEY `idx_limit_movement_customer_tenor` (`customer_id`,`tenor_months`),
+  KEY `idx_limit_movements_transaction_id` (`transaction_id`),
+  KEY `idx_limit_movements_payment_id` (`payment_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Dumping data for table `limit_movements`
+--
+
+LOCK TABLES `limit_movements` WRITE;
+/*!40000 ALTER TABLE `limit_movements` DISABLE KEYS */;
+/*!40000 ALTER TABLE `limit_movements` ENABLE KEYS */;
+UNLOCK TABLES;
+
+--
+-- Table structure for table `partner_credentials`
+--
+
+DROP TABLE IF EXISTS `partner_credentials`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!50503 SET character_set_client = utf8mb4 */;
+CREATE TABLE `partner_credentials` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `partner_id` bigint unsigned NOT NULL,
+  `api_key` varchar(64) NOT NULL,
+  `secret_hash` varchar(64) NOT NULL,
+  `expires_at` datetime(3) DEFAULT NULL,
+  `revoked_at` datetime(3) DEFAULT NULL,
+  `last_used_at` datetime(3) DEFAULT NULL,
+  `created_by` bigint unsigned DEFAULT NULL,
+  `created_at` datetime(3) DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uni_partner_credentials_api_key` (`api_key`),
+  KEY `idx_partner_credentials_partner_id` (`partner_id`),
+  CONSTRAINT `fk_partner_credentials_partner` FOREIGN KEY (`partner_id`) REFERENCES `partners` (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Dumping data for table `partner_credentials`
+--
+
+LOCK TABLES `partner_credentials` WRITE;
+/*!40000 ALTER TABLE `partner_credentials` DISABLE KEYS */;
+/*!40000 ALTER TABLE `partner_credentials` ENABLE KEYS */;
+UNLOCK TABLES;
+
+--
+-- Table structure for table `partner_customers`
+--
+
+DROP TABLE IF EXISTS `partner_customers`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!50503 SET character_set_client = utf8mb4 */;
+CREATE TABLE `partner_customers` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `partner_id` bigint unsigned NOT NULL,
+  `customer_id` bigint unsigned NOT NULL,
+  `linked_by` bigint unsigned DEFAULT NULL,
+  `created_at` datetime(3) DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_partner_customer` (`partner_id`,`customer_id`),
+  KEY `idx_partner_customers_customer_id` (`customer_id`),
+  CONSTRAINT `fk_partner_customers_partner` FOREIGN KEY (`partner_id`) REFERENCES `partners` (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Dumping data for table `partner_customers`
+--
+
+LOCK TABLES `partner_customers` WRITE;
+/*!40000 ALTER TABLE `partner_customers` DISABLE KEYS */;
+/*!40000 ALTER TABLE `partner_customers` ENABLE KEYS */;
+UNLOCK TABLES;
+
+--
+-- Table structure for table `partners`
+--
+
+DROP TABLE IF EXISTS `partners`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!50503 SET character_set_client = utf8mb4 */;
+CREATE TABLE `partners` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `type` varchar(20) NOT NULL,
+  `name` varchar(255) NOT NULL,
+  `settlement_bank_name` varchar(100) NOT NULL,
+  `settlement_account_number` varchar(50) NOT NULL,
+  `settlement_account_name` varchar(255) NOT NULL,
+  `status` varchar(20) NOT NULL DEFAULT 'ACTIVE',
+  `created_at` datetime(3) DEFAULT NULL,
+  `updated_at` datetime(3) DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  KEY `idx_partners_type` (`type`),
+  KEY `idx_partners_status` (`status`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Dumping data for table `partners`
+--
+
+LOCK TABLES `partners` WRITE;
+/*!40000 ALTER TABLE `partners` DISABLE KEYS */;
+/*!40000 ALTER TABLE `partners` ENABLE KEYS */;
+UNLOCK TABLES;
+
+--
+-- Table structure for table `payment_allocations`
+--
+
+DROP TABLE IF EXISTS `payment_allocations`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!50503 SET character_set_client = utf8mb4 */;
+CREATE TABLE `payment_allocations` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `payment_id` bigint unsigned NOT NULL,
+  `installment_id` bigint unsigned NOT NULL,
+  `penalty` decimal(15,2) DEFAULT '0.00',
+  `interest` decimal(15,2) DEFAULT '0.00',
+  `admin_fee` decimal(15,2) DEFAULT '0.00',
+  `principal` decimal(15,2) DEFAULT '0.00',
+  `created_at` datetime(3) DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  KEY `idx_payment_allocations_payment_id` (`payment_id`),
+  KEY `idx_payment_allocations_installment_id` (`installment_id`),
+  CONSTRAINT `fk_payments_allocations` FOREIGN KEY (`payment_id`) REFERENCES `payments` (`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Dumping data for table `payment_allocations`
+--
+
+LOCK TABLES `payment_allocations` WRITE;
+/*!40000 ALTER TABLE `payment_allocations` DISABLE KEYS */;
+/*!40000 ALTER TABLE `payment_allocations` ENABLE KEYS */;
+UNLOCK TABLES;
+
+--
+-- Table structure for table `payments`
+--
+
+DROP TABLE IF EXISTS `payments`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!50503 SET character_set_client = utf8mb4 */;
+CREATE TABLE `payments` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `transaction_id` bigint unsigned NOT NULL,
+  `customer_id` bigint unsigned NOT NULL,
+  `amount` decimal(15,2) NOT NULL,
+  `payment_date` datetime(3) NOT NULL,
+  `payment_method` varchar(50) NOT NULL,
+  `reference_number` varchar(100) DEFAULT NULL,
+  `allocated_penalty` decimal(15,2) DEFAULT '0.00',
+  `allocated_interest` decimal(15,2) DEFAULT '0.00',
+  `allocated_admin_fee` decimal(15,2) DEFAULT '0.00',
+  `allocated_principal` decimal(15,2) DEFAULT '0.00',
+  `excess_amount` decimal(15,2) DEFAULT '0.00',
+  `notes` varchar(500) DEFAULT NULL,
+  `recorded_by` bigint unsigned NOT NULL,
+  `created_at` datetime(3) DEFAULT NULL,
+  `limit_restored` decimal(15,2) DEFAULT '0.00',
+  PRIMARY KEY (`id`),
+  KEY `idx_payments_transaction_id` (`transaction_id`),
+  KEY `idx_payments_customer_id` (`customer_id`),
+  KEY `idx_payments_payment_date` (`payment_date`),
+  KEY `idx_payments_reference_number` (`reference_number`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Dumping data for table `payments`
+--
+
+LOCK TABLES `payments` WRITE;
+/*!40000 ALTER TABLE `payments` DISABLE KEYS */;
+/*!40000 ALTER TABLE `payments` ENABLE KEYS */;
+UNLOCK TABLES;
+
+--
+-- Table structure for table `products`
+--
+
+DROP TABLE IF EXISTS `products`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!50503 SET character_set_client = utf8mb4 */;
+CREATE TABLE `products` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `code` varchar(50) NOT NULL,
+  `name` varchar(255) NOT NULL,
+  `description` varchar(500) DEFAULT NULL,
+  `allowed_tenors` varchar(255) NOT NULL,
+  `interest_rate` decimal(7,4) NOT NULL,
+  `admin_fee_type` varchar(20) NOT NULL,
+  `admin_fee_value` decimal(15,4) NOT NULL,
+  `min_otr` decimal(15,2) DEFAULT '0.00',
+  `max_otr` decimal(15,2) DEFAULT '0.00',
+  `eligible_asset_types` varchar(255) NOT NULL,
+  `is_active` tinyint(1) DEFAULT NULL,
+  `created_at` datetime(3) DEFAULT NULL,
+  `updated_at` datetime(3) DEFAULT NULL,
+  `min_age` bigint DEFAULT '0',
+  `max_age_at_tenor_end` bigint DEFAULT '0',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uni_products_code` (`code`),
+  KEY `idx_products_is_active` (`is_active`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Dumping data for table `products`
+--
+
+LOCK TABLES `products` WRITE;
+/*!40000 ALTER TABLE `products` DISABLE KEYS */;
+/*!40000 ALTER TABLE `products` ENABLE KEYS */;
+UNLOCK TABLES;
+
+--
+-- Table structure for table `rate_cards`
+--
+
+DROP TABLE IF EXISTS `rate_cards`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!50503 SET character_set_client = utf8mb4 */;
+CREATE TABLE `rate_cards` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `product_id` bigint unsigned NOT NULL,
+  `tenor_months` bigint NOT NULL,
+  `asset_type` varchar(20) NOT NULL,
+  `interest_rate` decimal(7,4) NOT NULL,
+  `admin_fee_type` varchar(20) NOT NULL,
+  `admin_fee_value` decimal(15,4) NOT NULL,
+  `effective_from` datetime(3) NOT NULL,
+  `effective_to` datetime(3) DEFAULT NULL,
+  `created_by` bigint unsigned DEFAULT NULL,
+  `created_at` datetime(3) DEFAULT NULL,
+  `updated_at` datetime(3) DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  KEY `idx_rate_card_lookup` (`product_id`,`tenor_months`,`asset_type`,`effective_from`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Dumping data for table `rate_cards`
+--
+
+LOCK TABLES `rate_cards` WRITE;
+/*!40000 ALTER TABLE `rate_cards` DISABLE KEYS */;
+/*!40000 ALTER TABLE `rate_cards` ENABLE KEYS */;
+UNLOCK TABLES;
+
 --
 -- Table structure for table `transactions`
 --
@@ -118,6 +694,25 @@ CREATE TABLE `transactions` (
   `created_at` datetime(3) DEFAULT NULL,
   `updated_at` datetime(3) DEFAULT NULL,
   `deleted_at` datetime(3) DEFAULT NULL,
+  `days_past_due` bigint DEFAULT '0',
+  `accrued_penalty` decimal(15,2) DEFAULT '0.00',
+  `interest_method` varchar(20) DEFAULT NULL,
+  `interest_rate` decimal(7,4) DEFAULT NULL,
+  `status_reason` varchar(500) DEFAULT NULL,
+  `approved_at` datetime(3) DEFAULT NULL,
+  `rejected_at` datetime(3) DEFAULT NULL,
+  `activated_at` datetime(3) DEFAULT NULL,
+  `completed_at` datetime(3) DEFAULT NULL,
+  `defaulted_at` datetime(3) DEFAULT NULL,
+  `limit_restored` decimal(15,2) DEFAULT '0.00',
+  `product_id` bigint unsigned DEFAULT NULL,
+  `rate_card_id` bigint unsigned DEFAULT NULL,
+  `admin_fee_rule_id` bigint unsigned DEFAULT NULL,
+  `client_admin_fee` decimal(15,2) DEFAULT NULL,
+  `down_payment_amount` decimal(15,2) DEFAULT '0.00',
+  `financed_amount` decimal(15,2) DEFAULT '0.00',
+  `debt_to_income_ratio` decimal(7,4) DEFAULT '0.0000',
+  `partner_id` bigint unsigned DEFAULT NULL,
   PRIMARY KEY (`id`),
   UNIQUE KEY `uni_transactions_contract_number` (`contract_number`),
   KEY `idx_contract_number` (`contract_number`),
@@ -131,6 +726,11 @@ CREATE TABLE `transactions` (
   KEY `idx_transactions_asset_type` (`asset_type`),
   KEY `idx_transactions_status` (`status`),
   KEY `idx_transactions_created_at` (`created_at`),
+  KEY `idx_transactions_days_past_due` (`days_past_due`),
+  KEY `idx_transactions_product_id` (`product_id`),
+  KEY `idx_transactions_rate_card_id` (`rate_card_id`),
+  KEY `idx_transactions_admin_fee_rule_id` (`admin_fee_rule_id`),
+  KEY `idx_transactions_partner_id` (`partner_id`),
   CONSTRAINT `fk_transactions_customer` FOREIGN KEY (`customer_id`) REFERENCES `customers` (`id`),
   CONSTRAINT `transactions_ibfk_1` FOREIGN KEY (`customer_id`) REFERENCES `customers` (`id`) ON DELETE CASCADE
 ) ENGINE=InnoDB AUTO_INCREMENT=7 DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
@@ -181,6 +781,77 @@ LOCK TABLES `users` WRITE;
 INSERT INTO `users` VALUES (4,'ralfi_customer','ralfi@example.com','$2a$10$S9UI0ybjgzScHUk/s3jWAemHeEpB8r33YZWLsk8ny8xdwrRVwac6S','CUSTOMER',1,'2025-07-14 10:06:33.726','2025-07-14 10:06:33.726',NULL),(5,'admin','admin@ptxyz.com','$2a$10$K5QzJ8gOLJ8K5QzJ8gOLJO5QzJ8gOLJ8K5QzJ8gOLJ8K5QzJ8gOLJO','ADMIN',1,'2025-07-14 10:43:50.220','2025-07-14 10:43:50.220',NULL);
 /*!40000 ALTER TABLE `users` ENABLE KEYS */;
 UNLOCK TABLES;
+
+--
+-- Table structure for table `watchlist_hits`
+--
+
+DROP TABLE IF EXISTS `watchlist_hits`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!50503 SET character_set_client = utf8mb4 */;
+CREATE TABLE `watchlist_hits` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `watchlist_id` bigint unsigned NOT NULL,
+  `nik` varchar(16) NOT NULL,
+  `stage` varchar(30) NOT NULL,
+  `action` varchar(10) NOT NULL,
+  `customer_id` bigint unsigned DEFAULT NULL,
+  `transaction_id` bigint unsigned DEFAULT NULL,
+  `status` varchar(20) NOT NULL,
+  `resolution_note` varchar(500) DEFAULT NULL,
+  `resolved_by` bigint unsigned DEFAULT NULL,
+  `resolved_at` datetime(3) DEFAULT NULL,
+  `created_at` datetime(3) DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  KEY `idx_watchlist_hits_watchlist_id` (`watchlist_id`),
+  KEY `idx_watchlist_hits_nik` (`nik`),
+  KEY `idx_watchlist_hits_customer_id` (`customer_id`),
+  KEY `idx_watchlist_hits_transaction_id` (`transaction_id`),
+  KEY `idx_watchlist_hits_status` (`status`),
+  CONSTRAINT `fk_watchlist_hits_watchlist` FOREIGN KEY (`watchlist_id`) REFERENCES `watchlists` (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Dumping data for table `watchlist_hits`
+--
+
+LOCK TABLES `watchlist_hits` WRITE;
+/*!40000 ALTER TABLE `watchlist_hits` DISABLE KEYS */;
+/*!40000 ALTER TABLE `watchlist_hits` ENABLE KEYS */;
+UNLOCK TABLES;
+
+--
+-- Table structure for table `watchlists`
+--
+
+DROP TABLE IF EXISTS `watchlists`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!50503 SET character_set_client = utf8mb4 */;
+CREATE TABLE `watchlists` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `nik` varchar(16) NOT NULL,
+  `name` varchar(255) DEFAULT NULL,
+  `reason` varchar(500) NOT NULL,
+  `source` varchar(100) NOT NULL,
+  `expires_at` datetime(3) DEFAULT NULL,
+  `created_by` bigint unsigned DEFAULT NULL,
+  `created_at` datetime(3) DEFAULT NULL,
+  `updated_at` datetime(3) DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  KEY `idx_watchlists_nik` (`nik`),
+  KEY `idx_watchlists_expires_at` (`expires_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Dumping data for table `watchlists`
+--
+
+LOCK TABLES `watchlists` WRITE;
+/*!40000 ALTER TABLE `watchlists` DISABLE KEYS */;
+/*!40000 ALTER TABLE `watchlists` ENABLE KEYS */;
+UNLOCK TABLES;
 /*!40103 SET TIME_ZONE=@OLD_TIME_ZONE */;
 
 /*!40101 SET SQL_MODE=@OLD_SQL_MODE */;
@@ -1,14 +1,23 @@
 package main
 
 import (
+	"context"
 	"log"
+	"os"
 	"pt-xyz-multifinance/internal/config"
+	"pt-xyz-multifinance/internal/domain/entity"
+	"pt-xyz-multifinance/internal/domain/service"
 	"pt-xyz-multifinance/internal/infrastructure/database"
 	"pt-xyz-multifinance/internal/infrastructure/repository"
+	"pt-xyz-multifinance/internal/infrastructure/scheduler"
+	"pt-xyz-multifinance/internal/infrastructure/storage"
 	"pt-xyz-multifinance/internal/interfaces/api/handler"
 	"pt-xyz-multifinance/internal/interfaces/api/router"
+	"pt-xyz-multifinance/internal/interfaces/dto"
 	"pt-xyz-multifinance/internal/usecase"
 	"pt-xyz-multifinance/pkg/logger"
+	"pt-xyz-multifinance/pkg/utils"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
@@ -38,20 +47,187 @@ func main() {
 	customerRepo := repository.NewCustomerRepository(db)
 	transactionRepo := repository.NewTransactionRepository(db)
 	limitRepo := repository.NewLimitRepository(db)
+	scheduleRepo := repository.NewInstallmentScheduleRepository(db)
+	paymentRepo := repository.NewPaymentRepository(db)
+	limitRequestRepo := repository.NewLimitChangeRequestRepository(db)
+	productRepo := repository.NewProductRepository(db)
+	rateCardRepo := repository.NewRateCardRepository(db)
+	adminFeeRuleRepo := repository.NewAdminFeeRuleRepository(db)
+	contractSequenceRepo := repository.NewContractSequenceRepository(db)
+	contractTemplateRepo := repository.NewContractTemplateRepository(db)
+	contractDocumentRepo := repository.NewContractDocumentRepository(db)
+	watchlistRepo := repository.NewWatchlistRepository(db)
+	watchlistHitRepo := repository.NewWatchlistHitRepository(db)
+	customerDuplicateRepo := repository.NewCustomerDuplicateRepository(db)
+	partnerRepo := repository.NewPartnerRepository(db)
+	partnerCredentialRepo := repository.NewPartnerCredentialRepository(db)
+
+	if _, err := service.NewInterestCalculator(entity.InterestMethod(cfg.Interest.Method)); err != nil {
+		log.Fatal("Invalid interest configuration:", err)
+	}
+
+	adminFeePolicy := usecase.AdminFeePolicy{OnMismatch: usecase.AdminFeeMismatchAction(cfg.AdminFee.MismatchPolicy)}
+	if adminFeePolicy.OnMismatch != usecase.AdminFeeMismatchReject && adminFeePolicy.OnMismatch != usecase.AdminFeeMismatchIgnore {
+		log.Fatal("Invalid admin fee mismatch policy:", cfg.AdminFee.MismatchPolicy)
+	}
+
+	downPaymentMinimums, err := usecase.ParseDownPaymentMinimums(cfg.DownPayment.MinPercent)
+	if err != nil {
+		log.Fatal("Invalid down payment minimums:", err)
+	}
+
+	contractNumberFormat := service.ContractNumberFormat{
+		Template:      cfg.Contract.NumberFormat,
+		Prefix:        cfg.Contract.Prefix,
+		Branch:        cfg.Contract.Branch,
+		SequenceWidth: cfg.Contract.SequenceWidth,
+	}
+	if err := contractNumberFormat.Validate(); err != nil {
+		log.Fatal("Invalid contract number format:", err)
+	}
+
+	allocationOrder, err := entity.ParsePaymentComponents(cfg.Payment.AllocationOrder)
+	if err != nil {
+		log.Fatal("Invalid payment allocation order:", err)
+	}
+
+	tenorMultipliers, err := service.ParseTenorMultipliers(cfg.Limit.TenorMultipliers)
+	if err != nil {
+		log.Fatal("Invalid limit tenor multipliers:", err)
+	}
+	limitRecommender := service.NewLimitRecommender(service.LimitRecommendationPolicy{
+		TenorMultipliers: tenorMultipliers,
+		Tenors:           utils.ValidTenors,
+		YoungAge:         cfg.Limit.YoungAge,
+		YoungFactor:      cfg.Limit.YoungFactor,
+		SeniorAge:        cfg.Limit.SeniorAge,
+		SeniorFactor:     cfg.Limit.SeniorFactor,
+		RoundTo:          cfg.Limit.RoundTo,
+		MaxLimit:         cfg.Limit.MaxLimit,
+	})
+
+	// Without a region file only the province and the code structure are checked
+	var nikRegions map[string]string
+	if cfg.KYC.NIKRegionFile != "" {
+		regionFile, err := os.Open(cfg.KYC.NIKRegionFile)
+		if err != nil {
+			log.Fatal("Failed to open NIK region file:", err)
+		}
+		nikRegions, err = service.ParseRegionCSV(regionFile)
+		regionFile.Close()
+		if err != nil {
+			log.Fatal("Invalid NIK region file:", err)
+		}
+	}
+	nikParser := service.NewNIKParser(nikRegions)
+
+	watchlistPolicy := usecase.WatchlistPolicy{OnHit: entity.WatchlistAction(cfg.KYC.WatchlistAction)}
+	if watchlistPolicy.OnHit != entity.WatchlistBlock && watchlistPolicy.OnHit != entity.WatchlistReview {
+		log.Fatal("Invalid watchlist action:", cfg.KYC.WatchlistAction)
+	}
+
+	if cfg.KYC.DuplicateThreshold <= 0 || cfg.KYC.DuplicateThreshold > 1 || cfg.KYC.DuplicateMaxNIKDistance < 0 {
+		log.Fatal("Invalid duplicate detection configuration: the threshold must be in (0, 1] and the NIK distance not negative")
+	}
+	if cfg.Partner.CredentialGraceHours < 0 {
+		log.Fatal("Invalid partner credential grace period:", cfg.Partner.CredentialGraceHours)
+	}
+	if cfg.Affordability.MaxDebtToIncome < 0 || cfg.Affordability.MaxDebtToIncome > 1 {
+		log.Fatal("Invalid maximum debt-to-income ratio:", cfg.Affordability.MaxDebtToIncome)
+	}
+
+	duplicateScorer := service.NewDuplicateScorer(service.DuplicateScorePolicy{
+		Threshold:      cfg.KYC.DuplicateThreshold,
+		MaxNIKDistance: cfg.KYC.DuplicateMaxNIKDistance,
+	})
+
+	// Load the product catalog; tenor validation follows the active products
+	productUseCase := usecase.NewProductUseCase(productRepo, rateCardRepo, db)
+	if err := productUseCase.EnsureDefaultProduct(context.Background(), cfg.Interest.AnnualRate); err != nil {
+		log.Fatal("Failed to load product catalog:", err)
+	}
+	utils.SetTenorProvider(productUseCase.OfferedTenors)
+	if err := dto.RegisterValidators(); err != nil {
+		log.Fatal("Failed to register request validators:", err)
+	}
+
+	fileStorage, err := storage.NewLocalFileStorage(cfg.Storage.UploadPath, cfg.Storage.PublicBaseURL, cfg.Storage.URLSecret)
+	if err != nil {
+		log.Fatal("Failed to initialize file storage:", err)
+	}
+	kycUploadPolicy := usecase.KYCUploadPolicy{
+		MaxSize:          int64(cfg.Storage.MaxUploadSize),
+		AllowedMIMETypes: cfg.Storage.AllowedMIMETypes,
+		URLTTL:           time.Duration(cfg.Storage.URLTTLMinutes) * time.Minute,
+	}
+	if err := kycUploadPolicy.Validate(); err != nil {
+		log.Fatal("Invalid upload configuration:", err)
+	}
+	limitDocumentPolicy := usecase.LimitDocumentPolicy{
+		MaxSize:          int64(cfg.Storage.LimitDocumentMaxSize),
+		AllowedMIMETypes: cfg.Storage.LimitDocumentMIMETypes,
+		URLTTL:           time.Duration(cfg.Storage.URLTTLMinutes) * time.Minute,
+	}
+	if err := limitDocumentPolicy.Validate(); err != nil {
+		log.Fatal("Invalid limit document upload configuration:", err)
+	}
 
 	// Initialize use cases (pass DB instance for transaction handling)
-	customerUseCase := usecase.NewCustomerUseCase(customerRepo, limitRepo, db)
-	transactionUseCase := usecase.NewTransactionUseCase(transactionRepo, customerRepo, limitRepo, db)
-	authUseCase := usecase.NewAuthUseCase(userRepo, customerRepo, limitRepo, db)
+	rateCardUseCase := usecase.NewRateCardUseCase(rateCardRepo, productRepo)
+	adminFeeRuleUseCase := usecase.NewAdminFeeRuleUseCase(adminFeeRuleRepo, productRepo)
+	watchlistUseCase := usecase.NewWatchlistUseCase(watchlistRepo, watchlistHitRepo, watchlistPolicy, db)
+	customerDuplicateUseCase := usecase.NewCustomerDuplicateUseCase(customerDuplicateRepo, customerRepo, duplicateScorer)
+	customerUseCase := usecase.NewCustomerUseCase(customerRepo, limitRepo, limitRecommender, nikParser, watchlistUseCase, customerDuplicateUseCase, productUseCase, db)
+	scheduleUseCase := usecase.NewInstallmentScheduleUseCase(scheduleRepo)
+	limitPolicy := usecase.LimitPolicy{NonRevolvingTenors: cfg.Limit.NonRevolvingTenors}
+	contractDocumentUseCase := usecase.NewContractDocumentUseCase(contractTemplateRepo, contractDocumentRepo, transactionRepo, customerRepo, scheduleRepo)
+	if err := contractDocumentUseCase.EnsureDefaultTemplate(context.Background()); err != nil {
+		log.Fatal("Failed to load contract templates:", err)
+	}
+	stateMachine := usecase.NewTransactionStateMachine(limitRepo, limitPolicy, contractDocumentUseCase)
+	contractNumbers := usecase.NewContractNumberGenerator(contractSequenceRepo, contractNumberFormat)
+	transactionUseCase := usecase.NewTransactionUseCase(transactionRepo, customerRepo, limitRepo, scheduleUseCase, stateMachine, contractNumbers, productUseCase, rateCardUseCase, adminFeeRuleUseCase, watchlistUseCase, usecase.InterestPolicy{
+		Method: entity.InterestMethod(cfg.Interest.Method),
+	}, adminFeePolicy, usecase.DownPaymentPolicy{MinPercent: downPaymentMinimums}, usecase.AffordabilityPolicy{
+		MaxDebtToIncome: cfg.Affordability.MaxDebtToIncome,
+	}, db)
+	authUseCase := usecase.NewAuthUseCase(userRepo, customerRepo, limitRepo, limitRecommender, nikParser, watchlistUseCase, customerDuplicateUseCase, productUseCase, db)
+	delinquencyUseCase := usecase.NewDelinquencyUseCase(transactionRepo, scheduleRepo, stateMachine, usecase.DelinquencyPolicy{
+		GracePeriodDays:      cfg.Delinquency.GracePeriodDays,
+		LateFeeDailyRate:     cfg.Delinquency.LateFeeDailyRate,
+		LateFeeCapRate:       cfg.Delinquency.LateFeeCapRate,
+		DefaultThresholdDays: cfg.Delinquency.DefaultThresholdDays,
+	}, db)
+	limitRequestUseCase := usecase.NewLimitChangeRequestUseCase(limitRequestRepo, limitRepo, customerRepo, fileStorage, limitDocumentPolicy, db)
+	kycDocumentUseCase := usecase.NewKYCDocumentUseCase(customerRepo, fileStorage, watchlistUseCase, customerDuplicateUseCase, kycUploadPolicy, db)
+	partnerUseCase := usecase.NewPartnerUseCase(partnerRepo, partnerCredentialRepo, customerRepo, usecase.PartnerCredentialPolicy{
+		RotationGrace: time.Duration(cfg.Partner.CredentialGraceHours) * time.Hour,
+	}, db)
+	paymentUseCase := usecase.NewPaymentUseCase(paymentRepo, transactionRepo, scheduleRepo, customerRepo, limitRepo, delinquencyUseCase, stateMachine, allocationOrder, limitPolicy, db)
 
 	// Initialize handlers
-	customerHandler := handler.NewCustomerHandler(customerUseCase)
-	transactionHandler := handler.NewTransactionHandler(transactionUseCase, customerUseCase)
+	customerHandler := handler.NewCustomerHandler(customerUseCase, limitRequestUseCase)
+	transactionHandler := handler.NewTransactionHandler(transactionUseCase, customerUseCase, scheduleUseCase, partnerUseCase)
 	authHandler := handler.NewAuthHandler(authUseCase)
+	paymentHandler := handler.NewPaymentHandler(paymentUseCase, transactionUseCase)
+	delinquencyHandler := handler.NewDelinquencyHandler(delinquencyUseCase)
+	limitRequestHandler := handler.NewLimitChangeRequestHandler(limitRequestUseCase, limitDocumentPolicy.MaxSize)
+	productHandler := handler.NewProductHandler(productUseCase, rateCardUseCase)
+	adminFeeRuleHandler := handler.NewAdminFeeRuleHandler(adminFeeRuleUseCase)
+	contractHandler := handler.NewContractHandler(contractDocumentUseCase, transactionUseCase)
+	kycHandler := handler.NewKYCHandler(kycDocumentUseCase, kycUploadPolicy.MaxSize)
+	watchlistHandler := handler.NewWatchlistHandler(watchlistUseCase)
+	customerDuplicateHandler := handler.NewCustomerDuplicateHandler(customerDuplicateUseCase)
+	partnerHandler := handler.NewPartnerHandler(partnerUseCase)
+
+	// Start background jobs
+	delinquencyScheduler := scheduler.NewDelinquencyScheduler(delinquencyUseCase, time.Duration(cfg.Delinquency.RunIntervalHours)*time.Hour)
+	delinquencyScheduler.Start()
+	defer delinquencyScheduler.Stop()
 
 	// Initialize Gin router
 	r := gin.New()
-	router.SetupRoutes(r, customerHandler, transactionHandler, authHandler, authUseCase)
+	router.SetupRoutes(r, customerHandler, transactionHandler, authHandler, paymentHandler, delinquencyHandler, limitRequestHandler, productHandler, adminFeeRuleHandler, contractHandler, kycHandler, watchlistHandler, customerDuplicateHandler, partnerHandler, authUseCase, partnerUseCase)
 
 	// Start server
 	logger.Info("Starting PT XYZ Multifinance API server on port " + cfg.Server.Port)
@@ -61,6 +237,8 @@ func main() {
 	logger.Info("  GET  /api/v1/admin/customers - Get all customers (Admin only)")
 	logger.Info("  GET  /api/v1/customers/me - Get my profile (Customer)")
 	logger.Info("  POST /api/v1/transactions - Create transaction")
+	logger.Info("  GET  /api/v1/transactions/:id/schedule - Get installment schedule")
+	logger.Info("  POST /api/v1/admin/transactions/:id/payments - Record payment (Admin only)")
 	logger.Info("  GET  /health - Health check")
 
 	if err := r.Run(":" + cfg.Server.Port); err != nil {
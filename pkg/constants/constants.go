@@ -32,7 +32,8 @@ const (
 	MaxPageLimit     = 100
 )
 
-// ValidTenors defines the allowed tenor months for PT XYZ Multifinance
+// ValidTenors defines the built-in tenor months, used until the product
+// catalog is loaded and whenever it offers no tenors
 var ValidTenors = []int{1, 2, 3, 4}
 
 // IsValidTenor checks if the given tenor is valid (only 1, 2, 3, 4 allowed)
@@ -0,0 +1,183 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// A4 portrait in points, text set in 9pt Courier so columns line up
+const (
+	pageWidth    = 595
+	pageHeight   = 842
+	margin       = 50
+	fontSize     = 9
+	leading      = 12
+	linesPerPage = (pageHeight - 2*margin) / leading
+	maxLineChars = 90
+)
+
+// Document is a minimal PDF 1.4 writer for plain text documents. It only
+// knows the standard Courier font, so it needs no font files, and the same
+// content always produces the same bytes.
+type Document struct {
+	Title     string
+	CreatedAt time.Time
+
+	pages [][]string
+}
+
+func NewDocument(title string, createdAt time.Time) *Document {
+	return &Document{Title: title, CreatedAt: createdAt}
+}
+
+// AddLine appends a line of text, wrapping it at word boundaries and starting
+// a new page when the current one is full.
+func (d *Document) AddLine(text string) {
+	text = strings.ReplaceAll(text, "\t", "    ")
+	for _, line := range wrap(text, maxLineChars) {
+		if len(d.pages) == 0 || len(d.pages[len(d.pages)-1]) >= linesPerPage {
+			d.pages = append(d.pages, nil)
+		}
+		d.pages[len(d.pages)-1] = append(d.pages[len(d.pages)-1], line)
+	}
+}
+
+// AddText appends every line of a multi-line text. A line holding only a
+// form feed starts a new page.
+func (d *Document) AddText(text string) {
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		if line == "\f" {
+			d.NewPage()
+			continue
+		}
+		d.AddLine(line)
+	}
+}
+
+// NewPage starts a new page unless the current one is still empty.
+func (d *Document) NewPage() {
+	if len(d.pages) > 0 && len(d.pages[len(d.pages)-1]) > 0 {
+		d.pages = append(d.pages, nil)
+	}
+}
+
+// PageCount returns the number of pages written so far.
+func (d *Document) PageCount() int {
+	if len(d.pages) == 0 {
+		return 1
+	}
+	return len(d.pages)
+}
+
+// Bytes renders the document.
+func (d *Document) Bytes() []byte {
+	pages := d.pages
+	if len(pages) == 0 {
+		pages = [][]string{nil}
+	}
+
+	var buf bytes.Buffer
+	var offsets []int
+	writeObject := func(body string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	buf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// Objects 1-4 are fixed; each page adds a page and a content object
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 5+2*i)
+	}
+	writeObject("<< /Type /Catalog /Pages 2 0 R >>")
+	writeObject(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)))
+	writeObject("<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>")
+	writeObject(fmt.Sprintf("<< /Title (%s) /Producer (PT XYZ Multifinance) /CreationDate (D:%s) >>",
+		escape(d.Title), d.CreatedAt.UTC().Format("20060102150405Z")))
+
+	for i, lines := range pages {
+		var content bytes.Buffer
+		fmt.Fprintf(&content, "BT\n/F1 %d Tf\n%d TL\n%d %d Td\n", fontSize, leading, margin, pageHeight-margin)
+		for _, line := range lines {
+			fmt.Fprintf(&content, "(%s) Tj T*\n", escape(line))
+		}
+		content.WriteString("ET")
+
+		writeObject(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 3 0 R >> >> /Contents %d 0 R >>",
+			pageWidth, pageHeight, 6+2*i))
+		writeObject(fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", content.Len(), content.String()))
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R /Info 4 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	return buf.Bytes()
+}
+
+// escape turns text into the body of a PDF string literal. Latin-1 characters
+// are kept as octal escapes, anything else becomes '?'.
+func escape(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r >= 32 && r < 127:
+			b.WriteRune(r)
+		case r >= 0xA0 && r <= 0xFF:
+			fmt.Fprintf(&b, "\\%03o", r)
+		default:
+			b.WriteByte('?')
+		}
+	}
+	return b.String()
+}
+
+// wrap breaks text into lines of at most width characters, repeating the
+// indentation of the text on every line.
+func wrap(text string, width int) []string {
+	if utf8.RuneCountInString(text) <= width {
+		return []string{text}
+	}
+
+	body := strings.TrimLeft(text, " ")
+	if indent := text[:len(text)-len(body)]; indent != "" && len(indent) < width/2 {
+		lines := wrap(body, width-len(indent))
+		for i := range lines {
+			lines[i] = indent + lines[i]
+		}
+		return lines
+	}
+
+	var lines []string
+	var current []rune
+	for _, word := range strings.Split(text, " ") {
+		runes := []rune(word)
+		for len(runes) > width {
+			if len(current) > 0 {
+				lines = append(lines, string(current))
+				current = nil
+			}
+			lines = append(lines, string(runes[:width]))
+			runes = runes[width:]
+		}
+		if len(current) > 0 && len(current)+1+len(runes) > width {
+			lines = append(lines, string(current))
+			current = nil
+		}
+		if len(current) > 0 {
+			current = append(current, ' ')
+		}
+		current = append(current, runes...)
+	}
+	return append(lines, string(current))
+}
@@ -26,3 +26,12 @@ func Error(c *gin.Context, status int, message string, error string) {
 		Error:   error,
 	})
 }
+
+func ErrorWithData(c *gin.Context, status int, message string, error string, data interface{}) {
+	c.JSON(status, APIResponse{
+		Success: false,
+		Message: message,
+		Data:    data,
+		Error:   error,
+	})
+}
@@ -5,6 +5,9 @@ import (
 	"pt-xyz-multifinance/pkg/constants"
 	"regexp"
 	"sort"
+	"strconv"
+	"strings"
+	"sync"
 	"unicode"
 )
 
@@ -55,21 +58,73 @@ func IsValidEmail(email string) bool {
 	return emailRegex.MatchString(email)
 }
 
+// TenorProvider returns the tenors currently offered. The product catalog
+// installs one at startup; until then the built-in tenors apply.
+type TenorProvider func() []int
+
+var (
+	tenorMu       sync.RWMutex
+	tenorProvider TenorProvider = constants.GetValidTenors
+)
+
+func SetTenorProvider(provider TenorProvider) {
+	tenorMu.Lock()
+	defer tenorMu.Unlock()
+	tenorProvider = provider
+}
+
+// ValidTenors returns the offered tenors in ascending order.
+func ValidTenors() []int {
+	tenorMu.RLock()
+	provider := tenorProvider
+	tenorMu.RUnlock()
+
+	tenors := provider()
+	if len(tenors) == 0 {
+		tenors = constants.GetValidTenors()
+	}
+
+	sorted := make([]int, len(tenors))
+	copy(sorted, tenors)
+	sort.Ints(sorted)
+	return sorted
+}
+
+func IsValidTenor(tenor int) bool {
+	for _, validTenor := range ValidTenors() {
+		if tenor == validTenor {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidTenorsString returns the offered tenors as a comma-separated string.
+func ValidTenorsString() string {
+	tenors := ValidTenors()
+	parts := make([]string, len(tenors))
+	for i, tenor := range tenors {
+		parts[i] = strconv.Itoa(tenor)
+	}
+	return strings.Join(parts, ", ")
+}
+
 func ValidateTenor(tenor int) error {
-	if !constants.IsValidTenor(tenor) {
-		return fmt.Errorf("invalid tenor %d months, only 1, 2, 3, 4 months allowed", tenor)
+	if !IsValidTenor(tenor) {
+		return fmt.Errorf("invalid tenor %d months, only %s months allowed", tenor, ValidTenorsString())
 	}
 	return nil
 }
 
 func ValidateCompleteTenors(tenors []int) error {
+	requiredTenors := ValidTenors()
 
-	if len(tenors) != 4 {
+	if len(tenors) != len(requiredTenors) {
 		missing := getMissingTenors(tenors)
 		if len(missing) > 0 {
-			return fmt.Errorf("incomplete tenors provided. Missing tenors: %v. All tenors (1,2,3,4) are required", missing)
+			return fmt.Errorf("incomplete tenors provided. Missing tenors: %v. All tenors (%s) are required", missing, ValidTenorsString())
 		}
-		return fmt.Errorf("exactly 4 tenors (1,2,3,4) are required, got %d", len(tenors))
+		return fmt.Errorf("exactly %d tenors (%s) are required, got %d", len(requiredTenors), ValidTenorsString(), len(tenors))
 	}
 
 	for _, tenor := range tenors {
@@ -81,16 +136,15 @@ func ValidateCompleteTenors(tenors []int) error {
 	tenorMap := make(map[int]bool)
 	for _, tenor := range tenors {
 		if tenorMap[tenor] {
-			return fmt.Errorf("duplicate tenor %d found. Each tenor (1,2,3,4) must appear exactly once", tenor)
+			return fmt.Errorf("duplicate tenor %d found. Each tenor (%s) must appear exactly once", tenor, ValidTenorsString())
 		}
 		tenorMap[tenor] = true
 	}
 
-	requiredTenors := constants.GetValidTenors()
 	for _, required := range requiredTenors {
 		if !tenorMap[required] {
 			missing := getMissingTenors(tenors)
-			return fmt.Errorf("missing required tenor %d. All tenors (1,2,3,4) are mandatory. Missing: %v", required, missing)
+			return fmt.Errorf("missing required tenor %d. All tenors (%s) are mandatory. Missing: %v", required, ValidTenorsString(), missing)
 		}
 	}
 
@@ -98,7 +152,7 @@ func ValidateCompleteTenors(tenors []int) error {
 }
 
 func getMissingTenors(providedTenors []int) []int {
-	requiredTenors := constants.GetValidTenors()
+	requiredTenors := ValidTenors()
 	providedMap := make(map[int]bool)
 
 	for _, tenor := range providedTenors {
@@ -116,21 +170,30 @@ func getMissingTenors(providedTenors []int) []int {
 	return missing
 }
 
+// ValidateTenorLimits checks the limits supplied: each tenor must be offered,
+// appear once and have a positive amount. Offered tenors left out get the
+// recommended limit.
 func ValidateTenorLimits(tenorLimits []TenorLimit) error {
 	if len(tenorLimits) == 0 {
 		return fmt.Errorf("at least one tenor limit is required")
 	}
 
-	tenors := make([]int, len(tenorLimits))
-	for i, tl := range tenorLimits {
-		tenors[i] = tl.Tenor
+	seen := make(map[int]bool, len(tenorLimits))
+	for _, tl := range tenorLimits {
+		if err := ValidateTenor(tl.Tenor); err != nil {
+			return err
+		}
+		if seen[tl.Tenor] {
+			return fmt.Errorf("duplicate tenor %d found, each tenor may appear only once", tl.Tenor)
+		}
+		seen[tl.Tenor] = true
 
 		if tl.LimitAmount <= 0 {
 			return fmt.Errorf("limit amount must be greater than 0 for tenor %d months", tl.Tenor)
 		}
 	}
 
-	return ValidateCompleteTenors(tenors)
+	return nil
 }
 
 type TenorLimit struct {
@@ -2,7 +2,9 @@ package mocks
 
 import (
 	"context"
+	"io"
 	"pt-xyz-multifinance/internal/domain/entity"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -74,6 +76,14 @@ func (m *MockCustomerRepository) GetByID(ctx context.Context, id uint64) (*entit
 	return args.Get(0).(*entity.Customer), args.Error(1)
 }
 
+func (m *MockCustomerRepository) GetByIDForUpdate(ctx context.Context, id uint64) (*entity.Customer, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Customer), args.Error(1)
+}
+
 func (m *MockCustomerRepository) GetByUserID(ctx context.Context, userID uint64) (*entity.Customer, error) {
 	args := m.Called(ctx, userID)
 	if args.Get(0) == nil {
@@ -105,6 +115,21 @@ func (m *MockCustomerRepository) GetAll(ctx context.Context, limit, offset int)
 	return args.Get(0).([]*entity.Customer), args.Error(1)
 }
 
+func (m *MockCustomerRepository) AddCreditBalance(ctx context.Context, id uint64, amount float64) error {
+	args := m.Called(ctx, id, amount)
+	return args.Error(0)
+}
+
+func (m *MockCustomerRepository) GetByKYCStatus(ctx context.Context, status entity.KYCStatus, limit, offset int) ([]*entity.Customer, error) {
+	args := m.Called(ctx, status, limit, offset)
+	return args.Get(0).([]*entity.Customer), args.Error(1)
+}
+
+func (m *MockCustomerRepository) GetDuplicateCandidates(ctx context.Context, customer *entity.Customer, limit int) ([]*entity.Customer, error) {
+	args := m.Called(ctx, customer, limit)
+	return args.Get(0).([]*entity.Customer), args.Error(1)
+}
+
 type MockLimitRepository struct {
 	mock.Mock
 }
@@ -122,6 +147,14 @@ func (m *MockLimitRepository) GetByCustomerAndTenor(ctx context.Context, custome
 	return args.Get(0).(*entity.CustomerLimit), args.Error(1)
 }
 
+func (m *MockLimitRepository) GetByCustomerAndTenorForUpdate(ctx context.Context, customerID uint64, tenorMonths int) (*entity.CustomerLimit, error) {
+	args := m.Called(ctx, customerID, tenorMonths)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.CustomerLimit), args.Error(1)
+}
+
 func (m *MockLimitRepository) GetByCustomerID(ctx context.Context, customerID uint64) ([]*entity.CustomerLimit, error) {
 	args := m.Called(ctx, customerID)
 	return args.Get(0).([]*entity.CustomerLimit), args.Error(1)
@@ -132,11 +165,26 @@ func (m *MockLimitRepository) Update(ctx context.Context, limit *entity.Customer
 	return args.Error(0)
 }
 
-func (m *MockLimitRepository) UpdateUsedAmount(ctx context.Context, customerID uint64, tenorMonths int, amount float64) error {
-	args := m.Called(ctx, customerID, tenorMonths, amount)
+func (m *MockLimitRepository) UpdateUsedAmount(ctx context.Context, movement *entity.LimitMovement) error {
+	args := m.Called(ctx, movement)
 	return args.Error(0)
 }
 
+func (m *MockLimitRepository) GetMovements(ctx context.Context, customerID uint64, tenorMonths int) ([]*entity.LimitMovement, error) {
+	args := m.Called(ctx, customerID, tenorMonths)
+	return args.Get(0).([]*entity.LimitMovement), args.Error(1)
+}
+
+func (m *MockLimitRepository) UpdateLimitAmount(ctx context.Context, change *entity.LimitChange) error {
+	args := m.Called(ctx, change)
+	return args.Error(0)
+}
+
+func (m *MockLimitRepository) GetLimitChanges(ctx context.Context, customerID uint64, tenorMonths int) ([]*entity.LimitChange, error) {
+	args := m.Called(ctx, customerID, tenorMonths)
+	return args.Get(0).([]*entity.LimitChange), args.Error(1)
+}
+
 func (m *MockLimitRepository) Delete(ctx context.Context, id uint64) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
@@ -159,6 +207,14 @@ func (m *MockTransactionRepository) GetByID(ctx context.Context, id uint64) (*en
 	return args.Get(0).(*entity.Transaction), args.Error(1)
 }
 
+func (m *MockTransactionRepository) GetByIDForUpdate(ctx context.Context, id uint64) (*entity.Transaction, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Transaction), args.Error(1)
+}
+
 func (m *MockTransactionRepository) GetByContractNumber(ctx context.Context, contractNumber string) (*entity.Transaction, error) {
 	args := m.Called(ctx, contractNumber)
 	if args.Get(0) == nil {
@@ -186,3 +242,514 @@ func (m *MockTransactionRepository) GetAll(ctx context.Context, limit, offset in
 	args := m.Called(ctx, limit, offset)
 	return args.Get(0).([]*entity.Transaction), args.Error(1)
 }
+
+func (m *MockTransactionRepository) GetByStatuses(ctx context.Context, statuses []entity.TransactionStatus) ([]*entity.Transaction, error) {
+	args := m.Called(ctx, statuses)
+	return args.Get(0).([]*entity.Transaction), args.Error(1)
+}
+
+type MockInstallmentScheduleRepository struct {
+	mock.Mock
+}
+
+func (m *MockInstallmentScheduleRepository) CreateBatch(ctx context.Context, installments []*entity.InstallmentSchedule) error {
+	args := m.Called(ctx, installments)
+	return args.Error(0)
+}
+
+func (m *MockInstallmentScheduleRepository) GetByTransactionID(ctx context.Context, transactionID uint64) ([]*entity.InstallmentSchedule, error) {
+	args := m.Called(ctx, transactionID)
+	return args.Get(0).([]*entity.InstallmentSchedule), args.Error(1)
+}
+
+func (m *MockInstallmentScheduleRepository) GetByTransactionIDForUpdate(ctx context.Context, transactionID uint64) ([]*entity.InstallmentSchedule, error) {
+	args := m.Called(ctx, transactionID)
+	return args.Get(0).([]*entity.InstallmentSchedule), args.Error(1)
+}
+
+func (m *MockInstallmentScheduleRepository) Update(ctx context.Context, installment *entity.InstallmentSchedule) error {
+	args := m.Called(ctx, installment)
+	return args.Error(0)
+}
+
+type MockPaymentRepository struct {
+	mock.Mock
+}
+
+func (m *MockPaymentRepository) Create(ctx context.Context, payment *entity.Payment) error {
+	args := m.Called(ctx, payment)
+	return args.Error(0)
+}
+
+func (m *MockPaymentRepository) GetByID(ctx context.Context, id uint64) (*entity.Payment, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Payment), args.Error(1)
+}
+
+func (m *MockPaymentRepository) GetByTransactionID(ctx context.Context, transactionID uint64) ([]*entity.Payment, error) {
+	args := m.Called(ctx, transactionID)
+	return args.Get(0).([]*entity.Payment), args.Error(1)
+}
+
+type MockLimitChangeRequestRepository struct {
+	mock.Mock
+}
+
+func (m *MockLimitChangeRequestRepository) Create(ctx context.Context, request *entity.LimitChangeRequest) error {
+	args := m.Called(ctx, request)
+	return args.Error(0)
+}
+
+func (m *MockLimitChangeRequestRepository) GetByID(ctx context.Context, id uint64) (*entity.LimitChangeRequest, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.LimitChangeRequest), args.Error(1)
+}
+
+func (m *MockLimitChangeRequestRepository) GetByIDForUpdate(ctx context.Context, id uint64) (*entity.LimitChangeRequest, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.LimitChangeRequest), args.Error(1)
+}
+
+func (m *MockLimitChangeRequestRepository) GetByCustomerID(ctx context.Context, customerID uint64) ([]*entity.LimitChangeRequest, error) {
+	args := m.Called(ctx, customerID)
+	return args.Get(0).([]*entity.LimitChangeRequest), args.Error(1)
+}
+
+func (m *MockLimitChangeRequestRepository) GetByStatus(ctx context.Context, status entity.LimitChangeRequestStatus, limit, offset int) ([]*entity.LimitChangeRequest, error) {
+	args := m.Called(ctx, status, limit, offset)
+	return args.Get(0).([]*entity.LimitChangeRequest), args.Error(1)
+}
+
+func (m *MockLimitChangeRequestRepository) Update(ctx context.Context, request *entity.LimitChangeRequest) error {
+	args := m.Called(ctx, request)
+	return args.Error(0)
+}
+
+type MockProductRepository struct {
+	mock.Mock
+}
+
+func (m *MockProductRepository) Create(ctx context.Context, product *entity.Product) error {
+	args := m.Called(ctx, product)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) GetByID(ctx context.Context, id uint64) (*entity.Product, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) GetByCode(ctx context.Context, code string) (*entity.Product, error) {
+	args := m.Called(ctx, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) GetAll(ctx context.Context) ([]*entity.Product, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]*entity.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) GetActive(ctx context.Context) ([]*entity.Product, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]*entity.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) Update(ctx context.Context, product *entity.Product) error {
+	args := m.Called(ctx, product)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) Delete(ctx context.Context, id uint64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+type MockRateCardRepository struct {
+	mock.Mock
+}
+
+func (m *MockRateCardRepository) CreateVersion(ctx context.Context, card *entity.RateCard) error {
+	args := m.Called(ctx, card)
+	return args.Error(0)
+}
+
+func (m *MockRateCardRepository) GetByID(ctx context.Context, id uint64) (*entity.RateCard, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.RateCard), args.Error(1)
+}
+
+func (m *MockRateCardRepository) GetByProductID(ctx context.Context, productID uint64) ([]*entity.RateCard, error) {
+	args := m.Called(ctx, productID)
+	return args.Get(0).([]*entity.RateCard), args.Error(1)
+}
+
+func (m *MockRateCardRepository) GetEffective(ctx context.Context, productID uint64, tenorMonths int, assetType entity.AssetType, at time.Time) (*entity.RateCard, error) {
+	args := m.Called(ctx, productID, tenorMonths, assetType, at)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.RateCard), args.Error(1)
+}
+
+type MockAdminFeeRuleRepository struct {
+	mock.Mock
+}
+
+func (m *MockAdminFeeRuleRepository) Create(ctx context.Context, rule *entity.AdminFeeRule) error {
+	args := m.Called(ctx, rule)
+	return args.Error(0)
+}
+
+func (m *MockAdminFeeRuleRepository) GetByID(ctx context.Context, id uint64) (*entity.AdminFeeRule, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.AdminFeeRule), args.Error(1)
+}
+
+func (m *MockAdminFeeRuleRepository) GetAll(ctx context.Context) ([]*entity.AdminFeeRule, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]*entity.AdminFeeRule), args.Error(1)
+}
+
+func (m *MockAdminFeeRuleRepository) GetActive(ctx context.Context) ([]*entity.AdminFeeRule, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]*entity.AdminFeeRule), args.Error(1)
+}
+
+func (m *MockAdminFeeRuleRepository) Supersede(ctx context.Context, previousID uint64, rule *entity.AdminFeeRule) error {
+	args := m.Called(ctx, previousID, rule)
+	return args.Error(0)
+}
+
+func (m *MockAdminFeeRuleRepository) Deactivate(ctx context.Context, id uint64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+type MockContractSequenceRepository struct {
+	mock.Mock
+}
+
+func (m *MockContractSequenceRepository) Next(ctx context.Context, scope string) (uint64, error) {
+	args := m.Called(ctx, scope)
+	return args.Get(0).(uint64), args.Error(1)
+}
+
+type MockContractTemplateRepository struct {
+	mock.Mock
+}
+
+func (m *MockContractTemplateRepository) Create(ctx context.Context, template *entity.ContractTemplate) error {
+	args := m.Called(ctx, template)
+	return args.Error(0)
+}
+
+func (m *MockContractTemplateRepository) GetByID(ctx context.Context, id uint64) (*entity.ContractTemplate, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ContractTemplate), args.Error(1)
+}
+
+func (m *MockContractTemplateRepository) GetAll(ctx context.Context) ([]*entity.ContractTemplate, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]*entity.ContractTemplate), args.Error(1)
+}
+
+func (m *MockContractTemplateRepository) GetLatestActive(ctx context.Context) (*entity.ContractTemplate, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ContractTemplate), args.Error(1)
+}
+
+func (m *MockContractTemplateRepository) Update(ctx context.Context, template *entity.ContractTemplate) error {
+	args := m.Called(ctx, template)
+	return args.Error(0)
+}
+
+type MockContractDocumentRepository struct {
+	mock.Mock
+}
+
+func (m *MockContractDocumentRepository) Create(ctx context.Context, document *entity.ContractDocument) error {
+	args := m.Called(ctx, document)
+	return args.Error(0)
+}
+
+func (m *MockContractDocumentRepository) GetByTransactionID(ctx context.Context, transactionID uint64) (*entity.ContractDocument, error) {
+	args := m.Called(ctx, transactionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ContractDocument), args.Error(1)
+}
+
+type MockFileStorage struct {
+	mock.Mock
+}
+
+func (m *MockFileStorage) Save(ctx context.Context, key string, content io.Reader) (int64, error) {
+	data, _ := io.ReadAll(content)
+	args := m.Called(ctx, key, data)
+	return int64(len(data)), args.Error(0)
+}
+
+func (m *MockFileStorage) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	args := m.Called(ctx, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(io.ReadCloser), args.Error(1)
+}
+
+func (m *MockFileStorage) Delete(ctx context.Context, key string) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
+
+func (m *MockFileStorage) SignedURL(key string, ttl time.Duration) (string, time.Time, error) {
+	args := m.Called(key, ttl)
+	return args.String(0), args.Get(1).(time.Time), args.Error(2)
+}
+
+func (m *MockFileStorage) VerifySignature(key string, expires int64, signature string) error {
+	args := m.Called(key, expires, signature)
+	return args.Error(0)
+}
+
+type MockWatchlistRepository struct {
+	mock.Mock
+}
+
+func (m *MockWatchlistRepository) Create(ctx context.Context, entry *entity.Watchlist) error {
+	args := m.Called(ctx, entry)
+	return args.Error(0)
+}
+
+func (m *MockWatchlistRepository) GetByID(ctx context.Context, id uint64) (*entity.Watchlist, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Watchlist), args.Error(1)
+}
+
+func (m *MockWatchlistRepository) GetAll(ctx context.Context, limit, offset int) ([]*entity.Watchlist, error) {
+	args := m.Called(ctx, limit, offset)
+	return args.Get(0).([]*entity.Watchlist), args.Error(1)
+}
+
+func (m *MockWatchlistRepository) GetByNIKAndSource(ctx context.Context, nik, source string) (*entity.Watchlist, error) {
+	args := m.Called(ctx, nik, source)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Watchlist), args.Error(1)
+}
+
+func (m *MockWatchlistRepository) GetActiveByNIK(ctx context.Context, nik string, at time.Time) ([]*entity.Watchlist, error) {
+	args := m.Called(ctx, nik, at)
+	return args.Get(0).([]*entity.Watchlist), args.Error(1)
+}
+
+func (m *MockWatchlistRepository) Update(ctx context.Context, entry *entity.Watchlist) error {
+	args := m.Called(ctx, entry)
+	return args.Error(0)
+}
+
+func (m *MockWatchlistRepository) Delete(ctx context.Context, id uint64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+type MockWatchlistHitRepository struct {
+	mock.Mock
+}
+
+func (m *MockWatchlistHitRepository) Create(ctx context.Context, hit *entity.WatchlistHit) error {
+	args := m.Called(ctx, hit)
+	return args.Error(0)
+}
+
+func (m *MockWatchlistHitRepository) GetByID(ctx context.Context, id uint64) (*entity.WatchlistHit, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.WatchlistHit), args.Error(1)
+}
+
+func (m *MockWatchlistHitRepository) GetByStatus(ctx context.Context, status entity.WatchlistHitStatus, limit, offset int) ([]*entity.WatchlistHit, error) {
+	args := m.Called(ctx, status, limit, offset)
+	return args.Get(0).([]*entity.WatchlistHit), args.Error(1)
+}
+
+func (m *MockWatchlistHitRepository) CountHoldsByCustomer(ctx context.Context, customerID uint64) (int64, error) {
+	args := m.Called(ctx, customerID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockWatchlistHitRepository) CountHoldsByTransaction(ctx context.Context, transactionID uint64) (int64, error) {
+	args := m.Called(ctx, transactionID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockWatchlistHitRepository) Update(ctx context.Context, hit *entity.WatchlistHit) error {
+	args := m.Called(ctx, hit)
+	return args.Error(0)
+}
+
+type MockCustomerDuplicateRepository struct {
+	mock.Mock
+}
+
+func (m *MockCustomerDuplicateRepository) Create(ctx context.Context, duplicate *entity.CustomerDuplicate) error {
+	args := m.Called(ctx, duplicate)
+	return args.Error(0)
+}
+
+func (m *MockCustomerDuplicateRepository) GetByID(ctx context.Context, id uint64) (*entity.CustomerDuplicate, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.CustomerDuplicate), args.Error(1)
+}
+
+func (m *MockCustomerDuplicateRepository) GetByPair(ctx context.Context, customerID, matchedCustomerID uint64) (*entity.CustomerDuplicate, error) {
+	args := m.Called(ctx, customerID, matchedCustomerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.CustomerDuplicate), args.Error(1)
+}
+
+func (m *MockCustomerDuplicateRepository) GetByStatus(ctx context.Context, status entity.DuplicateStatus) ([]*entity.CustomerDuplicate, error) {
+	args := m.Called(ctx, status)
+	return args.Get(0).([]*entity.CustomerDuplicate), args.Error(1)
+}
+
+func (m *MockCustomerDuplicateRepository) CountHoldsByCustomer(ctx context.Context, customerID uint64) (int64, error) {
+	args := m.Called(ctx, customerID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockCustomerDuplicateRepository) Update(ctx context.Context, duplicate *entity.CustomerDuplicate) error {
+	args := m.Called(ctx, duplicate)
+	return args.Error(0)
+}
+
+type MockPartnerRepository struct {
+	mock.Mock
+}
+
+func (m *MockPartnerRepository) Create(ctx context.Context, partner *entity.Partner) error {
+	args := m.Called(ctx, partner)
+	return args.Error(0)
+}
+
+func (m *MockPartnerRepository) GetByID(ctx context.Context, id uint64) (*entity.Partner, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Partner), args.Error(1)
+}
+
+func (m *MockPartnerRepository) GetAll(ctx context.Context, limit, offset int) ([]*entity.Partner, error) {
+	args := m.Called(ctx, limit, offset)
+	return args.Get(0).([]*entity.Partner), args.Error(1)
+}
+
+func (m *MockPartnerRepository) Update(ctx context.Context, partner *entity.Partner) error {
+	args := m.Called(ctx, partner)
+	return args.Error(0)
+}
+
+func (m *MockPartnerRepository) LinkCustomer(ctx context.Context, link *entity.PartnerCustomer) error {
+	args := m.Called(ctx, link)
+	return args.Error(0)
+}
+
+func (m *MockPartnerRepository) UnlinkCustomer(ctx context.Context, partnerID, customerID uint64) error {
+	args := m.Called(ctx, partnerID, customerID)
+	return args.Error(0)
+}
+
+func (m *MockPartnerRepository) IsCustomerLinked(ctx context.Context, partnerID, customerID uint64) (bool, error) {
+	args := m.Called(ctx, partnerID, customerID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockPartnerRepository) GetCustomerLinks(ctx context.Context, customerID uint64) ([]*entity.PartnerCustomer, error) {
+	args := m.Called(ctx, customerID)
+	return args.Get(0).([]*entity.PartnerCustomer), args.Error(1)
+}
+
+type MockPartnerCredentialRepository struct {
+	mock.Mock
+}
+
+func (m *MockPartnerCredentialRepository) Create(ctx context.Context, credential *entity.PartnerCredential) error {
+	args := m.Called(ctx, credential)
+	return args.Error(0)
+}
+
+func (m *MockPartnerCredentialRepository) GetByID(ctx context.Context, id uint64) (*entity.PartnerCredential, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.PartnerCredential), args.Error(1)
+}
+
+func (m *MockPartnerCredentialRepository) GetByAPIKey(ctx context.Context, apiKey string) (*entity.PartnerCredential, error) {
+	args := m.Called(ctx, apiKey)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.PartnerCredential), args.Error(1)
+}
+
+func (m *MockPartnerCredentialRepository) GetByPartnerID(ctx context.Context, partnerID uint64) ([]*entity.PartnerCredential, error) {
+	args := m.Called(ctx, partnerID)
+	return args.Get(0).([]*entity.PartnerCredential), args.Error(1)
+}
+
+func (m *MockPartnerCredentialRepository) ExpireActive(ctx context.Context, partnerID uint64, expiresAt time.Time) error {
+	args := m.Called(ctx, partnerID, expiresAt)
+	return args.Error(0)
+}
+
+func (m *MockPartnerCredentialRepository) Update(ctx context.Context, credential *entity.PartnerCredential) error {
+	args := m.Called(ctx, credential)
+	return args.Error(0)
+}
+
+func (m *MockPartnerCredentialRepository) TouchLastUsed(ctx context.Context, id uint64, at time.Time) error {
+	args := m.Called(ctx, id, at)
+	return args.Error(0)
+}
@@ -28,7 +28,7 @@ func (suite *RepositoryTestSuite) SetupTest() {
 	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
 	suite.Require().NoError(err)
 
-	err = db.AutoMigrate(&entity.User{}, &entity.Customer{}, &entity.CustomerLimit{}, &entity.Transaction{})
+	err = db.AutoMigrate(&entity.User{}, &entity.Customer{}, &entity.CustomerLimit{}, &entity.LimitMovement{}, &entity.Transaction{})
 	suite.Require().NoError(err)
 
 	suite.db = db
@@ -150,7 +150,12 @@ func (suite *RepositoryTestSuite) TestLimitRepository() {
 	assert.NoError(suite.T(), err)
 	assert.Equal(suite.T(), limit.LimitAmount, retrievedLimit.LimitAmount)
 
-	err = suite.limitRepo.UpdateUsedAmount(ctx, customer.ID, 1, 500000)
+	err = suite.limitRepo.UpdateUsedAmount(ctx, &entity.LimitMovement{
+		CustomerID:  customer.ID,
+		TenorMonths: 1,
+		Type:        entity.MovementReserve,
+		Amount:      500000,
+	})
 	assert.NoError(suite.T(), err)
 
 	updatedLimit, err := suite.limitRepo.GetByCustomerAndTenor(ctx, customer.ID, 1)
@@ -221,3 +226,168 @@ func (suite *RepositoryTestSuite) TestTransactionRepository() {
 func TestRepositoryTestSuite(t *testing.T) {
 	suite.Run(t, new(RepositoryTestSuite))
 }
+
+// LimitLedgerTestSuite covers the limit ledger on its own tables only. They
+// are created by hand: migrating CustomerLimit pulls in the users table, whose
+// MySQL enum column SQLite cannot parse.
+type LimitLedgerTestSuite struct {
+	suite.Suite
+	db        *gorm.DB
+	limitRepo repository.LimitRepository
+}
+
+func (suite *LimitLedgerTestSuite) SetupTest() {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	suite.Require().NoError(err)
+
+	suite.Require().NoError(db.Exec(`CREATE TABLE customer_limits (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		customer_id INTEGER NOT NULL,
+		tenor_months INTEGER NOT NULL,
+		limit_amount DECIMAL(15,2) NOT NULL,
+		used_amount DECIMAL(15,2) DEFAULT 0,
+		created_at DATETIME,
+		updated_at DATETIME
+	)`).Error)
+	suite.Require().NoError(db.AutoMigrate(&entity.LimitMovement{}))
+
+	suite.db = db
+	suite.limitRepo = repoImpl.NewLimitRepository(db)
+}
+
+func (suite *LimitLedgerTestSuite) TestUpdateUsedAmountOpensLedgerForExistingUsage() {
+	ctx := context.Background()
+
+	limit := &entity.CustomerLimit{CustomerID: 1, TenorMonths: 3, LimitAmount: 1000000, UsedAmount: 300000}
+	suite.Require().NoError(suite.limitRepo.Create(ctx, limit))
+
+	err := suite.limitRepo.UpdateUsedAmount(ctx, &entity.LimitMovement{
+		CustomerID:  1,
+		TenorMonths: 3,
+		Type:        entity.MovementReserve,
+		Amount:      200000,
+	})
+	assert.NoError(suite.T(), err)
+
+	movements, err := suite.limitRepo.GetMovements(ctx, 1, 3)
+	assert.NoError(suite.T(), err)
+	if assert.Len(suite.T(), movements, 2) {
+		assert.Equal(suite.T(), entity.MovementManualAdjustment, movements[0].Type)
+		assert.Equal(suite.T(), float64(300000), movements[0].Amount)
+		assert.Equal(suite.T(), float64(500000), movements[1].UsedAmountAfter)
+	}
+
+	updated, err := suite.limitRepo.GetByCustomerAndTenor(ctx, 1, 3)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), float64(500000), updated.UsedAmount)
+}
+
+func (suite *LimitLedgerTestSuite) TestUpdateUsedAmountRejectsNegativeBalance() {
+	ctx := context.Background()
+
+	suite.Require().NoError(suite.limitRepo.Create(ctx, &entity.CustomerLimit{CustomerID: 1, TenorMonths: 1, LimitAmount: 1000000}))
+	suite.Require().NoError(suite.limitRepo.UpdateUsedAmount(ctx, &entity.LimitMovement{
+		CustomerID: 1, TenorMonths: 1, Type: entity.MovementReserve, Amount: 400000,
+	}))
+
+	err := suite.limitRepo.UpdateUsedAmount(ctx, &entity.LimitMovement{
+		CustomerID: 1, TenorMonths: 1, Type: entity.MovementRelease, Amount: -400001,
+	})
+	assert.ErrorContains(suite.T(), err, "would drop below zero")
+
+	err = suite.limitRepo.UpdateUsedAmount(ctx, &entity.LimitMovement{
+		CustomerID: 1, TenorMonths: 1, Type: entity.MovementReserve, Amount: 600001,
+	})
+	assert.ErrorContains(suite.T(), err, "would exceed limit amount")
+
+	movements, err := suite.limitRepo.GetMovements(ctx, 1, 1)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), movements, 1)
+}
+
+func (suite *LimitLedgerTestSuite) TestUpdateUsedAmountRefusesLedgerOutOfSync() {
+	ctx := context.Background()
+
+	limit := &entity.CustomerLimit{CustomerID: 1, TenorMonths: 2, LimitAmount: 1000000}
+	suite.Require().NoError(suite.limitRepo.Create(ctx, limit))
+	suite.Require().NoError(suite.limitRepo.UpdateUsedAmount(ctx, &entity.LimitMovement{
+		CustomerID: 1, TenorMonths: 2, Type: entity.MovementReserve, Amount: 250000,
+	}))
+
+	// Written around the ledger
+	suite.Require().NoError(suite.db.Model(&entity.CustomerLimit{}).Where("id = ?", limit.ID).Update("used_amount", 100000).Error)
+
+	err := suite.limitRepo.UpdateUsedAmount(ctx, &entity.LimitMovement{
+		CustomerID: 1, TenorMonths: 2, Type: entity.MovementRelease, Amount: -50000,
+	})
+	assert.ErrorContains(suite.T(), err, "limit ledger out of sync")
+
+	movements, err := suite.limitRepo.GetMovements(ctx, 1, 2)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), movements, 1)
+}
+
+func TestLimitLedgerTestSuite(t *testing.T) {
+	suite.Run(t, new(LimitLedgerTestSuite))
+}
+
+// CatalogTestSuite covers the catalog tables, which SQLite can migrate as is.
+type CatalogTestSuite struct {
+	suite.Suite
+	db          *gorm.DB
+	productRepo repository.ProductRepository
+	feeRuleRepo repository.AdminFeeRuleRepository
+}
+
+func (suite *CatalogTestSuite) SetupTest() {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	suite.Require().NoError(err)
+	suite.Require().NoError(db.AutoMigrate(&entity.Product{}, &entity.AdminFeeRule{}))
+
+	suite.db = db
+	suite.productRepo = repoImpl.NewProductRepository(db)
+	suite.feeRuleRepo = repoImpl.NewAdminFeeRuleRepository(db)
+}
+
+func (suite *CatalogTestSuite) TestProductCreatedInactiveStaysInactive() {
+	ctx := context.Background()
+
+	product := &entity.Product{
+		Code:               "GADGET",
+		Name:               "Gadget financing",
+		AllowedTenors:      []int{3, 6},
+		InterestRate:       24,
+		AdminFeeType:       entity.AdminFeeFlat,
+		AdminFeeValue:      50000,
+		EligibleAssetTypes: []entity.AssetType{entity.AssetWhiteGoods},
+		IsActive:           false,
+	}
+	suite.Require().NoError(suite.productRepo.Create(ctx, product))
+
+	stored, err := suite.productRepo.GetByID(ctx, product.ID)
+	assert.NoError(suite.T(), err)
+	assert.False(suite.T(), stored.IsActive)
+
+	active, err := suite.productRepo.GetActive(ctx)
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), active)
+}
+
+func (suite *CatalogTestSuite) TestAdminFeeRuleCreatedInactiveStaysInactive() {
+	ctx := context.Background()
+
+	rule := &entity.AdminFeeRule{Name: "Dealer promo", Type: entity.AdminFeeFlat, Value: 25000, IsActive: false}
+	suite.Require().NoError(suite.feeRuleRepo.Create(ctx, rule))
+
+	stored, err := suite.feeRuleRepo.GetByID(ctx, rule.ID)
+	assert.NoError(suite.T(), err)
+	assert.False(suite.T(), stored.IsActive)
+
+	active, err := suite.feeRuleRepo.GetActive(ctx)
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), active)
+}
+
+func TestCatalogTestSuite(t *testing.T) {
+	suite.Run(t, new(CatalogTestSuite))
+}
@@ -0,0 +1,81 @@
+package router
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"pt-xyz-multifinance/internal/domain/entity"
+	"pt-xyz-multifinance/internal/interfaces/api/handler"
+	"pt-xyz-multifinance/internal/interfaces/api/router"
+	"pt-xyz-multifinance/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+// customerAuth accepts any token as the customer with ID 1.
+type customerAuth struct {
+	usecase.AuthUseCase
+}
+
+func (customerAuth) ValidateToken(token string) (jwt.MapClaims, error) {
+	return jwt.MapClaims{"user_id": float64(10), "username": "customer", "role": string(entity.RoleCustomer)}, nil
+}
+
+func (customerAuth) GetCustomerFromUser(ctx context.Context, userID uint64) (*entity.Customer, error) {
+	return &entity.Customer{ID: 1, UserID: userID}, nil
+}
+
+// recordingLimitRequests records the upload it receives.
+type recordingLimitRequests struct {
+	usecase.LimitChangeRequestUseCase
+	customerID uint64
+	requestID  uint64
+}
+
+func (r *recordingLimitRequests) UploadDocument(ctx context.Context, customerID uint64, requestID uint64, content io.Reader, size int64) (*entity.LimitChangeRequest, error) {
+	r.customerID = customerID
+	r.requestID = requestID
+	return &entity.LimitChangeRequest{ID: requestID, CustomerID: customerID, Status: entity.LimitRequestPending}, nil
+}
+
+func (r *recordingLimitRequests) DocumentURL(request *entity.LimitChangeRequest) (string, time.Time, error) {
+	return "", time.Time{}, nil
+}
+
+func setupRouter(limitRequests usecase.LimitChangeRequestUseCase) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	router.SetupRoutes(r, nil, nil, nil, nil, nil, handler.NewLimitChangeRequestHandler(limitRequests, 1024),
+		nil, nil, nil, nil, nil, nil, nil, customerAuth{}, nil)
+	return r
+}
+
+func TestLimitRequestDocumentUploadPassesOwnershipCheck(t *testing.T) {
+	limitRequests := &recordingLimitRequests{}
+	r := setupRouter(limitRequests)
+
+	body := &bytes.Buffer{}
+	form := multipart.NewWriter(body)
+	part, err := form.CreateFormFile("file", "payslip.pdf")
+	assert.NoError(t, err)
+	_, _ = part.Write([]byte("%PDF-1.4\n"))
+	assert.NoError(t, form.Close())
+
+	// Request 5 belongs to customer 1; the request ID must not be taken for a customer ID
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/customers/me/limit-requests/5/document", body)
+	req.Header.Set("Authorization", "Bearer token")
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	assert.Equal(t, uint64(1), limitRequests.customerID)
+	assert.Equal(t, uint64(5), limitRequests.requestID)
+}
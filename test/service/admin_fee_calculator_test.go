@@ -0,0 +1,50 @@
+package service_test
+
+import (
+	"testing"
+
+	"pt-xyz-multifinance/internal/domain/entity"
+	"pt-xyz-multifinance/internal/domain/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalculateAdminFeeTieredWithCaps(t *testing.T) {
+	rule := &entity.AdminFeeRule{
+		Type: entity.AdminFeeTiered,
+		Tiers: []entity.AdminFeeTier{
+			{UpToOTR: 0, Type: entity.AdminFeePercentage, Value: 1},
+			{UpToOTR: 5000000, Type: entity.AdminFeeFlat, Value: 50000},
+		},
+		MinFee: 25000,
+		MaxFee: 500000,
+	}
+
+	fee, err := service.CalculateAdminFee(rule, 5000000)
+	require.NoError(t, err)
+	assert.Equal(t, float64(50000), fee)
+
+	fee, err = service.CalculateAdminFee(rule, 20000000)
+	require.NoError(t, err)
+	assert.Equal(t, float64(200000), fee)
+
+	fee, err = service.CalculateAdminFee(rule, 90000000)
+	require.NoError(t, err)
+	assert.Equal(t, float64(500000), fee, "capped at max fee")
+
+	rule.Tiers[1].Value = 10000
+	fee, err = service.CalculateAdminFee(rule, 1000000)
+	require.NoError(t, err)
+	assert.Equal(t, float64(25000), fee, "raised to min fee")
+}
+
+func TestCalculateAdminFeeUncoveredTier(t *testing.T) {
+	rule := &entity.AdminFeeRule{
+		Type:  entity.AdminFeeTiered,
+		Tiers: []entity.AdminFeeTier{{UpToOTR: 1000000, Type: entity.AdminFeeFlat, Value: 10000}},
+	}
+
+	_, err := service.CalculateAdminFee(rule, 2000000)
+	assert.Error(t, err)
+}
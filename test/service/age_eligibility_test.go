@@ -0,0 +1,41 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"pt-xyz-multifinance/internal/domain/entity"
+	"pt-xyz-multifinance/internal/domain/service"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckAgeEligibility(t *testing.T) {
+	product := &entity.Product{Code: "MOTOR", MinAge: 21, MaxAgeAtTenorEnd: 60}
+	asOf := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.NoError(t, service.CheckAgeEligibility(product, time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC), asOf, 12))
+
+	// Turns 21 the day after applying
+	err := service.CheckAgeEligibility(product, time.Date(2003, 6, 2, 0, 0, 0, 0, time.UTC), asOf, 1)
+	var ageErr *entity.AgeIneligibleError
+	if assert.ErrorAs(t, err, &ageErr) {
+		assert.Equal(t, entity.EligibilityBelowMinAge, ageErr.Code)
+		assert.Equal(t, 20, ageErr.Age)
+		assert.Equal(t, 21, ageErr.Limit)
+	}
+
+	// 59 today: a 12 month tenor ends at 60, a 24 month tenor at 61
+	birthDate := time.Date(1965, 1, 1, 0, 0, 0, 0, time.UTC)
+	assert.NoError(t, service.CheckAgeEligibility(product, birthDate, asOf, 12))
+
+	err = service.CheckAgeEligibility(product, birthDate, asOf, 24)
+	if assert.ErrorAs(t, err, &ageErr) {
+		assert.Equal(t, entity.EligibilityAboveMaxAgeAtTerm, ageErr.Code)
+		assert.Equal(t, 61, ageErr.Age)
+		assert.Equal(t, 24, ageErr.TenorMonths)
+	}
+
+	// Zero limits are not checked
+	assert.NoError(t, service.CheckAgeEligibility(&entity.Product{Code: "DEFAULT"}, asOf, asOf, 4))
+}
@@ -0,0 +1,39 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"pt-xyz-multifinance/internal/domain/service"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContractNumberFormat(t *testing.T) {
+	format := service.ContractNumberFormat{
+		Template:      "{prefix}-{date}-{branch}-{seq}-{check}",
+		Prefix:        "XYZ",
+		Branch:        "JKT",
+		SequenceWidth: 6,
+	}
+	date := time.Date(2024, 1, 31, 10, 0, 0, 0, time.UTC)
+
+	assert.NoError(t, format.Validate())
+	assert.Equal(t, "contract:XYZ:20240131:JKT", format.SequenceScope(date))
+
+	number := format.Format(date, 42)
+	assert.Equal(t, "XYZ-20240131-JKT-000042-6", number)
+	assert.True(t, service.ValidContractCheckDigit(number))
+	assert.False(t, service.ValidContractCheckDigit("XYZ-20240131-JKT-000043-6"))
+	assert.False(t, service.ValidContractCheckDigit("XYZ-20240131-JKT-000042-0"))
+}
+
+func TestContractNumberFormatValidate(t *testing.T) {
+	assert.Error(t, service.ContractNumberFormat{Template: "{prefix}-{check}", SequenceWidth: 6}.Validate())
+	assert.Error(t, service.ContractNumberFormat{Template: "{prefix}-{seq}", SequenceWidth: 6}.Validate())
+	assert.Error(t, service.ContractNumberFormat{Template: "{seq}{check}", SequenceWidth: 0}.Validate())
+
+	// Without {date} the sequence must not restart every day
+	format := service.ContractNumberFormat{Template: "{prefix}{seq}{check}", Prefix: "XYZ", SequenceWidth: 8}
+	assert.Equal(t, "contract:XYZ", format.SequenceScope(time.Now()))
+}
@@ -0,0 +1,56 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"pt-xyz-multifinance/internal/domain/entity"
+	"pt-xyz-multifinance/internal/domain/service"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDuplicateScorer(t *testing.T) {
+	scorer := service.NewDuplicateScorer(service.DuplicateScorePolicy{Threshold: 0.75, MaxNIKDistance: 2})
+
+	assert.Equal(t, "DOE JOHN", service.NormalizeName("Dr. John  Doe"))
+	assert.Equal(t, service.NormalizeName("Doe, John"), service.NormalizeName("JOHN DOE"))
+	assert.Equal(t, 1, service.EditDistance("3171010101900001", "3171010101900007"))
+	assert.Equal(t, 3, service.EditDistance("kitten", "sitting"))
+
+	existing := &entity.Customer{
+		NIK:        "3171010101900001",
+		FullName:   "John Doe",
+		LegalName:  "John Doe",
+		BirthPlace: "Jakarta",
+		BirthDate:  time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	// A re-registration with a mistyped NIK and the legal name written differently
+	typo := &entity.Customer{
+		NIK:        "3171010101900007",
+		FullName:   "Jon Doe",
+		LegalName:  "DOE, JOHN",
+		BirthPlace: "JAKARTA",
+		BirthDate:  time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	score := scorer.Score(typo, existing)
+	assert.Equal(t, 1.0, score.NameSimilarity)
+	assert.True(t, score.BirthDateMatch)
+	assert.True(t, score.BirthPlaceMatch)
+	assert.Equal(t, 1, score.NIKDistance)
+	assert.InDelta(t, 0.9167, score.Total, 0.0001)
+	assert.True(t, scorer.IsLikelyDuplicate(score))
+
+	// Someone else born the same day in the same district
+	neighbour := &entity.Customer{
+		NIK:        "3171014101900002",
+		FullName:   "Siti Rahma",
+		LegalName:  "Siti Rahma",
+		BirthPlace: "Bogor",
+		BirthDate:  time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	score = scorer.Score(neighbour, existing)
+	assert.False(t, score.BirthPlaceMatch)
+	assert.False(t, scorer.IsLikelyDuplicate(score))
+}
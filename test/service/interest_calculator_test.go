@@ -0,0 +1,67 @@
+package service_test
+
+import (
+	"testing"
+
+	"pt-xyz-multifinance/internal/domain/entity"
+	"pt-xyz-multifinance/internal/domain/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlatInterestCalculator(t *testing.T) {
+	calculator, err := service.NewInterestCalculator(entity.InterestFlat)
+	require.NoError(t, err)
+
+	result, err := calculator.Calculate(1200000, 24, 3)
+	require.NoError(t, err)
+
+	// 1,200,000 x 24% x 3/12
+	assert.Equal(t, float64(72000), result.TotalInterest)
+	assert.Equal(t, float64(424000), result.InstallmentAmount)
+	assert.Len(t, result.Periods, 3)
+	for _, period := range result.Periods {
+		assert.Equal(t, float64(400000), period.Principal)
+		assert.Equal(t, float64(24000), period.Interest)
+	}
+}
+
+func TestEffectiveInterestCalculator(t *testing.T) {
+	calculator, err := service.NewInterestCalculator(entity.InterestEffective)
+	require.NoError(t, err)
+
+	result, err := calculator.Calculate(1000000, 12, 4)
+	require.NoError(t, err)
+
+	// Annuity at 1% per month
+	assert.Equal(t, 256281.09, result.InstallmentAmount)
+	assert.Len(t, result.Periods, 4)
+
+	var principal, interest float64
+	for i, period := range result.Periods {
+		principal += period.Principal
+		interest += period.Interest
+		if i > 0 {
+			assert.Less(t, period.Interest, result.Periods[i-1].Interest, "interest should decline with the balance")
+		}
+	}
+	assert.InDelta(t, 1000000, principal, 0.001)
+	assert.InDelta(t, result.TotalInterest, interest, 0.001)
+	assert.Equal(t, float64(10000), result.Periods[0].Interest)
+}
+
+func TestEffectiveInterestCalculatorZeroRate(t *testing.T) {
+	calculator, err := service.NewInterestCalculator(entity.InterestEffective)
+	require.NoError(t, err)
+
+	result, err := calculator.Calculate(1000000, 0, 4)
+	require.NoError(t, err)
+	assert.Equal(t, float64(0), result.TotalInterest)
+	assert.Equal(t, float64(250000), result.InstallmentAmount)
+}
+
+func TestNewInterestCalculatorUnsupportedMethod(t *testing.T) {
+	_, err := service.NewInterestCalculator("DAILY")
+	assert.Error(t, err)
+}
@@ -0,0 +1,93 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"pt-xyz-multifinance/internal/domain/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimitRecommender(t *testing.T) {
+	multipliers, err := service.ParseTenorMultipliers([]string{"1:1", "2:1.5", "3:2", "4:2.5"})
+	require.NoError(t, err)
+
+	recommender := service.NewLimitRecommender(service.LimitRecommendationPolicy{
+		TenorMultipliers: multipliers,
+		YoungAge:         25,
+		YoungFactor:      0.8,
+		SeniorAge:        55,
+		SeniorFactor:     0.7,
+		RoundTo:          100000,
+		MaxLimit:         10000000,
+	})
+
+	asOf := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	// 30 years old: no age adjustment
+	recommendation, err := recommender.Recommend(5150000, time.Date(1994, 1, 15, 0, 0, 0, 0, time.UTC), asOf)
+	require.NoError(t, err)
+	assert.Equal(t, 30, recommendation.Age)
+	require.Len(t, recommendation.Limits, 4)
+	assert.Equal(t, 1, recommendation.Limits[0].TenorMonths)
+	assert.Equal(t, float64(5100000), recommendation.Limits[0].LimitAmount)
+	assert.Equal(t, float64(7700000), recommendation.Limits[1].LimitAmount)
+	assert.Equal(t, float64(10000000), recommendation.Limits[3].LimitAmount) // capped
+	assert.NotEmpty(t, recommendation.Limits[3].Reasoning)
+
+	// Turns 25 the day after asOf: still young
+	young, err := recommender.Recommend(5000000, time.Date(1999, 6, 2, 0, 0, 0, 0, time.UTC), asOf)
+	require.NoError(t, err)
+	assert.Equal(t, 24, young.Age)
+	assert.Equal(t, 0.8, young.AgeFactor)
+	assert.Equal(t, float64(4000000), young.Limits[0].LimitAmount)
+
+	_, err = recommender.Recommend(0, time.Date(1994, 1, 15, 0, 0, 0, 0, time.UTC), asOf)
+	assert.Error(t, err)
+}
+
+func TestLimitRecommenderNeverRoundsToZero(t *testing.T) {
+	recommender := service.NewLimitRecommender(service.LimitRecommendationPolicy{
+		TenorMultipliers: map[int]float64{1: 1, 2: 1.5},
+		YoungAge:         25,
+		YoungFactor:      0.8,
+		RoundTo:          100000,
+	})
+
+	asOf := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	// 120,000 x 0.8 = 96,000 for one month, 144,000 for two
+	recommendation, err := recommender.Recommend(120000, time.Date(2003, 1, 1, 0, 0, 0, 0, time.UTC), asOf)
+	require.NoError(t, err)
+	require.Len(t, recommendation.Limits, 2)
+	assert.Equal(t, float64(100000), recommendation.Limits[0].LimitAmount)
+	assert.Contains(t, recommendation.Limits[0].Reasoning, "raised to the minimum")
+	assert.Equal(t, float64(100000), recommendation.Limits[1].LimitAmount)
+	assert.NotContains(t, recommendation.Limits[1].Reasoning, "raised to the minimum")
+}
+
+func TestParseTenorMultipliersRejectsInvalidPairs(t *testing.T) {
+	_, err := service.ParseTenorMultipliers([]string{"1-1.5"})
+	assert.Error(t, err)
+
+	_, err = service.ParseTenorMultipliers([]string{"2:abc"})
+	assert.Error(t, err)
+}
+
+func TestLimitRecommenderCoversCatalogTenorsWithoutMultiplier(t *testing.T) {
+	recommender := service.NewLimitRecommender(service.LimitRecommendationPolicy{
+		TenorMultipliers: map[int]float64{1: 1, 3: 2},
+		Tenors:           func() []int { return []int{6, 1, 3} },
+		RoundTo:          100000,
+	})
+
+	recommendation, err := recommender.Recommend(5000000, time.Date(1994, 1, 15, 0, 0, 0, 0, time.UTC), time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.Len(t, recommendation.Limits, 3)
+	assert.Equal(t, 6, recommendation.Limits[2].TenorMonths)
+	// No 6 month multiplier: the 3 month one applies
+	assert.Equal(t, float64(10000000), recommendation.Limits[2].LimitAmount)
+	assert.Contains(t, recommendation.Limits[2].Reasoning, "3 month")
+}
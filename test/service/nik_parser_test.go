@@ -0,0 +1,55 @@
+package service_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"pt-xyz-multifinance/internal/domain/entity"
+	"pt-xyz-multifinance/internal/domain/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNIKParser(t *testing.T) {
+	parser := service.NewNIKParser(nil)
+
+	// Women have 40 added to the day of birth
+	info, err := parser.Parse("3273014508920003")
+	require.NoError(t, err)
+	assert.Equal(t, "32", info.ProvinceCode)
+	assert.Equal(t, "Jawa Barat", info.Province)
+	assert.Equal(t, "3273", info.RegencyCode)
+	assert.Equal(t, "327301", info.DistrictCode)
+	assert.Equal(t, entity.SexFemale, info.Sex)
+	assert.Equal(t, time.Date(1992, 8, 5, 0, 0, 0, 0, time.UTC), info.BirthDate)
+	assert.Equal(t, "0003", info.Serial)
+
+	info, err = parser.Validate("3171010101900001", time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, entity.SexMale, info.Sex)
+
+	_, err = parser.Validate("3171010101900001", time.Date(1990, 1, 2, 0, 0, 0, 0, time.UTC))
+	assert.Error(t, err, "birth date mismatch")
+
+	_, err = parser.Parse("9971010101900001")
+	assert.Error(t, err, "unknown province")
+
+	_, err = parser.Parse("3100010101900001")
+	assert.Error(t, err, "empty regency")
+
+	_, err = parser.Parse("3171013102900001")
+	assert.Error(t, err, "31 February")
+
+	// With a region directory regencies and districts must be listed
+	regions, err := service.ParseRegionCSV(strings.NewReader("31.71,KOTA ADM. JAKARTA SELATAN\n31.71.01,Jagakarsa\n"))
+	require.NoError(t, err)
+	parser = service.NewNIKParser(regions)
+
+	_, err = parser.Parse("3171010101900001")
+	assert.NoError(t, err)
+
+	_, err = parser.Parse("3171020101900001")
+	assert.Error(t, err, "unknown district")
+}
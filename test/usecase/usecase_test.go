@@ -1,11 +1,17 @@
 package usecase_test
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
 	"testing"
 	"time"
 
 	"pt-xyz-multifinance/internal/domain/entity"
+	"pt-xyz-multifinance/internal/domain/service"
+	"pt-xyz-multifinance/internal/infrastructure/database"
 	"pt-xyz-multifinance/internal/interfaces/dto"
 	"pt-xyz-multifinance/internal/usecase"
 	"pt-xyz-multifinance/test/mocks"
@@ -20,14 +26,40 @@ import (
 
 type UseCaseTestSuite struct {
 	suite.Suite
-	authUseCase        usecase.AuthUseCase
-	customerUseCase    usecase.CustomerUseCase
-	transactionUseCase usecase.TransactionUseCase
-	userRepo           *mocks.MockUserRepository
-	customerRepo       *mocks.MockCustomerRepository
-	limitRepo          *mocks.MockLimitRepository
-	transactionRepo    *mocks.MockTransactionRepository
-	db                 *gorm.DB
+	authUseCase         usecase.AuthUseCase
+	customerUseCase     usecase.CustomerUseCase
+	transactionUseCase  usecase.TransactionUseCase
+	scheduleUseCase     usecase.InstallmentScheduleUseCase
+	paymentUseCase      usecase.PaymentUseCase
+	delinquencyUseCase  usecase.DelinquencyUseCase
+	stateMachine        usecase.TransactionStateMachine
+	limitRequestUseCase usecase.LimitChangeRequestUseCase
+	productUseCase      usecase.ProductUseCase
+	contractUseCase     usecase.ContractDocumentUseCase
+	kycUseCase          usecase.KYCDocumentUseCase
+	watchlistUseCase    usecase.WatchlistUseCase
+	duplicateUseCase    usecase.CustomerDuplicateUseCase
+	partnerUseCase      usecase.PartnerUseCase
+	userRepo            *mocks.MockUserRepository
+	customerRepo        *mocks.MockCustomerRepository
+	limitRepo           *mocks.MockLimitRepository
+	transactionRepo     *mocks.MockTransactionRepository
+	scheduleRepo        *mocks.MockInstallmentScheduleRepository
+	paymentRepo         *mocks.MockPaymentRepository
+	limitRequestRepo    *mocks.MockLimitChangeRequestRepository
+	productRepo         *mocks.MockProductRepository
+	rateCardRepo        *mocks.MockRateCardRepository
+	adminFeeRuleRepo    *mocks.MockAdminFeeRuleRepository
+	contractSeqRepo     *mocks.MockContractSequenceRepository
+	templateRepo        *mocks.MockContractTemplateRepository
+	documentRepo        *mocks.MockContractDocumentRepository
+	fileStorage         *mocks.MockFileStorage
+	watchlistRepo       *mocks.MockWatchlistRepository
+	watchlistHitRepo    *mocks.MockWatchlistHitRepository
+	duplicateRepo       *mocks.MockCustomerDuplicateRepository
+	partnerRepo         *mocks.MockPartnerRepository
+	credentialRepo      *mocks.MockPartnerCredentialRepository
+	db                  *gorm.DB
 }
 
 func (suite *UseCaseTestSuite) SetupTest() {
@@ -35,21 +67,128 @@ func (suite *UseCaseTestSuite) SetupTest() {
 	suite.customerRepo = new(mocks.MockCustomerRepository)
 	suite.limitRepo = new(mocks.MockLimitRepository)
 	suite.transactionRepo = new(mocks.MockTransactionRepository)
+	suite.scheduleRepo = new(mocks.MockInstallmentScheduleRepository)
+	suite.paymentRepo = new(mocks.MockPaymentRepository)
+	suite.limitRequestRepo = new(mocks.MockLimitChangeRequestRepository)
+	suite.productRepo = new(mocks.MockProductRepository)
+	suite.rateCardRepo = new(mocks.MockRateCardRepository)
+	suite.adminFeeRuleRepo = new(mocks.MockAdminFeeRuleRepository)
+	suite.contractSeqRepo = new(mocks.MockContractSequenceRepository)
+	suite.templateRepo = new(mocks.MockContractTemplateRepository)
+	suite.documentRepo = new(mocks.MockContractDocumentRepository)
+	suite.fileStorage = new(mocks.MockFileStorage)
+	suite.watchlistRepo = new(mocks.MockWatchlistRepository)
+	suite.watchlistHitRepo = new(mocks.MockWatchlistHitRepository)
+	suite.duplicateRepo = new(mocks.MockCustomerDuplicateRepository)
+	suite.partnerRepo = new(mocks.MockPartnerRepository)
+	suite.credentialRepo = new(mocks.MockPartnerCredentialRepository)
+
+	// Nobody is on the watchlist unless a test says otherwise
+	suite.watchlistRepo.On("GetActiveByNIK", mock.Anything, mock.Anything, mock.Anything).Return([]*entity.Watchlist{}, nil).Maybe()
+	suite.watchlistHitRepo.On("CountHoldsByCustomer", mock.Anything, mock.Anything).Return(int64(0), nil).Maybe()
+	suite.watchlistHitRepo.On("CountHoldsByTransaction", mock.Anything, mock.Anything).Return(int64(0), nil).Maybe()
+
+	// Nor does anyone resemble an existing customer
+	suite.customerRepo.On("GetDuplicateCandidates", mock.Anything, mock.Anything, mock.Anything).Return([]*entity.Customer{}, nil).Maybe()
+	suite.duplicateRepo.On("CountHoldsByCustomer", mock.Anything, mock.Anything).Return(int64(0), nil).Maybe()
 
 	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
 	suite.Require().NoError(err)
 	suite.db = db
 
+	limitRecommender := service.NewLimitRecommender(service.LimitRecommendationPolicy{
+		TenorMultipliers: map[int]float64{1: 1, 2: 1.5, 3: 2, 4: 2.5},
+		RoundTo:          100000,
+	})
+	nikParser := service.NewNIKParser(nil)
+	suite.watchlistUseCase = usecase.NewWatchlistUseCase(
+		suite.watchlistRepo, suite.watchlistHitRepo, usecase.WatchlistPolicy{OnHit: entity.WatchlistBlock}, suite.db)
+	suite.duplicateUseCase = usecase.NewCustomerDuplicateUseCase(suite.duplicateRepo, suite.customerRepo,
+		service.NewDuplicateScorer(service.DuplicateScorePolicy{Threshold: 0.75, MaxNIKDistance: 2}))
+	suite.productUseCase = usecase.NewProductUseCase(suite.productRepo, suite.rateCardRepo, suite.db)
 	suite.authUseCase = usecase.NewAuthUseCase(
-		suite.userRepo, suite.customerRepo, suite.limitRepo, suite.db)
+		suite.userRepo, suite.customerRepo, suite.limitRepo, limitRecommender, nikParser, suite.watchlistUseCase, suite.duplicateUseCase, suite.productUseCase, suite.db)
 	suite.customerUseCase = usecase.NewCustomerUseCase(
-		suite.customerRepo, suite.limitRepo, suite.db)
+		suite.customerRepo, suite.limitRepo, limitRecommender, nikParser, suite.watchlistUseCase, suite.duplicateUseCase, suite.productUseCase, suite.db)
+	suite.scheduleUseCase = usecase.NewInstallmentScheduleUseCase(suite.scheduleRepo)
+	suite.limitRequestUseCase = usecase.NewLimitChangeRequestUseCase(
+		suite.limitRequestRepo, suite.limitRepo, suite.customerRepo, suite.fileStorage, usecase.LimitDocumentPolicy{
+			MaxSize:          1024,
+			AllowedMIMETypes: []string{"application/pdf", "image/jpeg", "image/png"},
+			URLTTL:           15 * time.Minute,
+		}, suite.db)
+	limitPolicy := usecase.LimitPolicy{NonRevolvingTenors: []int{4}}
+	suite.contractUseCase = usecase.NewContractDocumentUseCase(
+		suite.templateRepo, suite.documentRepo, suite.transactionRepo, suite.customerRepo, suite.scheduleRepo)
+	suite.stateMachine = usecase.NewTransactionStateMachine(suite.limitRepo, limitPolicy, suite.contractUseCase)
 	suite.transactionUseCase = usecase.NewTransactionUseCase(
-		suite.transactionRepo, suite.customerRepo, suite.limitRepo, suite.db)
+		suite.transactionRepo, suite.customerRepo, suite.limitRepo, suite.scheduleUseCase, suite.stateMachine,
+		usecase.NewContractNumberGenerator(suite.contractSeqRepo, service.ContractNumberFormat{
+			Template:      "{prefix}-{date}-{branch}-{seq}-{check}",
+			Prefix:        "XYZ",
+			Branch:        "JKT",
+			SequenceWidth: 6,
+		}),
+		suite.productUseCase, usecase.NewRateCardUseCase(suite.rateCardRepo, suite.productRepo),
+		usecase.NewAdminFeeRuleUseCase(suite.adminFeeRuleRepo, suite.productRepo), suite.watchlistUseCase,
+		usecase.InterestPolicy{Method: entity.InterestFlat},
+		usecase.AdminFeePolicy{OnMismatch: usecase.AdminFeeMismatchReject},
+		usecase.DownPaymentPolicy{MinPercent: map[entity.AssetType]float64{entity.AssetMotor: 10, entity.AssetMobil: 20}},
+		usecase.AffordabilityPolicy{MaxDebtToIncome: 0.3}, suite.db)
+	suite.delinquencyUseCase = usecase.NewDelinquencyUseCase(
+		suite.transactionRepo, suite.scheduleRepo, suite.stateMachine, usecase.DelinquencyPolicy{
+			LateFeeDailyRate:     0.001,
+			LateFeeCapRate:       0.1,
+			DefaultThresholdDays: 90,
+		}, suite.db)
+	suite.paymentUseCase = usecase.NewPaymentUseCase(
+		suite.paymentRepo, suite.transactionRepo, suite.scheduleRepo, suite.customerRepo, suite.limitRepo,
+		suite.delinquencyUseCase, suite.stateMachine, entity.DefaultAllocationOrder, limitPolicy, suite.db)
+	suite.kycUseCase = usecase.NewKYCDocumentUseCase(suite.customerRepo, suite.fileStorage, suite.watchlistUseCase, suite.duplicateUseCase, usecase.KYCUploadPolicy{
+		MaxSize:          1024,
+		AllowedMIMETypes: []string{"image/jpeg", "image/png"},
+		URLTTL:           15 * time.Minute,
+	}, suite.db)
+	suite.partnerUseCase = usecase.NewPartnerUseCase(suite.partnerRepo, suite.credentialRepo, suite.customerRepo, usecase.PartnerCredentialPolicy{
+		RotationGrace: 24 * time.Hour,
+	}, suite.db)
+}
+
+// inTransaction matches a context carrying an open database transaction.
+func (suite *UseCaseTestSuite) inTransaction() interface{} {
+	return mock.MatchedBy(func(ctx context.Context) bool {
+		_, ok := database.Conn(ctx, suite.db).Statement.ConnPool.(gorm.TxCommitter)
+		return ok
+	})
+}
+
+func defaultProduct() *entity.Product {
+	return &entity.Product{
+		ID:                 1,
+		Code:               "DEFAULT",
+		AllowedTenors:      []int{1, 2, 3, 4},
+		InterestRate:       24,
+		AdminFeeType:       entity.AdminFeeFlat,
+		AdminFeeValue:      50000,
+		EligibleAssetTypes: []entity.AssetType{entity.AssetWhiteGoods, entity.AssetMotor, entity.AssetMobil},
+		IsActive:           true,
+	}
+}
+
+// defaultRateCard prices like defaultProduct for any tenor and asset type.
+func defaultRateCard() *entity.RateCard {
+	return &entity.RateCard{
+		ID:            1,
+		ProductID:     1,
+		InterestRate:  24,
+		AdminFeeType:  entity.AdminFeeFlat,
+		AdminFeeValue: 50000,
+	}
 }
 
 func (suite *UseCaseTestSuite) TestAuthUseCase_RegisterSuccess() {
 	ctx := context.Background()
+	suite.productRepo.On("GetActive", mock.Anything).Return([]*entity.Product{defaultProduct()}, nil)
 	req := &dto.RegisterRequest{
 		Username:        "testuser",
 		Email:           "test@example.com",
@@ -57,7 +196,7 @@ func (suite *UseCaseTestSuite) TestAuthUseCase_RegisterSuccess() {
 		ConfirmPassword: "password123",
 		Role:            "CUSTOMER",
 		CustomerData: &dto.CreateCustomerRequest{
-			NIK:        "1234567890123456",
+			NIK:        "3171010101900001",
 			FullName:   "John Doe",
 			LegalName:  "John Doe",
 			BirthPlace: "Jakarta",
@@ -74,7 +213,7 @@ func (suite *UseCaseTestSuite) TestAuthUseCase_RegisterSuccess() {
 
 	suite.userRepo.On("GetByUsername", mock.Anything, "testuser").Return(nil, gorm.ErrRecordNotFound)
 	suite.userRepo.On("GetByEmail", mock.Anything, "test@example.com").Return(nil, gorm.ErrRecordNotFound)
-	suite.customerRepo.On("GetByNIK", mock.Anything, "1234567890123456").Return(nil, gorm.ErrRecordNotFound)
+	suite.customerRepo.On("GetByNIK", mock.Anything, "3171010101900001").Return(nil, gorm.ErrRecordNotFound)
 
 	suite.userRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.User")).Return(nil).Run(func(args mock.Arguments) {
 		user := args.Get(1).(*entity.User)
@@ -131,8 +270,9 @@ func (suite *UseCaseTestSuite) TestAuthUseCase_LoginSuccess() {
 
 func (suite *UseCaseTestSuite) TestCustomerUseCase_CreateCustomerSuccess() {
 	ctx := context.Background()
+	suite.productRepo.On("GetActive", mock.Anything).Return([]*entity.Product{defaultProduct()}, nil)
 	customer := &entity.Customer{
-		NIK:        "1234567890123456",
+		NIK:        "3171010101900001",
 		FullName:   "John Doe",
 		LegalName:  "John Doe",
 		BirthPlace: "Jakarta",
@@ -147,7 +287,7 @@ func (suite *UseCaseTestSuite) TestCustomerUseCase_CreateCustomerSuccess() {
 		{TenorMonths: 4, LimitAmount: 400000},
 	}
 
-	suite.customerRepo.On("GetByNIK", mock.Anything, "1234567890123456").Return(nil, gorm.ErrRecordNotFound)
+	suite.customerRepo.On("GetByNIK", mock.Anything, "3171010101900001").Return(nil, gorm.ErrRecordNotFound)
 	suite.customerRepo.On("Create", mock.Anything, customer).Return(nil).Run(func(args mock.Arguments) {
 		customer.ID = 1
 	})
@@ -158,13 +298,95 @@ func (suite *UseCaseTestSuite) TestCustomerUseCase_CreateCustomerSuccess() {
 	assert.NoError(suite.T(), err)
 }
 
+func (suite *UseCaseTestSuite) TestCustomerUseCase_CreateCustomerDefaultsToRecommendedLimits() {
+	ctx := context.Background()
+	suite.productRepo.On("GetActive", mock.Anything).Return([]*entity.Product{defaultProduct()}, nil)
+	customer := &entity.Customer{
+		NIK:        "3273014101900002",
+		FullName:   "Jane Doe",
+		LegalName:  "Jane Doe",
+		BirthPlace: "Bandung",
+		BirthDate:  time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
+		Salary:     4000000,
+	}
+
+	var created []*entity.CustomerLimit
+	suite.customerRepo.On("GetByNIK", mock.Anything, "3273014101900002").Return(nil, gorm.ErrRecordNotFound)
+	suite.customerRepo.On("Create", mock.Anything, customer).Return(nil)
+	suite.limitRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.CustomerLimit")).Return(nil).Run(func(args mock.Arguments) {
+		created = append(created, args.Get(1).(*entity.CustomerLimit))
+	})
+
+	err := suite.customerUseCase.CreateCustomer(ctx, customer, nil)
+
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), created, 4)
+	assert.Equal(suite.T(), float64(4000000), created[0].LimitAmount)
+	assert.Equal(suite.T(), float64(10000000), created[3].LimitAmount)
+	assert.Equal(suite.T(), entity.SexFemale, customer.Sex)
+	assert.Equal(suite.T(), "327301", customer.DistrictCode)
+}
+
+func (suite *UseCaseTestSuite) TestCustomerUseCase_CreateCustomerFillsMissingTenorsFromRecommendation() {
+	ctx := context.Background()
+	suite.productRepo.On("GetActive", mock.Anything).Return([]*entity.Product{defaultProduct()}, nil)
+	customer := &entity.Customer{
+		NIK:        "3273014101900002",
+		FullName:   "Jane Doe",
+		LegalName:  "Jane Doe",
+		BirthPlace: "Bandung",
+		BirthDate:  time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
+		Salary:     4000000,
+	}
+	limits := []*entity.CustomerLimit{
+		{TenorMonths: 1, LimitAmount: 100000},
+		{TenorMonths: 3, LimitAmount: 300000},
+	}
+
+	created := make(map[int]float64)
+	suite.customerRepo.On("GetByNIK", mock.Anything, "3273014101900002").Return(nil, gorm.ErrRecordNotFound)
+	suite.customerRepo.On("Create", mock.Anything, customer).Return(nil)
+	suite.limitRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.CustomerLimit")).Return(nil).Run(func(args mock.Arguments) {
+		limit := args.Get(1).(*entity.CustomerLimit)
+		created[limit.TenorMonths] = limit.LimitAmount
+	})
+
+	err := suite.customerUseCase.CreateCustomer(ctx, customer, limits)
+
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), created, 4)
+	assert.Equal(suite.T(), float64(100000), created[1])
+	assert.Equal(suite.T(), float64(300000), created[3])
+	assert.Equal(suite.T(), float64(10000000), created[4])
+}
+
+func (suite *UseCaseTestSuite) TestCustomerUseCase_CreateCustomerRejectsNIKBirthDateMismatch() {
+	ctx := context.Background()
+	customer := &entity.Customer{
+		NIK:        "3171010101900001",
+		FullName:   "John Doe",
+		LegalName:  "John Doe",
+		BirthPlace: "Jakarta",
+		BirthDate:  time.Date(1991, 1, 1, 0, 0, 0, 0, time.UTC),
+		Salary:     5000000,
+	}
+
+	err := suite.customerUseCase.CreateCustomer(ctx, customer, nil)
+
+	assert.Error(suite.T(), err)
+	assert.Contains(suite.T(), err.Error(), "does not match birth date")
+	suite.customerRepo.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+}
+
 func (suite *UseCaseTestSuite) TestTransactionUseCase_CreateTransactionSuccess() {
 	ctx := context.Background()
 
 	customer := &entity.Customer{
-		ID:       1,
-		NIK:      "1234567890123456",
-		FullName: "John Doe",
+		ID:        1,
+		NIK:       "1234567890123456",
+		FullName:  "John Doe",
+		Salary:    5000000,
+		KYCStatus: entity.KYCVerified,
 	}
 
 	limit := &entity.CustomerLimit{
@@ -188,27 +410,429 @@ func (suite *UseCaseTestSuite) TestTransactionUseCase_CreateTransactionSuccess()
 	}
 
 	suite.customerRepo.On("GetByID", mock.Anything, uint64(1)).Return(customer, nil)
+	suite.transactionRepo.On("GetByCustomerID", mock.Anything, uint64(1)).Return([]*entity.Transaction{}, nil)
+	suite.productRepo.On("GetActive", mock.Anything).Return([]*entity.Product{defaultProduct()}, nil)
+	suite.rateCardRepo.On("GetEffective", mock.Anything, uint64(1), 1, entity.AssetWhiteGoods, mock.AnythingOfType("time.Time")).Return(defaultRateCard(), nil)
+	suite.adminFeeRuleRepo.On("GetActive", mock.Anything).Return([]*entity.AdminFeeRule{}, nil)
 	suite.limitRepo.On("GetByCustomerAndTenor", mock.Anything, uint64(1), 1).Return(limit, nil)
-	suite.transactionRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.Transaction")).Return(nil).Run(func(args mock.Arguments) {
+	suite.contractSeqRepo.On("Next", mock.Anything, mock.AnythingOfType("string")).Return(uint64(42), nil)
+	suite.contractSeqRepo.On("Next", mock.Anything, mock.MatchedBy(func(scope string) bool {
+		return strings.HasPrefix(scope, "contract:XYZ:") && strings.HasSuffix(scope, ":JKT")
+	})).Return(uint64(42), nil)
+	suite.transactionRepo.On("Create", suite.inTransaction(), mock.AnythingOfType("*entity.Transaction")).Return(nil).Run(func(args mock.Arguments) {
 		tx := args.Get(1).(*entity.Transaction)
 		tx.ID = 1
-		tx.ContractNumber = "XYZ001"
 	})
-	suite.limitRepo.On("UpdateUsedAmount", mock.Anything, uint64(1), 1, float64(500000)).Return(nil)
+	suite.scheduleRepo.On("CreateBatch", suite.inTransaction(), mock.AnythingOfType("[]*entity.InstallmentSchedule")).Return(nil)
+	suite.limitRepo.On("UpdateUsedAmount", suite.inTransaction(), mock.MatchedBy(func(m *entity.LimitMovement) bool {
+		return m.Type == entity.MovementReserve && m.CustomerID == 1 && m.TenorMonths == 1 && m.Amount == 500000
+	})).Return(nil)
+
+	err := suite.transactionUseCase.CreateTransaction(ctx, transaction)
+
+	assert.NoError(suite.T(), err)
+	assert.Regexp(suite.T(), `^XYZ-\d{8}-JKT-000042-\d$`, transaction.ContractNumber)
+	assert.Equal(suite.T(), entity.InterestFlat, transaction.InterestMethod)
+	assert.Equal(suite.T(), float64(10000), transaction.InterestAmount)
+	assert.Equal(suite.T(), float64(560000), transaction.InstallmentAmount)
+	assert.Equal(suite.T(), 0.112, transaction.DebtToIncomeRatio)
+	assert.Equal(suite.T(), uint64(1), transaction.ProductID)
+	if assert.NotNil(suite.T(), transaction.RateCardID) {
+		assert.Equal(suite.T(), uint64(1), *transaction.RateCardID)
+	}
+	suite.scheduleRepo.AssertNumberOfCalls(suite.T(), "CreateBatch", 1)
+}
+
+func (suite *UseCaseTestSuite) TestTransactionUseCase_CreateTransactionUsesEffectiveRateCard() {
+	ctx := context.Background()
+
+	transaction := &entity.Transaction{
+		CustomerID:        1,
+		TenorMonths:       1,
+		OTRAmount:         500000,
+		AssetName:         "Smartphone",
+		AssetType:         entity.AssetWhiteGoods,
+		TransactionSource: entity.SourceEcommerce,
+		Status:            entity.StatusPending,
+	}
+	card := &entity.RateCard{
+		ID:            7,
+		ProductID:     1,
+		TenorMonths:   1,
+		AssetType:     entity.AssetWhiteGoods,
+		InterestRate:  12,
+		AdminFeeType:  entity.AdminFeeFlat,
+		EffectiveFrom: time.Now().AddDate(0, -1, 0),
+	}
+
+	suite.customerRepo.On("GetByID", mock.Anything, uint64(1)).Return(&entity.Customer{ID: 1, Salary: 5000000, KYCStatus: entity.KYCVerified}, nil)
+	suite.transactionRepo.On("GetByCustomerID", mock.Anything, uint64(1)).Return([]*entity.Transaction{}, nil)
+	suite.productRepo.On("GetActive", mock.Anything).Return([]*entity.Product{defaultProduct()}, nil)
+	suite.rateCardRepo.On("GetEffective", mock.Anything, uint64(1), 1, entity.AssetWhiteGoods, mock.AnythingOfType("time.Time")).Return(card, nil)
+	suite.adminFeeRuleRepo.On("GetActive", mock.Anything).Return([]*entity.AdminFeeRule{}, nil)
+	suite.limitRepo.On("GetByCustomerAndTenor", mock.Anything, uint64(1), 1).Return(&entity.CustomerLimit{ID: 1, CustomerID: 1, TenorMonths: 1, LimitAmount: 1000000}, nil)
+	suite.contractSeqRepo.On("Next", mock.Anything, mock.AnythingOfType("string")).Return(uint64(42), nil)
+	suite.transactionRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.Transaction")).Return(nil)
+	suite.scheduleRepo.On("CreateBatch", mock.Anything, mock.AnythingOfType("[]*entity.InstallmentSchedule")).Return(nil)
+	suite.limitRepo.On("UpdateUsedAmount", mock.Anything, mock.AnythingOfType("*entity.LimitMovement")).Return(nil)
+
+	err := suite.transactionUseCase.CreateTransaction(ctx, transaction)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), float64(12), transaction.InterestRate)
+	assert.Equal(suite.T(), float64(5000), transaction.InterestAmount)
+	assert.Equal(suite.T(), float64(0), transaction.AdminFee, "rate card fee replaces the product fee")
+	if assert.NotNil(suite.T(), transaction.RateCardID) {
+		assert.Equal(suite.T(), uint64(7), *transaction.RateCardID)
+	}
+}
+
+func (suite *UseCaseTestSuite) TestTransactionUseCase_CreateTransactionRejectsTenorWithoutRateCard() {
+	ctx := context.Background()
+
+	transaction := &entity.Transaction{
+		CustomerID:        1,
+		TenorMonths:       1,
+		OTRAmount:         500000,
+		AssetName:         "Smartphone",
+		AssetType:         entity.AssetWhiteGoods,
+		TransactionSource: entity.SourceEcommerce,
+		Status:            entity.StatusPending,
+	}
+
+	suite.customerRepo.On("GetByID", mock.Anything, uint64(1)).Return(&entity.Customer{ID: 1, Salary: 5000000, KYCStatus: entity.KYCVerified}, nil)
+	suite.transactionRepo.On("GetByCustomerID", mock.Anything, uint64(1)).Return([]*entity.Transaction{}, nil)
+	suite.productRepo.On("GetActive", mock.Anything).Return([]*entity.Product{defaultProduct()}, nil)
+	suite.rateCardRepo.On("GetEffective", mock.Anything, uint64(1), 1, entity.AssetWhiteGoods, mock.AnythingOfType("time.Time")).Return(nil, nil)
+	suite.limitRepo.On("GetByCustomerAndTenor", mock.Anything, uint64(1), 1).Return(&entity.CustomerLimit{ID: 1, CustomerID: 1, TenorMonths: 1, LimitAmount: 1000000}, nil)
+
+	err := suite.transactionUseCase.CreateTransaction(ctx, transaction)
+
+	assert.ErrorIs(suite.T(), err, usecase.ErrNoEffectiveRateCard)
+	assert.Nil(suite.T(), transaction.RateCardID)
+	suite.transactionRepo.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (suite *UseCaseTestSuite) TestTransactionUseCase_CreateTransactionFinancesOTRLessDownPayment() {
+	ctx := context.Background()
+
+	transaction := &entity.Transaction{
+		CustomerID:        1,
+		TenorMonths:       2,
+		OTRAmount:         20000000,
+		DownPaymentAmount: 4000000,
+		AssetName:         "Scooter",
+		AssetType:         entity.AssetMotor,
+		TransactionSource: entity.SourceDealer,
+		Status:            entity.StatusPending,
+	}
+
+	suite.customerRepo.On("GetByID", mock.Anything, uint64(1)).Return(&entity.Customer{ID: 1, Salary: 30000000, KYCStatus: entity.KYCVerified}, nil)
+	suite.transactionRepo.On("GetByCustomerID", mock.Anything, uint64(1)).Return([]*entity.Transaction{}, nil)
+	suite.productRepo.On("GetActive", mock.Anything).Return([]*entity.Product{defaultProduct()}, nil)
+	suite.limitRepo.On("GetByCustomerAndTenor", mock.Anything, uint64(1), 2).Return(&entity.CustomerLimit{ID: 2, CustomerID: 1, TenorMonths: 2, LimitAmount: 17000000}, nil)
+	suite.rateCardRepo.On("GetEffective", mock.Anything, uint64(1), 2, entity.AssetMotor, mock.AnythingOfType("time.Time")).Return(defaultRateCard(), nil)
+	suite.adminFeeRuleRepo.On("GetActive", mock.Anything).Return([]*entity.AdminFeeRule{}, nil)
+	suite.contractSeqRepo.On("Next", mock.Anything, mock.AnythingOfType("string")).Return(uint64(42), nil)
+	suite.transactionRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.Transaction")).Return(nil)
+	suite.scheduleRepo.On("CreateBatch", mock.Anything, mock.AnythingOfType("[]*entity.InstallmentSchedule")).Return(nil)
+	suite.limitRepo.On("UpdateUsedAmount", mock.Anything, mock.MatchedBy(func(m *entity.LimitMovement) bool {
+		return m.Type == entity.MovementReserve && m.Amount == 16000000
+	})).Return(nil)
+
+	err := suite.transactionUseCase.CreateTransaction(ctx, transaction)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), float64(16000000), transaction.FinancedAmount)
+	// 16,000,000 at 24% flat for 2 months
+	assert.Equal(suite.T(), float64(640000), transaction.InterestAmount)
+}
+
+func (suite *UseCaseTestSuite) TestTransactionUseCase_SimulateTransactionQuotesEveryTenor() {
+	ctx := context.Background()
+
+	suite.customerRepo.On("GetByID", mock.Anything, uint64(1)).Return(&entity.Customer{ID: 1, Salary: 10000000}, nil)
+	suite.transactionRepo.On("GetByCustomerID", mock.Anything, uint64(1)).Return([]*entity.Transaction{
+		{CustomerID: 1, Status: entity.StatusActive, InstallmentAmount: 1000000},
+		{CustomerID: 1, Status: entity.StatusCompleted, InstallmentAmount: 5000000},
+	}, nil)
+	suite.productRepo.On("GetActive", mock.Anything).Return([]*entity.Product{defaultProduct()}, nil)
+	suite.limitRepo.On("GetByCustomerID", mock.Anything, uint64(1)).Return([]*entity.CustomerLimit{
+		{CustomerID: 1, TenorMonths: 1, LimitAmount: 10000000},
+		{CustomerID: 1, TenorMonths: 2, LimitAmount: 17000000},
+	}, nil)
+	suite.rateCardRepo.On("GetEffective", mock.Anything, uint64(1), mock.AnythingOfType("int"), entity.AssetMotor, mock.AnythingOfType("time.Time")).Return(defaultRateCard(), nil)
+	suite.adminFeeRuleRepo.On("GetActive", mock.Anything).Return([]*entity.AdminFeeRule{}, nil)
+
+	options, err := suite.transactionUseCase.SimulateTransaction(ctx, &usecase.SimulationRequest{
+		CustomerID:        1,
+		OTRAmount:         20000000,
+		DownPaymentAmount: 4000000,
+		AssetType:         entity.AssetMotor,
+		TransactionSource: entity.SourceDealer,
+	})
+
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), options, 4)
+	assert.False(suite.T(), options[0].LimitSufficient)
+	assert.Equal(suite.T(), 2, options[1].TenorMonths)
+	assert.Equal(suite.T(), float64(640000), options[1].InterestAmount)
+	// 16,000,000 financed + 640,000 interest + 50,000 admin fee
+	assert.Equal(suite.T(), float64(16690000), options[1].TotalPayable)
+	assert.True(suite.T(), options[1].LimitSufficient)
+	assert.False(suite.T(), options[3].LimitSufficient)
+	// 1,000,000 on the active contract plus 8,345,000 a month is over 30% of 10,000,000
+	assert.Equal(suite.T(), 0.9345, options[1].DebtToIncomeRatio)
+	assert.False(suite.T(), options[1].Affordable)
+	suite.transactionRepo.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+	suite.contractSeqRepo.AssertNotCalled(suite.T(), "Next", mock.Anything, mock.Anything)
+}
+
+func (suite *UseCaseTestSuite) TestTransactionUseCase_CreateTransactionRejectsLowDownPayment() {
+	ctx := context.Background()
+
+	transaction := &entity.Transaction{
+		CustomerID:        1,
+		TenorMonths:       2,
+		OTRAmount:         20000000,
+		DownPaymentAmount: 1000000,
+		AssetName:         "Scooter",
+		AssetType:         entity.AssetMotor,
+		TransactionSource: entity.SourceDealer,
+		Status:            entity.StatusPending,
+	}
+
+	suite.customerRepo.On("GetByID", mock.Anything, uint64(1)).Return(&entity.Customer{ID: 1, KYCStatus: entity.KYCVerified}, nil)
+	suite.productRepo.On("GetActive", mock.Anything).Return([]*entity.Product{defaultProduct()}, nil)
+
+	err := suite.transactionUseCase.CreateTransaction(ctx, transaction)
+
+	assert.Error(suite.T(), err)
+	assert.Contains(suite.T(), err.Error(), "below the minimum 2000000.00")
+	suite.limitRepo.AssertNotCalled(suite.T(), "GetByCustomerAndTenor", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *UseCaseTestSuite) TestTransactionUseCase_CreateTransactionRejectsMismatchedAdminFee() {
+	ctx := context.Background()
+
+	clientFee := float64(0)
+	productID := uint64(1)
+	transaction := &entity.Transaction{
+		CustomerID:        1,
+		TenorMonths:       1,
+		OTRAmount:         500000,
+		ClientAdminFee:    &clientFee,
+		AssetName:         "Smartphone",
+		AssetType:         entity.AssetWhiteGoods,
+		TransactionSource: entity.SourceEcommerce,
+		Status:            entity.StatusPending,
+	}
+	rules := []*entity.AdminFeeRule{
+		{ID: 1, Name: "Catch-all", Type: entity.AdminFeeFlat, Value: 100000, IsActive: true},
+		{ID: 2, Name: "Marketplace", ProductID: &productID, TransactionSource: entity.SourceEcommerce, Type: entity.AdminFeePercentage, Value: 2, MinFee: 15000, IsActive: true},
+	}
+
+	suite.customerRepo.On("GetByID", mock.Anything, uint64(1)).Return(&entity.Customer{ID: 1, KYCStatus: entity.KYCVerified}, nil)
+	suite.productRepo.On("GetActive", mock.Anything).Return([]*entity.Product{defaultProduct()}, nil)
+	suite.limitRepo.On("GetByCustomerAndTenor", mock.Anything, uint64(1), 1).Return(&entity.CustomerLimit{ID: 1, CustomerID: 1, TenorMonths: 1, LimitAmount: 1000000}, nil)
+	suite.rateCardRepo.On("GetEffective", mock.Anything, uint64(1), 1, entity.AssetWhiteGoods, mock.AnythingOfType("time.Time")).Return(defaultRateCard(), nil)
+	suite.adminFeeRuleRepo.On("GetActive", mock.Anything).Return(rules, nil)
+
+	err := suite.transactionUseCase.CreateTransaction(ctx, transaction)
+
+	var mismatchErr *entity.AdminFeeMismatchError
+	if assert.ErrorAs(suite.T(), err, &mismatchErr) {
+		assert.Equal(suite.T(), float64(15000), mismatchErr.Expected)
+		assert.Equal(suite.T(), float64(0), mismatchErr.Provided)
+	}
+	suite.transactionRepo.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (suite *UseCaseTestSuite) TestProductUseCase_UpdateOpensRateCardsForAddedTenors() {
+	ctx := context.Background()
+	product := &entity.Product{
+		ID:                 2,
+		Code:               "motor",
+		AllowedTenors:      []int{12, 6},
+		InterestRate:       18,
+		AdminFeeType:       entity.AdminFeePercentage,
+		AdminFeeValue:      1.5,
+		EligibleAssetTypes: []entity.AssetType{entity.AssetMotor},
+		IsActive:           true,
+	}
+	// The 6 month card was repriced since; only the added 12 month tenor needs one
+	existing := []*entity.RateCard{{ID: 4, ProductID: 2, TenorMonths: 6, AssetType: entity.AssetMotor, InterestRate: 20}}
+
+	stored := *product
+	stored.AllowedTenors = []int{6}
+
+	suite.productRepo.On("GetByCode", mock.Anything, "MOTOR").Return(product, nil)
+	suite.productRepo.On("GetByID", mock.Anything, uint64(2)).Return(&stored, nil)
+	suite.productRepo.On("Update", suite.inTransaction(), product).Return(nil)
+	suite.rateCardRepo.On("GetByProductID", suite.inTransaction(), uint64(2)).Return(existing, nil)
+	suite.rateCardRepo.On("CreateVersion", suite.inTransaction(), mock.MatchedBy(func(card *entity.RateCard) bool {
+		return card.ProductID == 2 && card.TenorMonths == 12 && card.AssetType == entity.AssetMotor &&
+			card.InterestRate == 18 && card.AdminFeeType == entity.AdminFeePercentage && card.AdminFeeValue == 1.5
+	})).Return(nil)
+	suite.productRepo.On("GetActive", mock.Anything).Return([]*entity.Product{product}, nil)
+
+	err := suite.productUseCase.UpdateProduct(ctx, product)
+
+	assert.NoError(suite.T(), err)
+	suite.rateCardRepo.AssertNumberOfCalls(suite.T(), "CreateVersion", 1)
+	assert.Equal(suite.T(), []int{6, 12}, suite.productUseCase.OfferedTenors())
+}
+
+func (suite *UseCaseTestSuite) TestProductUseCase_UpdateRepricesChangedRateCards() {
+	ctx := context.Background()
+	product := &entity.Product{
+		ID:                 2,
+		Code:               "MOTOR",
+		AllowedTenors:      []int{6, 12},
+		InterestRate:       21,
+		AdminFeeType:       entity.AdminFeePercentage,
+		AdminFeeValue:      1.5,
+		EligibleAssetTypes: []entity.AssetType{entity.AssetMotor},
+		IsActive:           true,
+	}
+	stored := *product
+	stored.InterestRate = 18
+	// The 12 month card was already moved to the new rate; only 6 months is stale
+	stale := &entity.RateCard{ID: 4, ProductID: 2, TenorMonths: 6, AssetType: entity.AssetMotor,
+		InterestRate: 18, AdminFeeType: entity.AdminFeePercentage, AdminFeeValue: 1.5}
+	current := &entity.RateCard{ID: 5, ProductID: 2, TenorMonths: 12, AssetType: entity.AssetMotor,
+		InterestRate: 21, AdminFeeType: entity.AdminFeePercentage, AdminFeeValue: 1.5}
+
+	suite.productRepo.On("GetByCode", mock.Anything, "MOTOR").Return(product, nil)
+	suite.productRepo.On("GetByID", mock.Anything, uint64(2)).Return(&stored, nil)
+	suite.productRepo.On("Update", suite.inTransaction(), product).Return(nil)
+	suite.rateCardRepo.On("GetEffective", suite.inTransaction(), uint64(2), 6, entity.AssetMotor, mock.Anything).Return(stale, nil)
+	suite.rateCardRepo.On("GetEffective", suite.inTransaction(), uint64(2), 12, entity.AssetMotor, mock.Anything).Return(current, nil)
+	suite.rateCardRepo.On("CreateVersion", suite.inTransaction(), mock.MatchedBy(func(card *entity.RateCard) bool {
+		return card.ProductID == 2 && card.TenorMonths == 6 && card.InterestRate == 21
+	})).Return(nil)
+	suite.productRepo.On("GetActive", mock.Anything).Return([]*entity.Product{product}, nil)
+
+	err := suite.productUseCase.UpdateProduct(ctx, product)
+
+	assert.NoError(suite.T(), err)
+	suite.rateCardRepo.AssertNumberOfCalls(suite.T(), "CreateVersion", 1)
+}
+
+func (suite *UseCaseTestSuite) TestAdminFeeRuleUseCase_QuotePrefersNewestOfEquallySpecificRules() {
+	ctx := context.Background()
+	feeUseCase := usecase.NewAdminFeeRuleUseCase(suite.adminFeeRuleRepo, suite.productRepo)
+	rules := []*entity.AdminFeeRule{
+		{ID: 7, Name: "Newer", Type: entity.AdminFeeFlat, Value: 60000, IsActive: true},
+		{ID: 3, Name: "Older", Type: entity.AdminFeeFlat, Value: 40000, IsActive: true},
+	}
+	suite.adminFeeRuleRepo.On("GetActive", mock.Anything).Return(rules, nil)
+
+	quote, err := feeUseCase.QuoteAdminFee(ctx, defaultProduct(), defaultRateCard(), entity.SourceWeb, 1000000)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), uint64(7), quote.Rule.ID)
+	assert.Equal(suite.T(), float64(60000), quote.Amount)
+}
+
+func (suite *UseCaseTestSuite) TestAdminFeeRuleUseCase_UpdateSupersedesRule() {
+	ctx := context.Background()
+	feeUseCase := usecase.NewAdminFeeRuleUseCase(suite.adminFeeRuleRepo, suite.productRepo)
+	rule := &entity.AdminFeeRule{ID: 4, Name: "Web orders", TransactionSource: entity.SourceWeb, Type: entity.AdminFeeFlat, Value: 75000, IsActive: true}
+
+	suite.adminFeeRuleRepo.On("Supersede", mock.Anything, uint64(4), mock.MatchedBy(func(r *entity.AdminFeeRule) bool {
+		return r.ID == 0 && r.Value == 75000
+	})).Run(func(args mock.Arguments) {
+		args.Get(2).(*entity.AdminFeeRule).ID = 5
+	}).Return(nil)
+
+	err := feeUseCase.UpdateRule(ctx, rule)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), uint64(5), rule.ID)
+}
+
+func (suite *UseCaseTestSuite) TestTransactionUseCase_CreateTransactionNoEligibleProduct() {
+	ctx := context.Background()
+
+	product := defaultProduct()
+	product.EligibleAssetTypes = []entity.AssetType{entity.AssetWhiteGoods}
+
+	transaction := &entity.Transaction{
+		CustomerID:        1,
+		TenorMonths:       1,
+		OTRAmount:         500000,
+		AssetName:         "Sedan",
+		AssetType:         entity.AssetMobil,
+		TransactionSource: entity.SourceDealer,
+		Status:            entity.StatusPending,
+	}
+
+	suite.customerRepo.On("GetByID", mock.Anything, uint64(1)).Return(&entity.Customer{ID: 1, KYCStatus: entity.KYCVerified}, nil)
+	suite.productRepo.On("GetActive", mock.Anything).Return([]*entity.Product{product}, nil)
 
 	err := suite.transactionUseCase.CreateTransaction(ctx, transaction)
 
+	assert.Error(suite.T(), err)
+	assert.Contains(suite.T(), err.Error(), "no active product")
+	suite.transactionRepo.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (suite *UseCaseTestSuite) TestTransactionUseCase_GetByContractNumberChecksDigitFirst() {
+	ctx := context.Background()
+
+	_, err := suite.transactionUseCase.GetTransactionByContractNumber(ctx, "XYZ-20240131-JKT-000042-0")
+
+	assert.Error(suite.T(), err)
+	assert.Contains(suite.T(), err.Error(), "check digit")
+	suite.transactionRepo.AssertNotCalled(suite.T(), "GetByContractNumber", mock.Anything, mock.Anything)
+}
+
+func (suite *UseCaseTestSuite) TestInstallmentScheduleUseCase_GenerateSchedule() {
+	ctx := context.Background()
+
+	transaction := &entity.Transaction{
+		ID:             1,
+		TenorMonths:    3,
+		OTRAmount:      1000000,
+		AdminFee:       50000,
+		InterestAmount: 100000,
+		CreatedAt:      time.Date(2024, 1, 31, 10, 0, 0, 0, time.UTC),
+	}
+
+	suite.scheduleRepo.On("CreateBatch", mock.Anything, mock.AnythingOfType("[]*entity.InstallmentSchedule")).Return(nil)
+
+	installments, err := suite.scheduleUseCase.GenerateSchedule(ctx, transaction)
+
 	assert.NoError(suite.T(), err)
-	assert.NotEmpty(suite.T(), transaction.ContractNumber)
+	assert.Len(suite.T(), installments, 3)
+
+	var principal, interest, adminFee, total float64
+	for i, installment := range installments {
+		assert.Equal(suite.T(), i+1, installment.InstallmentNumber)
+		assert.Equal(suite.T(), entity.InstallmentUnpaid, installment.Status)
+		principal += installment.PrincipalAmount
+		interest += installment.InterestAmount
+		adminFee += installment.AdminFeeAmount
+		total += installment.AmountDue
+	}
+
+	assert.InDelta(suite.T(), 1000000, principal, 0.001)
+	assert.InDelta(suite.T(), 100000, interest, 0.001)
+	assert.InDelta(suite.T(), 50000, adminFee, 0.001)
+	assert.InDelta(suite.T(), 1150000, total, 0.001)
+	assert.Equal(suite.T(), time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC), installments[0].DueDate)
+	assert.Equal(suite.T(), time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC), installments[1].DueDate)
 }
 
 func (suite *UseCaseTestSuite) TestTransactionUseCase_CreateTransactionInsufficientLimit() {
 	ctx := context.Background()
 
 	customer := &entity.Customer{
-		ID:       1,
-		NIK:      "1234567890123456",
-		FullName: "John Doe",
+		ID:        1,
+		NIK:       "1234567890123456",
+		FullName:  "John Doe",
+		KYCStatus: entity.KYCVerified,
 	}
 
 	limit := &entity.CustomerLimit{
@@ -232,6 +856,7 @@ func (suite *UseCaseTestSuite) TestTransactionUseCase_CreateTransactionInsuffici
 	}
 
 	suite.customerRepo.On("GetByID", mock.Anything, uint64(1)).Return(customer, nil)
+	suite.productRepo.On("GetActive", mock.Anything).Return([]*entity.Product{defaultProduct()}, nil)
 	suite.limitRepo.On("GetByCustomerAndTenor", mock.Anything, uint64(1), 1).Return(limit, nil)
 
 	err := suite.transactionUseCase.CreateTransaction(ctx, transaction)
@@ -240,6 +865,1067 @@ func (suite *UseCaseTestSuite) TestTransactionUseCase_CreateTransactionInsuffici
 	assert.Contains(suite.T(), err.Error(), "exceeds available limit")
 }
 
+func (suite *UseCaseTestSuite) TestPaymentUseCase_RecordPaymentAllocatesAndCompletes() {
+	ctx := context.Background()
+
+	nextMonth := time.Now().AddDate(0, 1, 0)
+	transaction := &entity.Transaction{
+		ID:          1,
+		CustomerID:  1,
+		TenorMonths: 2,
+		OTRAmount:   1000000,
+		Status:      entity.StatusActive,
+	}
+
+	installments := []*entity.InstallmentSchedule{
+		{ID: 10, TransactionID: 1, InstallmentNumber: 1, DueDate: nextMonth, PrincipalAmount: 500000, InterestAmount: 50000, AdminFeeAmount: 10000, AmountDue: 560000, PenaltyAmount: 5000, Status: entity.InstallmentUnpaid},
+		{ID: 11, TransactionID: 1, InstallmentNumber: 2, DueDate: nextMonth.AddDate(0, 1, 0), PrincipalAmount: 500000, InterestAmount: 50000, AdminFeeAmount: 10000, AmountDue: 560000, Status: entity.InstallmentUnpaid},
+	}
+
+	suite.transactionRepo.On("GetByIDForUpdate", suite.inTransaction(), uint64(1)).Return(transaction, nil)
+	suite.scheduleRepo.On("GetByTransactionIDForUpdate", suite.inTransaction(), uint64(1)).Return(installments, nil)
+	suite.paymentRepo.On("GetByTransactionID", suite.inTransaction(), uint64(1)).Return([]*entity.Payment{}, nil)
+	suite.scheduleRepo.On("Update", mock.Anything, mock.AnythingOfType("*entity.InstallmentSchedule")).Return(nil)
+	suite.paymentRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.Payment")).Return(nil)
+	suite.customerRepo.On("AddCreditBalance", mock.Anything, uint64(1), float64(20000)).Return(nil)
+	suite.limitRepo.On("UpdateUsedAmount", mock.Anything, mock.MatchedBy(func(m *entity.LimitMovement) bool {
+		return m.Type == entity.MovementRepaymentRestore && m.Amount == -1000000 && m.PaymentID != nil && *m.TransactionID == 1
+	})).Return(nil).Once()
+	suite.transactionRepo.On("Update", mock.Anything, transaction).Return(nil)
+
+	payment := &entity.Payment{TransactionID: 1, Amount: 1145000, PaymentMethod: "TRANSFER", RecordedBy: 1}
+	err := suite.paymentUseCase.RecordPayment(ctx, payment)
+
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), payment.Allocations, 2)
+	assert.Equal(suite.T(), float64(5000), payment.AllocatedPenalty)
+	assert.Equal(suite.T(), float64(100000), payment.AllocatedInterest)
+	assert.Equal(suite.T(), float64(1000000), payment.AllocatedPrincipal)
+	assert.Equal(suite.T(), float64(20000), payment.ExcessAmount)
+	assert.Equal(suite.T(), entity.InstallmentPaid, installments[0].Status)
+	assert.Equal(suite.T(), entity.InstallmentPaid, installments[1].Status)
+	assert.Equal(suite.T(), entity.StatusCompleted, transaction.Status)
+	assert.Equal(suite.T(), float64(1000000), payment.LimitRestored)
+	assert.Equal(suite.T(), float64(1000000), transaction.LimitRestored)
+	suite.limitRepo.AssertExpectations(suite.T())
+}
+
+func (suite *UseCaseTestSuite) TestPaymentUseCase_NonRevolvingTenorKeepsLimitUsedUntilCompleted() {
+	ctx := context.Background()
+
+	nextMonth := time.Now().AddDate(0, 1, 0)
+	transaction := &entity.Transaction{ID: 4, CustomerID: 1, TenorMonths: 4, OTRAmount: 500000, Status: entity.StatusActive}
+	installments := []*entity.InstallmentSchedule{
+		{ID: 40, TransactionID: 4, InstallmentNumber: 1, DueDate: nextMonth, PrincipalAmount: 250000, AmountDue: 250000, Status: entity.InstallmentUnpaid},
+		{ID: 41, TransactionID: 4, InstallmentNumber: 2, DueDate: nextMonth.AddDate(0, 1, 0), PrincipalAmount: 250000, AmountDue: 250000, Status: entity.InstallmentUnpaid},
+	}
+
+	suite.transactionRepo.On("GetByIDForUpdate", suite.inTransaction(), uint64(4)).Return(transaction, nil)
+	suite.scheduleRepo.On("GetByTransactionIDForUpdate", suite.inTransaction(), uint64(4)).Return(installments, nil)
+	suite.paymentRepo.On("GetByTransactionID", suite.inTransaction(), uint64(4)).Return([]*entity.Payment{}, nil)
+	suite.scheduleRepo.On("Update", mock.Anything, mock.AnythingOfType("*entity.InstallmentSchedule")).Return(nil)
+	suite.paymentRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.Payment")).Return(nil)
+	suite.transactionRepo.On("Update", mock.Anything, transaction).Return(nil)
+
+	first := &entity.Payment{TransactionID: 4, Amount: 250000, PaymentMethod: "TRANSFER", RecordedBy: 1}
+	err := suite.paymentUseCase.RecordPayment(ctx, first)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), entity.StatusActive, transaction.Status)
+	assert.Equal(suite.T(), float64(0), first.LimitRestored)
+	suite.limitRepo.AssertNotCalled(suite.T(), "UpdateUsedAmount", mock.Anything, mock.Anything)
+
+	// Completion gives the whole financed amount back
+	suite.limitRepo.On("UpdateUsedAmount", mock.Anything, mock.MatchedBy(func(m *entity.LimitMovement) bool {
+		return m.Type == entity.MovementRepaymentRestore && m.Amount == -500000 && m.TenorMonths == 4
+	})).Return(nil).Once()
+
+	last := &entity.Payment{TransactionID: 4, Amount: 250000, PaymentMethod: "TRANSFER", RecordedBy: 1}
+	err = suite.paymentUseCase.RecordPayment(ctx, last)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), entity.StatusCompleted, transaction.Status)
+	assert.Equal(suite.T(), float64(500000), last.LimitRestored)
+	assert.Equal(suite.T(), float64(500000), transaction.LimitRestored)
+	suite.limitRepo.AssertExpectations(suite.T())
+}
+
+func (suite *UseCaseTestSuite) TestPaymentUseCase_UnlistedCatalogTenorRevolves() {
+	ctx := context.Background()
+
+	nextMonth := time.Now().AddDate(0, 1, 0)
+	transaction := &entity.Transaction{ID: 5, CustomerID: 1, TenorMonths: 12, OTRAmount: 600000, Status: entity.StatusActive}
+	installments := []*entity.InstallmentSchedule{
+		{ID: 50, TransactionID: 5, InstallmentNumber: 1, DueDate: nextMonth, PrincipalAmount: 300000, AmountDue: 300000, Status: entity.InstallmentUnpaid},
+		{ID: 51, TransactionID: 5, InstallmentNumber: 2, DueDate: nextMonth.AddDate(0, 1, 0), PrincipalAmount: 300000, AmountDue: 300000, Status: entity.InstallmentUnpaid},
+	}
+
+	suite.transactionRepo.On("GetByIDForUpdate", suite.inTransaction(), uint64(5)).Return(transaction, nil)
+	suite.scheduleRepo.On("GetByTransactionIDForUpdate", suite.inTransaction(), uint64(5)).Return(installments, nil)
+	suite.paymentRepo.On("GetByTransactionID", suite.inTransaction(), uint64(5)).Return([]*entity.Payment{}, nil)
+	suite.scheduleRepo.On("Update", mock.Anything, mock.AnythingOfType("*entity.InstallmentSchedule")).Return(nil)
+	suite.paymentRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.Payment")).Return(nil)
+	suite.limitRepo.On("UpdateUsedAmount", mock.Anything, mock.MatchedBy(func(m *entity.LimitMovement) bool {
+		return m.Type == entity.MovementRepaymentRestore && m.Amount == -300000 && m.TenorMonths == 12
+	})).Return(nil).Once()
+	suite.transactionRepo.On("Update", mock.Anything, transaction).Return(nil)
+
+	payment := &entity.Payment{TransactionID: 5, Amount: 300000, PaymentMethod: "TRANSFER", RecordedBy: 1}
+	err := suite.paymentUseCase.RecordPayment(ctx, payment)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), entity.StatusActive, transaction.Status)
+	assert.Equal(suite.T(), float64(300000), payment.LimitRestored)
+	suite.limitRepo.AssertExpectations(suite.T())
+}
+
+func (suite *UseCaseTestSuite) TestPaymentUseCase_RecordPaymentRejectsFutureDate() {
+	ctx := context.Background()
+
+	transaction := &entity.Transaction{ID: 6, CustomerID: 1, TenorMonths: 1, Status: entity.StatusActive}
+	suite.transactionRepo.On("GetByIDForUpdate", suite.inTransaction(), uint64(6)).Return(transaction, nil)
+
+	payment := &entity.Payment{TransactionID: 6, Amount: 100000, PaymentDate: time.Now().AddDate(0, 0, 3), PaymentMethod: "TRANSFER", RecordedBy: 1}
+	err := suite.paymentUseCase.RecordPayment(ctx, payment)
+
+	assert.Error(suite.T(), err)
+	assert.Contains(suite.T(), err.Error(), "in the future")
+	suite.scheduleRepo.AssertNotCalled(suite.T(), "Update", mock.Anything, mock.Anything)
+	suite.paymentRepo.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (suite *UseCaseTestSuite) TestPaymentUseCase_RecordPaymentRejectsBackdatedDate() {
+	ctx := context.Background()
+
+	activatedAt := time.Now().AddDate(0, -2, 0)
+	transaction := &entity.Transaction{ID: 7, CustomerID: 1, TenorMonths: 3, Status: entity.StatusActive, ActivatedAt: &activatedAt}
+	previous := []*entity.Payment{{ID: 70, TransactionID: 7, PaymentDate: time.Now().AddDate(0, 0, -5)}}
+	suite.transactionRepo.On("GetByIDForUpdate", suite.inTransaction(), uint64(7)).Return(transaction, nil)
+	suite.paymentRepo.On("GetByTransactionID", suite.inTransaction(), uint64(7)).Return(previous, nil)
+
+	beforeActivation := &entity.Payment{TransactionID: 7, Amount: 100000, PaymentDate: activatedAt.AddDate(0, 0, -1), PaymentMethod: "TRANSFER", RecordedBy: 1}
+	err := suite.paymentUseCase.RecordPayment(ctx, beforeActivation)
+
+	assert.Error(suite.T(), err)
+	assert.Contains(suite.T(), err.Error(), "before the contract was activated")
+
+	beforeLastPayment := &entity.Payment{TransactionID: 7, Amount: 100000, PaymentDate: time.Now().AddDate(0, 0, -10), PaymentMethod: "TRANSFER", RecordedBy: 1}
+	err = suite.paymentUseCase.RecordPayment(ctx, beforeLastPayment)
+
+	assert.Error(suite.T(), err)
+	assert.Contains(suite.T(), err.Error(), "before the last payment")
+	suite.paymentRepo.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (suite *UseCaseTestSuite) TestPaymentUseCase_PartialPaymentFollowsAllocationOrder() {
+	ctx := context.Background()
+
+	transaction := &entity.Transaction{ID: 2, CustomerID: 1, TenorMonths: 1, Status: entity.StatusActive}
+	installment := &entity.InstallmentSchedule{
+		ID: 20, TransactionID: 2, InstallmentNumber: 1, DueDate: time.Now().AddDate(0, 1, 0),
+		PrincipalAmount: 500000, InterestAmount: 50000, AdminFeeAmount: 10000, AmountDue: 560000, PenaltyAmount: 5000,
+		Status: entity.InstallmentUnpaid,
+	}
+
+	suite.transactionRepo.On("GetByIDForUpdate", suite.inTransaction(), uint64(2)).Return(transaction, nil)
+	suite.scheduleRepo.On("GetByTransactionIDForUpdate", suite.inTransaction(), uint64(2)).Return([]*entity.InstallmentSchedule{installment}, nil)
+	suite.paymentRepo.On("GetByTransactionID", suite.inTransaction(), uint64(2)).Return([]*entity.Payment{}, nil)
+	suite.scheduleRepo.On("Update", mock.Anything, installment).Return(nil)
+	suite.paymentRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.Payment")).Return(nil)
+	suite.transactionRepo.On("Update", mock.Anything, transaction).Return(nil)
+
+	payment := &entity.Payment{TransactionID: 2, Amount: 30000, PaymentMethod: "CASH", RecordedBy: 1}
+	err := suite.paymentUseCase.RecordPayment(ctx, payment)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), float64(5000), installment.PenaltyPaid)
+	assert.Equal(suite.T(), float64(25000), installment.InterestPaid)
+	assert.Equal(suite.T(), float64(0), installment.PrincipalPaid)
+	assert.Equal(suite.T(), entity.InstallmentPartiallyPaid, installment.Status)
+	assert.Equal(suite.T(), entity.StatusActive, transaction.Status)
+}
+
+func (suite *UseCaseTestSuite) TestDelinquencyUseCase_ChargesPenaltyAndDefaults() {
+	ctx := context.Background()
+	asOf := time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC)
+
+	transaction := &entity.Transaction{ID: 3, CustomerID: 1, TenorMonths: 2, Status: entity.StatusActive}
+	installments := []*entity.InstallmentSchedule{
+		{ID: 30, TransactionID: 3, InstallmentNumber: 1, DueDate: asOf.AddDate(0, 0, -100), AmountDue: 560000, Status: entity.InstallmentUnpaid},
+		{ID: 31, TransactionID: 3, InstallmentNumber: 2, DueDate: asOf.AddDate(0, 0, -10), AmountDue: 560000, Status: entity.InstallmentUnpaid},
+	}
+
+	suite.transactionRepo.On("GetByStatuses", mock.Anything, []entity.TransactionStatus{entity.StatusActive, entity.StatusDefaulted}).
+		Return([]*entity.Transaction{{ID: 3, Status: entity.StatusActive}}, nil)
+	suite.transactionRepo.On("GetByIDForUpdate", suite.inTransaction(), uint64(3)).Return(transaction, nil)
+	suite.scheduleRepo.On("GetByTransactionIDForUpdate", suite.inTransaction(), uint64(3)).Return(installments, nil)
+	suite.scheduleRepo.On("Update", suite.inTransaction(), mock.AnythingOfType("*entity.InstallmentSchedule")).Return(nil)
+	suite.transactionRepo.On("Update", suite.inTransaction(), transaction).Return(nil)
+
+	result, err := suite.delinquencyUseCase.RunAssessment(ctx, asOf)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 1, result.TransactionsDefaulted)
+	assert.Equal(suite.T(), 100, installments[0].DaysPastDue)
+	assert.Equal(suite.T(), float64(56000), installments[0].PenaltyAmount) // capped at 10%
+	assert.Equal(suite.T(), float64(5600), installments[1].PenaltyAmount)
+	assert.Equal(suite.T(), 100, transaction.DaysPastDue)
+	assert.Equal(suite.T(), float64(61600), transaction.AccruedPenalty)
+	assert.Equal(suite.T(), entity.StatusDefaulted, transaction.Status)
+}
+
+func (suite *UseCaseTestSuite) TestDelinquencyUseCase_LateActivationOnlyCountsDaysSinceActivation() {
+	ctx := context.Background()
+	asOf := time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC)
+
+	// Created in April, due at the end of May, but only activated ten days ago
+	activatedAt := asOf.AddDate(0, 0, -10)
+	transaction := &entity.Transaction{ID: 8, CustomerID: 1, TenorMonths: 2, Status: entity.StatusActive, ActivatedAt: &activatedAt}
+	installments := []*entity.InstallmentSchedule{
+		{ID: 80, TransactionID: 8, InstallmentNumber: 1, DueDate: asOf.AddDate(0, 0, -30), AmountDue: 560000, Status: entity.InstallmentUnpaid},
+		{ID: 81, TransactionID: 8, InstallmentNumber: 2, DueDate: asOf.AddDate(0, 0, 1), AmountDue: 560000, Status: entity.InstallmentUnpaid},
+	}
+
+	suite.transactionRepo.On("GetByIDForUpdate", suite.inTransaction(), uint64(8)).Return(transaction, nil)
+	suite.scheduleRepo.On("GetByTransactionIDForUpdate", suite.inTransaction(), uint64(8)).Return(installments, nil)
+	suite.scheduleRepo.On("Update", suite.inTransaction(), mock.AnythingOfType("*entity.InstallmentSchedule")).Return(nil)
+	suite.transactionRepo.On("Update", suite.inTransaction(), transaction).Return(nil)
+
+	_, err := suite.delinquencyUseCase.AssessTransaction(ctx, 8, asOf)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 10, installments[0].DaysPastDue)
+	assert.Equal(suite.T(), float64(5600), installments[0].PenaltyAmount)
+	assert.Equal(suite.T(), 0, installments[1].DaysPastDue)
+	assert.Equal(suite.T(), 10, transaction.DaysPastDue)
+}
+
+func (suite *UseCaseTestSuite) TestDelinquencyUseCase_SkipsContractCompletedMeanwhile() {
+	ctx := context.Background()
+	asOf := time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC)
+
+	// Listed as ACTIVE, but a payment completed it before the lock was taken
+	suite.transactionRepo.On("GetByStatuses", mock.Anything, []entity.TransactionStatus{entity.StatusActive, entity.StatusDefaulted}).
+		Return([]*entity.Transaction{{ID: 4, Status: entity.StatusActive}}, nil)
+	suite.transactionRepo.On("GetByIDForUpdate", suite.inTransaction(), uint64(4)).Return(&entity.Transaction{ID: 4, Status: entity.StatusCompleted}, nil)
+
+	result, err := suite.delinquencyUseCase.RunAssessment(ctx, asOf)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 0, result.TransactionsAssessed)
+	suite.scheduleRepo.AssertNotCalled(suite.T(), "Update", mock.Anything, mock.Anything)
+	suite.transactionRepo.AssertNotCalled(suite.T(), "Update", mock.Anything, mock.Anything)
+}
+
+func (suite *UseCaseTestSuite) TestTransactionUseCase_RejectsIllegalStatusTransition() {
+	ctx := context.Background()
+	transaction := &entity.Transaction{ID: 1, CustomerID: 1, TenorMonths: 3, OTRAmount: 500000, Status: entity.StatusCompleted}
+
+	suite.transactionRepo.On("GetByIDForUpdate", suite.inTransaction(), uint64(1)).Return(transaction, nil)
+
+	err := suite.transactionUseCase.UpdateTransactionStatus(ctx, 1, entity.StatusActive, "")
+
+	var transitionErr *entity.InvalidStatusTransitionError
+	assert.ErrorAs(suite.T(), err, &transitionErr)
+	assert.Equal(suite.T(), entity.StatusCompleted, transaction.Status)
+	suite.transactionRepo.AssertNotCalled(suite.T(), "Update", mock.Anything, mock.Anything)
+}
+
+func (suite *UseCaseTestSuite) TestTransactionUseCase_StatusUpdateReturnsTypedErrors() {
+	ctx := context.Background()
+
+	suite.transactionRepo.On("GetByIDForUpdate", suite.inTransaction(), uint64(404)).
+		Return(nil, gorm.ErrRecordNotFound)
+
+	err := suite.transactionUseCase.UpdateTransactionStatus(ctx, 404, entity.StatusApproved, "")
+	assert.ErrorIs(suite.T(), err, usecase.ErrTransactionNotFound)
+
+	held := &entity.Transaction{ID: 6, CustomerID: 1, TenorMonths: 3, Status: entity.StatusPending}
+	suite.transactionRepo.On("GetByIDForUpdate", suite.inTransaction(), uint64(6)).Return(held, nil)
+	suite.watchlistHitRepo.ExpectedCalls = nil
+	suite.watchlistHitRepo.On("CountHoldsByTransaction", suite.inTransaction(), uint64(6)).Return(int64(1), nil)
+
+	err = suite.transactionUseCase.UpdateTransactionStatus(ctx, 6, entity.StatusApproved, "")
+	assert.ErrorIs(suite.T(), err, usecase.ErrTransactionOnHold)
+	assert.Equal(suite.T(), entity.StatusPending, held.Status)
+	suite.transactionRepo.AssertNotCalled(suite.T(), "Update", mock.Anything, mock.Anything)
+}
+
+func (suite *UseCaseTestSuite) TestTransactionUseCase_RejectReleasesLimit() {
+	ctx := context.Background()
+	transaction := &entity.Transaction{ID: 1, CustomerID: 1, TenorMonths: 3, OTRAmount: 500000, Status: entity.StatusPending}
+
+	suite.transactionRepo.On("GetByIDForUpdate", suite.inTransaction(), uint64(1)).Return(transaction, nil)
+	suite.limitRepo.On("UpdateUsedAmount", mock.Anything, mock.MatchedBy(func(m *entity.LimitMovement) bool {
+		return m.Type == entity.MovementRelease && m.Amount == -500000 && m.Reason == "incomplete documents"
+	})).Return(nil)
+	suite.transactionRepo.On("Update", mock.Anything, transaction).Return(nil)
+
+	err := suite.transactionUseCase.RejectTransaction(ctx, 1, "incomplete documents")
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), entity.StatusRejected, transaction.Status)
+	assert.Equal(suite.T(), "incomplete documents", transaction.StatusReason)
+	assert.NotNil(suite.T(), transaction.RejectedAt)
+	suite.limitRepo.AssertExpectations(suite.T())
+}
+
+func (suite *UseCaseTestSuite) TestTransactionStateMachine_FailingHookRestoresTransaction() {
+	ctx := context.Background()
+	transaction := &entity.Transaction{ID: 1, CustomerID: 1, TenorMonths: 3, OTRAmount: 500000, Status: entity.StatusPending, StatusReason: "awaiting review"}
+
+	// RejectedAt is stamped before the limit release fails
+	suite.limitRepo.On("UpdateUsedAmount", mock.Anything, mock.AnythingOfType("*entity.LimitMovement")).Return(assert.AnError)
+
+	err := suite.stateMachine.Transition(ctx, transaction, entity.StatusRejected, "incomplete documents")
+
+	assert.ErrorIs(suite.T(), err, assert.AnError)
+	assert.Equal(suite.T(), entity.StatusPending, transaction.Status)
+	assert.Equal(suite.T(), "awaiting review", transaction.StatusReason)
+	assert.Nil(suite.T(), transaction.RejectedAt)
+}
+
+func (suite *UseCaseTestSuite) TestCustomerUseCase_UpdateLimitAmountRequiresReason() {
+	ctx := context.Background()
+
+	_, err := suite.customerUseCase.UpdateLimitAmount(ctx, 1, 2, 3000000, 9, "  ")
+	assert.Error(suite.T(), err)
+
+	suite.limitRepo.On("UpdateLimitAmount", ctx, mock.MatchedBy(func(c *entity.LimitChange) bool {
+		return c.CustomerID == 1 && c.TenorMonths == 2 && c.NewLimitAmount == 3000000 && c.ChangedBy == 9
+	})).Run(func(args mock.Arguments) {
+		args.Get(1).(*entity.LimitChange).OldLimitAmount = 2000000
+	}).Return(nil)
+
+	change, err := suite.customerUseCase.UpdateLimitAmount(ctx, 1, 2, 3000000, 9, "salary increase verified")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), float64(2000000), change.OldLimitAmount)
+	suite.limitRepo.AssertNumberOfCalls(suite.T(), "UpdateLimitAmount", 1)
+}
+
+func (suite *UseCaseTestSuite) TestLimitChangeRequestUseCase_ApproveAppliesLimitAndSalary() {
+	ctx := context.Background()
+	salary := float64(8000000)
+	request := &entity.LimitChangeRequest{
+		ID: 5, CustomerID: 1, TenorMonths: 3, CurrentLimitAmount: 2000000, RequestedLimitAmount: 4000000,
+		UpdatedSalary: &salary, Status: entity.LimitRequestPending,
+	}
+	customer := &entity.Customer{ID: 1, Salary: 5000000}
+
+	suite.limitRequestRepo.On("GetByIDForUpdate", suite.inTransaction(), uint64(5)).Return(request, nil)
+	suite.limitRepo.On("GetByCustomerAndTenorForUpdate", suite.inTransaction(), uint64(1), 3).
+		Return(&entity.CustomerLimit{CustomerID: 1, TenorMonths: 3, LimitAmount: 2000000}, nil)
+	suite.limitRepo.On("UpdateLimitAmount", suite.inTransaction(), mock.MatchedBy(func(c *entity.LimitChange) bool {
+		return c.CustomerID == 1 && c.TenorMonths == 3 && c.NewLimitAmount == 4000000 && c.ChangedBy == 9
+	})).Return(nil)
+	suite.customerRepo.On("GetByID", suite.inTransaction(), uint64(1)).Return(customer, nil)
+	suite.customerRepo.On("Update", suite.inTransaction(), customer).Return(nil)
+	suite.limitRequestRepo.On("Update", suite.inTransaction(), request).Return(nil)
+
+	approved, err := suite.limitRequestUseCase.ApproveRequest(ctx, 5, 9, 0, "payslip verified")
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), entity.LimitRequestApproved, approved.Status)
+	assert.Equal(suite.T(), float64(4000000), approved.ApprovedLimitAmount)
+	assert.Equal(suite.T(), salary, customer.Salary)
+	assert.NotNil(suite.T(), approved.ReviewedAt)
+
+	_, err = suite.limitRequestUseCase.RejectRequest(ctx, 5, 9, "too late")
+	assert.Error(suite.T(), err)
+}
+
+func (suite *UseCaseTestSuite) TestLimitChangeRequestUseCase_ApproveRejectsAmountOutsideRequest() {
+	ctx := context.Background()
+	request := &entity.LimitChangeRequest{
+		ID: 5, CustomerID: 1, TenorMonths: 3, CurrentLimitAmount: 2000000, RequestedLimitAmount: 4000000,
+		Status: entity.LimitRequestPending,
+	}
+	suite.limitRequestRepo.On("GetByIDForUpdate", suite.inTransaction(), uint64(5)).Return(request, nil)
+	// Raised by an admin since the request was submitted
+	suite.limitRepo.On("GetByCustomerAndTenorForUpdate", suite.inTransaction(), uint64(1), 3).
+		Return(&entity.CustomerLimit{CustomerID: 1, TenorMonths: 3, LimitAmount: 3000000}, nil)
+
+	for _, amount := range []float64{3000000, 4500000} {
+		_, err := suite.limitRequestUseCase.ApproveRequest(ctx, 5, 9, amount, "")
+		assert.Error(suite.T(), err)
+	}
+
+	assert.Equal(suite.T(), entity.LimitRequestPending, request.Status)
+	suite.limitRepo.AssertNotCalled(suite.T(), "UpdateLimitAmount", mock.Anything, mock.Anything)
+}
+
+func (suite *UseCaseTestSuite) TestLimitChangeRequestUseCase_UploadDocumentRecordsChecksum() {
+	ctx := context.Background()
+
+	image := append([]byte("\xff\xd8\xff\xe0"), make([]byte, 200)...)
+	sum := sha256.Sum256(image)
+	request := &entity.LimitChangeRequest{ID: 7, CustomerID: 1, TenorMonths: 3, Status: entity.LimitRequestPending, DocumentKey: "limit-requests/1/7-old.jpg"}
+
+	suite.limitRequestRepo.On("GetByID", mock.Anything, uint64(7)).Return(request, nil)
+	suite.limitRequestRepo.On("GetByIDForUpdate", suite.inTransaction(), uint64(7)).Return(request, nil)
+	suite.fileStorage.On("Save", mock.Anything, mock.MatchedBy(func(key string) bool {
+		return strings.HasPrefix(key, "limit-requests/1/7-") && strings.HasSuffix(key, ".jpg")
+	}), image).Return(nil)
+	suite.limitRequestRepo.On("Update", suite.inTransaction(), request).Return(nil)
+	suite.fileStorage.On("Delete", mock.Anything, "limit-requests/1/7-old.jpg").Return(nil)
+
+	updated, err := suite.limitRequestUseCase.UploadDocument(ctx, 1, 7, bytes.NewReader(image), int64(len(image)))
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), hex.EncodeToString(sum[:]), updated.DocumentChecksum)
+	assert.NotEqual(suite.T(), "limit-requests/1/7-old.jpg", updated.DocumentKey)
+	assert.NotNil(suite.T(), updated.DocumentUploadedAt)
+	suite.fileStorage.AssertCalled(suite.T(), "Delete", mock.Anything, "limit-requests/1/7-old.jpg")
+}
+
+func (suite *UseCaseTestSuite) TestLimitChangeRequestUseCase_UploadDocumentAcceptsPDF() {
+	ctx := context.Background()
+
+	payslip := append([]byte("%PDF-1.4\n"), make([]byte, 200)...)
+	request := &entity.LimitChangeRequest{ID: 9, CustomerID: 1, TenorMonths: 3, Status: entity.LimitRequestPending}
+
+	suite.limitRequestRepo.On("GetByID", mock.Anything, uint64(9)).Return(request, nil)
+	suite.limitRequestRepo.On("GetByIDForUpdate", suite.inTransaction(), uint64(9)).Return(request, nil)
+	suite.fileStorage.On("Save", mock.Anything, mock.MatchedBy(func(key string) bool {
+		return strings.HasPrefix(key, "limit-requests/1/9-") && strings.HasSuffix(key, ".pdf")
+	}), payslip).Return(nil)
+	suite.limitRequestRepo.On("Update", suite.inTransaction(), request).Return(nil)
+
+	updated, err := suite.limitRequestUseCase.UploadDocument(ctx, 1, 9, bytes.NewReader(payslip), int64(len(payslip)))
+
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), strings.HasSuffix(updated.DocumentKey, ".pdf"))
+}
+
+func (suite *UseCaseTestSuite) TestLimitChangeRequestUseCase_UploadDocumentRejectsOtherCustomersRequest() {
+	ctx := context.Background()
+
+	image := append([]byte("\xff\xd8\xff\xe0"), make([]byte, 200)...)
+	request := &entity.LimitChangeRequest{ID: 8, CustomerID: 2, TenorMonths: 3, Status: entity.LimitRequestPending}
+	suite.limitRequestRepo.On("GetByID", mock.Anything, uint64(8)).Return(request, nil)
+
+	_, err := suite.limitRequestUseCase.UploadDocument(ctx, 1, 8, bytes.NewReader(image), int64(len(image)))
+
+	assert.Error(suite.T(), err)
+	suite.fileStorage.AssertNotCalled(suite.T(), "Save", mock.Anything, mock.Anything, mock.Anything)
+	suite.limitRequestRepo.AssertNotCalled(suite.T(), "Update", mock.Anything, mock.Anything)
+}
+
+func (suite *UseCaseTestSuite) TestContractDocumentUseCase_GenerateContractStoresHashedPDF() {
+	ctx := context.Background()
+
+	approvedAt := time.Now()
+	transaction := &entity.Transaction{
+		ID:                7,
+		ContractNumber:    "XYZ-20240131-JKT-000042-6",
+		CustomerID:        1,
+		TenorMonths:       1,
+		OTRAmount:         1000000,
+		FinancedAmount:    1000000,
+		AdminFee:          50000,
+		InterestAmount:    20000,
+		InstallmentAmount: 1070000,
+		AssetName:         "Refrigerator",
+		AssetType:         entity.AssetWhiteGoods,
+		Status:            entity.StatusApproved,
+		ApprovedAt:        &approvedAt,
+	}
+	template := &entity.ContractTemplate{ID: 3, Version: 2, Name: "Standard", IsActive: true,
+		Body: "Agreement {{.Transaction.ContractNumber}} for {{.Customer.LegalName}}, total {{money .TotalPayable}}"}
+
+	suite.documentRepo.On("GetByTransactionID", mock.Anything, uint64(7)).Return(nil, nil)
+	suite.transactionRepo.On("GetByID", mock.Anything, uint64(7)).Return(transaction, nil)
+	suite.customerRepo.On("GetByID", mock.Anything, uint64(1)).Return(&entity.Customer{ID: 1, LegalName: "Budi Santoso"}, nil)
+	suite.scheduleRepo.On("GetByTransactionID", mock.Anything, uint64(7)).Return([]*entity.InstallmentSchedule{}, nil)
+	suite.templateRepo.On("GetLatestActive", mock.Anything).Return(template, nil)
+	suite.documentRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.ContractDocument")).Return(nil)
+
+	document, err := suite.contractUseCase.GenerateContract(ctx, 7)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 2, document.TemplateVersion)
+	assert.True(suite.T(), strings.HasPrefix(string(document.Content), "%PDF-1.4"))
+	assert.Contains(suite.T(), string(document.Content), "Rp 1.070.000,00")
+	sum := sha256.Sum256(document.Content)
+	assert.Equal(suite.T(), hex.EncodeToString(sum[:]), document.SHA256)
+	assert.Equal(suite.T(), int64(len(document.Content)), document.Size)
+}
+
+func (suite *UseCaseTestSuite) TestTransactionUseCase_ApproveTransactionIssuesContract() {
+	ctx := context.Background()
+
+	transaction := &entity.Transaction{
+		ID:                9,
+		ContractNumber:    "XYZ-20240131-JKT-000043-4",
+		CustomerID:        1,
+		TenorMonths:       1,
+		FinancedAmount:    1000000,
+		InstallmentAmount: 1070000,
+		Status:            entity.StatusPending,
+	}
+	template := &entity.ContractTemplate{ID: 3, Version: 2, Name: "Standard", IsActive: true,
+		Body: "Agreement {{.Transaction.ContractNumber}} approved {{.Transaction.ApprovedAt.Format \"2006-01-02\"}}"}
+
+	suite.transactionRepo.On("GetByIDForUpdate", suite.inTransaction(), uint64(9)).Return(transaction, nil)
+	suite.documentRepo.On("GetByTransactionID", suite.inTransaction(), uint64(9)).Return(nil, nil)
+	suite.customerRepo.On("GetByID", suite.inTransaction(), uint64(1)).Return(&entity.Customer{ID: 1, LegalName: "Budi Santoso"}, nil)
+	suite.scheduleRepo.On("GetByTransactionID", suite.inTransaction(), uint64(9)).Return([]*entity.InstallmentSchedule{}, nil)
+	suite.templateRepo.On("GetLatestActive", suite.inTransaction()).Return(template, nil)
+	suite.documentRepo.On("Create", suite.inTransaction(), mock.MatchedBy(func(document *entity.ContractDocument) bool {
+		return document.TransactionID == 9 && strings.Contains(string(document.Content), time.Now().Format("2006-01-02"))
+	})).Return(nil)
+	suite.transactionRepo.On("Update", suite.inTransaction(), transaction).Return(nil)
+
+	err := suite.transactionUseCase.ApproveTransaction(ctx, 9)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), entity.StatusApproved, transaction.Status)
+	suite.documentRepo.AssertNumberOfCalls(suite.T(), "Create", 1)
+}
+
+func (suite *UseCaseTestSuite) TestContractDocumentUseCase_GenerateContractRequiresApproval() {
+	ctx := context.Background()
+
+	suite.documentRepo.On("GetByTransactionID", mock.Anything, uint64(8)).Return(nil, nil)
+	suite.transactionRepo.On("GetByID", mock.Anything, uint64(8)).Return(&entity.Transaction{ID: 8, Status: entity.StatusPending}, nil)
+
+	_, err := suite.contractUseCase.GenerateContract(ctx, 8)
+
+	assert.Error(suite.T(), err)
+	assert.Contains(suite.T(), err.Error(), "only issued for approved transactions")
+	suite.documentRepo.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (suite *UseCaseTestSuite) TestKYCDocumentUseCase_UploadRecordsChecksumAndReplacesOldImage() {
+	ctx := context.Background()
+
+	image := append([]byte("\x89PNG\r\n\x1a\n"), make([]byte, 200)...)
+	sum := sha256.Sum256(image)
+	customer := &entity.Customer{ID: 1, KTPObjectKey: "kyc/1/ktp-old.png"}
+
+	suite.customerRepo.On("GetByID", mock.Anything, uint64(1)).Return(customer, nil)
+	suite.customerRepo.On("GetByIDForUpdate", suite.inTransaction(), uint64(1)).Return(customer, nil)
+	suite.fileStorage.On("Save", mock.Anything, mock.MatchedBy(func(key string) bool {
+		return strings.HasPrefix(key, "kyc/1/ktp-") && strings.HasSuffix(key, ".png")
+	}), image).Return(nil)
+	suite.customerRepo.On("Update", suite.inTransaction(), customer).Return(nil)
+	suite.fileStorage.On("Delete", mock.Anything, "kyc/1/ktp-old.png").Return(nil)
+	suite.fileStorage.On("SignedURL", mock.AnythingOfType("string"), 15*time.Minute).Return("http://localhost/api/v1/files/signed", time.Now(), nil)
+
+	link, err := suite.kycUseCase.UploadDocument(ctx, 1, entity.KYCDocumentKTP, bytes.NewReader(image), int64(len(image)))
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), hex.EncodeToString(sum[:]), customer.KTPChecksum)
+	assert.Equal(suite.T(), customer.KTPObjectKey, link.ObjectKey)
+	assert.NotNil(suite.T(), customer.KTPUploadedAt)
+	suite.fileStorage.AssertCalled(suite.T(), "Delete", mock.Anything, "kyc/1/ktp-old.png")
+}
+
+func (suite *UseCaseTestSuite) TestKYCDocumentUseCase_UploadRejectsDisallowedType() {
+	ctx := context.Background()
+
+	content := []byte("%PDF-1.4 not an image")
+	suite.customerRepo.On("GetByID", mock.Anything, uint64(1)).Return(&entity.Customer{ID: 1}, nil)
+
+	_, err := suite.kycUseCase.UploadDocument(ctx, 1, entity.KYCDocumentSelfie, bytes.NewReader(content), int64(len(content)))
+
+	assert.Error(suite.T(), err)
+	assert.Contains(suite.T(), err.Error(), "application/pdf is not allowed")
+	suite.fileStorage.AssertNotCalled(suite.T(), "Save", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *UseCaseTestSuite) TestTransactionUseCase_CreateTransactionRequiresVerifiedKYC() {
+	ctx := context.Background()
+
+	transaction := &entity.Transaction{
+		CustomerID:        1,
+		TenorMonths:       1,
+		OTRAmount:         500000,
+		AssetName:         "Smartphone",
+		AssetType:         entity.AssetWhiteGoods,
+		TransactionSource: entity.SourceEcommerce,
+		Status:            entity.StatusPending,
+	}
+
+	suite.customerRepo.On("GetByID", mock.Anything, uint64(1)).Return(&entity.Customer{ID: 1, KYCStatus: entity.KYCPendingReview}, nil)
+
+	err := suite.transactionUseCase.CreateTransaction(ctx, transaction)
+
+	assert.Error(suite.T(), err)
+	assert.Contains(suite.T(), err.Error(), "customer KYC is PENDING_REVIEW")
+	suite.limitRepo.AssertNotCalled(suite.T(), "GetByCustomerAndTenor", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *UseCaseTestSuite) TestKYCDocumentUseCase_SecondImageQueuesReviewAndApprovalRecordsReviewer() {
+	ctx := context.Background()
+
+	image := append([]byte("\xff\xd8\xff\xe0"), make([]byte, 200)...)
+	customer := &entity.Customer{ID: 1, KTPObjectKey: "kyc/1/ktp-a.png", KYCStatus: entity.KYCUnverified}
+
+	suite.customerRepo.On("GetByID", mock.Anything, uint64(1)).Return(customer, nil)
+	suite.customerRepo.On("GetByIDForUpdate", suite.inTransaction(), uint64(1)).Return(customer, nil)
+	suite.fileStorage.On("Save", mock.Anything, mock.AnythingOfType("string"), image).Return(nil)
+	suite.customerRepo.On("Update", mock.Anything, customer).Return(nil)
+	suite.fileStorage.On("SignedURL", mock.AnythingOfType("string"), 15*time.Minute).Return("http://localhost/api/v1/files/signed", time.Now(), nil)
+
+	_, err := suite.kycUseCase.UploadDocument(ctx, 1, entity.KYCDocumentSelfie, bytes.NewReader(image), int64(len(image)))
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), entity.KYCPendingReview, customer.KYCStatus)
+	assert.NotNil(suite.T(), customer.KYCSubmittedAt)
+
+	_, err = suite.kycUseCase.RejectCustomer(ctx, 1, 9, " ")
+	assert.Error(suite.T(), err, "a rejection needs a reason")
+
+	reviewed, err := suite.kycUseCase.ApproveCustomer(ctx, 1, 9, "KTP and selfie match")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), entity.KYCVerified, reviewed.KYCStatus)
+	assert.Equal(suite.T(), "KTP and selfie match", reviewed.KYCReviewReason)
+	if assert.NotNil(suite.T(), reviewed.KYCReviewedBy) {
+		assert.Equal(suite.T(), uint64(9), *reviewed.KYCReviewedBy)
+	}
+
+	_, err = suite.kycUseCase.ApproveCustomer(ctx, 1, 9, "")
+	assert.Error(suite.T(), err, "only PENDING_REVIEW can be approved")
+}
+
+func (suite *UseCaseTestSuite) TestKYCDocumentUseCase_ReviewRechecksStatusUnderLock() {
+	ctx := context.Background()
+
+	// Another admin approved the customer after this one loaded the queue
+	customer := &entity.Customer{
+		ID:              4,
+		KTPObjectKey:    "kyc/4/ktp.jpg",
+		SelfieObjectKey: "kyc/4/selfie.jpg",
+		KYCStatus:       entity.KYCVerified,
+	}
+	suite.customerRepo.On("GetByIDForUpdate", suite.inTransaction(), uint64(4)).Return(customer, nil)
+
+	_, err := suite.kycUseCase.RejectCustomer(ctx, 4, 9, "Selfie is blurred")
+	assert.ErrorContains(suite.T(), err, "only PENDING_REVIEW can be reviewed")
+
+	_, err = suite.kycUseCase.ApproveCustomer(ctx, 4, 9, "KTP and selfie match")
+	assert.ErrorContains(suite.T(), err, "only PENDING_REVIEW can be reviewed")
+
+	suite.customerRepo.AssertNotCalled(suite.T(), "Update", mock.Anything, mock.Anything)
+	assert.Equal(suite.T(), entity.KYCVerified, customer.KYCStatus)
+}
+
+func (suite *UseCaseTestSuite) TestWatchlistUseCase_BlockedCustomerRecordsHit() {
+	ctx := context.Background()
+	suite.productRepo.On("GetActive", mock.Anything).Return([]*entity.Product{defaultProduct()}, nil)
+	customer := &entity.Customer{
+		NIK:        "3171010101900001",
+		FullName:   "John Doe",
+		LegalName:  "John Doe",
+		BirthPlace: "Jakarta",
+		BirthDate:  time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
+		Salary:     5000000,
+	}
+
+	suite.watchlistRepo.ExpectedCalls = nil
+	suite.watchlistRepo.On("GetActiveByNIK", ctx, "3171010101900001", mock.Anything).
+		Return([]*entity.Watchlist{{ID: 7, NIK: "3171010101900001", Reason: "Fraud ring", Source: "OJK"}}, nil)
+	suite.watchlistHitRepo.On("Create", ctx, mock.MatchedBy(func(hit *entity.WatchlistHit) bool {
+		return hit.WatchlistID == 7 && hit.Status == entity.WatchlistHitBlocked &&
+			hit.Stage == entity.ScreeningCustomerCreation
+	})).Return(nil)
+
+	err := suite.customerUseCase.CreateCustomer(ctx, customer, nil)
+
+	assert.ErrorIs(suite.T(), err, usecase.ErrWatchlistBlocked)
+	assert.NotContains(suite.T(), err.Error(), "Fraud ring")
+	suite.customerRepo.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+	suite.watchlistHitRepo.AssertExpectations(suite.T())
+}
+
+func (suite *UseCaseTestSuite) TestWatchlistUseCase_BlockedTransactionKeepsHit() {
+	ctx := context.Background()
+	transaction := &entity.Transaction{
+		CustomerID:        1,
+		TenorMonths:       1,
+		OTRAmount:         500000,
+		AssetName:         "Smartphone",
+		AssetType:         entity.AssetWhiteGoods,
+		TransactionSource: entity.SourceEcommerce,
+		Status:            entity.StatusPending,
+	}
+
+	suite.customerRepo.On("GetByID", ctx, uint64(1)).Return(&entity.Customer{ID: 1, NIK: "3171010101900001", Salary: 5000000, KYCStatus: entity.KYCVerified}, nil)
+	suite.watchlistRepo.ExpectedCalls = nil
+	suite.watchlistRepo.On("GetActiveByNIK", ctx, "3171010101900001", mock.Anything).
+		Return([]*entity.Watchlist{{ID: 7, NIK: "3171010101900001", Reason: "Fraud ring", Source: "OJK"}}, nil)
+	// The plain context shows the hit is written outside the transaction
+	// that the refusal rolls back
+	suite.watchlistHitRepo.On("Create", ctx, mock.MatchedBy(func(hit *entity.WatchlistHit) bool {
+		return hit.WatchlistID == 7 && hit.Status == entity.WatchlistHitBlocked &&
+			hit.Stage == entity.ScreeningTransaction && hit.CustomerID != nil && *hit.CustomerID == 1
+	})).Return(nil)
+
+	err := suite.transactionUseCase.CreateTransaction(ctx, transaction)
+
+	assert.ErrorIs(suite.T(), err, usecase.ErrWatchlistBlocked)
+	suite.watchlistHitRepo.AssertExpectations(suite.T())
+	suite.transactionRepo.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (suite *UseCaseTestSuite) TestWatchlistUseCase_ImportCSVValidatesEveryRowFirst() {
+	ctx := context.Background()
+
+	csv := "nik,name,reason,source,expires_at\n" +
+		"3171010101900001,John Doe,Fraud ring,ojk,\n" +
+		"12345,Jane Doe,Bad NIK,ojk,\n" +
+		"3171010101900001,John Doe,Again,OJK,2030-01-01\n"
+
+	_, err := suite.watchlistUseCase.ImportCSV(ctx, strings.NewReader(csv), 1)
+
+	assert.Error(suite.T(), err)
+	assert.Contains(suite.T(), err.Error(), "line 3: NIK must be exactly 16 digits")
+	assert.Contains(suite.T(), err.Error(), "line 4: duplicates line 2")
+	suite.watchlistRepo.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+
+	existing := &entity.Watchlist{ID: 3, NIK: "3171010101900001", Reason: "Old", Source: "OJK"}
+	suite.watchlistRepo.On("GetByNIKAndSource", suite.inTransaction(), "3171010101900001", "OJK").Return(existing, nil)
+	suite.watchlistRepo.On("GetByNIKAndSource", suite.inTransaction(), "3273014101900002", "INTERNAL").Return(nil, nil)
+	suite.watchlistRepo.On("Update", suite.inTransaction(), existing).Return(nil)
+	suite.watchlistRepo.On("Create", suite.inTransaction(), mock.AnythingOfType("*entity.Watchlist")).Return(nil)
+
+	csv = "3171010101900001,John Doe,Fraud ring,ojk,2030-01-01\n" +
+		"3273014101900002,Jane Doe,Chargebacks,internal,\n"
+
+	result, err := suite.watchlistUseCase.ImportCSV(ctx, strings.NewReader(csv), 1)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 1, result.Created)
+	assert.Equal(suite.T(), 1, result.Updated)
+	assert.Equal(suite.T(), "Fraud ring", existing.Reason)
+	assert.NotNil(suite.T(), existing.ExpiresAt)
+}
+
+func (suite *UseCaseTestSuite) TestWatchlistUseCase_ImportCSVFailsAsAWhole() {
+	ctx := context.Background()
+
+	suite.watchlistRepo.On("GetByNIKAndSource", suite.inTransaction(), mock.Anything, mock.Anything).Return(nil, nil)
+	suite.watchlistRepo.On("Create", suite.inTransaction(), mock.MatchedBy(func(entry *entity.Watchlist) bool {
+		return entry.NIK == "3171010101900001"
+	})).Return(nil).Once()
+	suite.watchlistRepo.On("Create", suite.inTransaction(), mock.MatchedBy(func(entry *entity.Watchlist) bool {
+		return entry.NIK == "3273014101900002"
+	})).Return(assert.AnError).Once()
+
+	csv := "3171010101900001,John Doe,Fraud ring,ojk,\n" +
+		"3273014101900002,Jane Doe,Chargebacks,internal,\n"
+
+	result, err := suite.watchlistUseCase.ImportCSV(ctx, strings.NewReader(csv), 1)
+
+	assert.Nil(suite.T(), result)
+	assert.ErrorContains(suite.T(), err, "nothing was imported")
+	suite.watchlistRepo.AssertNumberOfCalls(suite.T(), "Create", 2)
+}
+
+func (suite *UseCaseTestSuite) TestCustomerDuplicateUseCase_CreateCustomerFlagsLikelyDuplicate() {
+	ctx := context.Background()
+	suite.productRepo.On("GetActive", mock.Anything).Return([]*entity.Product{defaultProduct()}, nil)
+	existing := &entity.Customer{
+		ID:         1,
+		NIK:        "3171010101900001",
+		FullName:   "John Doe",
+		LegalName:  "John Doe",
+		BirthPlace: "Jakarta",
+		BirthDate:  time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	stranger := &entity.Customer{
+		ID:         2,
+		NIK:        "3171014101900002",
+		FullName:   "Siti Rahma",
+		LegalName:  "Siti Rahma",
+		BirthPlace: "Bogor",
+		BirthDate:  time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	customer := &entity.Customer{
+		NIK:        "3171010101900007",
+		FullName:   "Jon Doe",
+		LegalName:  "Doe, John",
+		BirthPlace: "Jakarta",
+		BirthDate:  time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
+		Salary:     5000000,
+	}
+
+	suite.customerRepo.ExpectedCalls = nil
+	suite.customerRepo.On("GetByNIK", mock.Anything, "3171010101900007").Return(nil, gorm.ErrRecordNotFound)
+	suite.customerRepo.On("Create", mock.Anything, customer).Return(nil).Run(func(args mock.Arguments) {
+		customer.ID = 3
+	})
+	suite.customerRepo.On("GetDuplicateCandidates", mock.Anything, customer, mock.Anything).
+		Return([]*entity.Customer{existing, stranger}, nil)
+	suite.limitRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.CustomerLimit")).Return(nil)
+	suite.duplicateRepo.On("GetByPair", mock.Anything, uint64(3), uint64(1)).Return(nil, nil)
+
+	var flagged *entity.CustomerDuplicate
+	suite.duplicateRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.CustomerDuplicate")).Return(nil).Run(func(args mock.Arguments) {
+		flagged = args.Get(1).(*entity.CustomerDuplicate)
+	})
+
+	err := suite.customerUseCase.CreateCustomer(ctx, customer, nil)
+
+	assert.NoError(suite.T(), err)
+	suite.duplicateRepo.AssertNumberOfCalls(suite.T(), "Create", 1)
+	if assert.NotNil(suite.T(), flagged) {
+		assert.Equal(suite.T(), uint64(1), flagged.MatchedCustomerID)
+		assert.Equal(suite.T(), entity.DuplicateOpen, flagged.Status)
+		assert.Equal(suite.T(), 1, flagged.NIKDistance)
+		assert.Contains(suite.T(), flagged.Reasons, "same birth date")
+	}
+}
+
+func (suite *UseCaseTestSuite) TestCustomerDuplicateUseCase_OpenFlagHoldsApprovalUntilDismissed() {
+	ctx := context.Background()
+	customer := &entity.Customer{
+		ID:              3,
+		KTPObjectKey:    "kyc/3/ktp.jpg",
+		SelfieObjectKey: "kyc/3/selfie.jpg",
+		KYCStatus:       entity.KYCPendingReview,
+	}
+	suite.customerRepo.On("GetByIDForUpdate", suite.inTransaction(), uint64(3)).Return(customer, nil)
+
+	suite.duplicateRepo.ExpectedCalls = nil
+	suite.duplicateRepo.On("CountHoldsByCustomer", mock.Anything, uint64(3)).Return(int64(1), nil).Once()
+
+	_, err := suite.kycUseCase.ApproveCustomer(ctx, 3, 9, "KTP and selfie match")
+	assert.Error(suite.T(), err)
+	assert.Contains(suite.T(), err.Error(), "likely duplicate")
+
+	flag := &entity.CustomerDuplicate{ID: 5, CustomerID: 3, MatchedCustomerID: 1, Status: entity.DuplicateOpen}
+	suite.duplicateRepo.On("GetByID", mock.Anything, uint64(5)).Return(flag, nil)
+	suite.duplicateRepo.On("Update", mock.Anything, flag).Return(nil)
+
+	_, err = suite.duplicateUseCase.ResolveDuplicate(ctx, 5, 9, entity.DuplicateOpen, "not sure")
+	assert.Error(suite.T(), err, "OPEN is not a resolution")
+
+	resolved, err := suite.duplicateUseCase.ResolveDuplicate(ctx, 5, 9, entity.DuplicateDismissed, "Twins, different people")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), entity.DuplicateDismissed, resolved.Status)
+	assert.NotNil(suite.T(), resolved.ResolvedAt)
+
+	_, err = suite.duplicateUseCase.ResolveDuplicate(ctx, 5, 9, entity.DuplicateConfirmed, "Changed my mind")
+	assert.Error(suite.T(), err, "already resolved")
+
+	suite.duplicateRepo.On("CountHoldsByCustomer", mock.Anything, uint64(3)).Return(int64(0), nil)
+	suite.customerRepo.On("Update", mock.Anything, customer).Return(nil)
+
+	reviewed, err := suite.kycUseCase.ApproveCustomer(ctx, 3, 9, "KTP and selfie match")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), entity.KYCVerified, reviewed.KYCStatus)
+}
+
+func (suite *UseCaseTestSuite) TestCustomerDuplicateUseCase_GetClustersGroupsLinkedCustomers() {
+	ctx := context.Background()
+	customer := func(id uint64) entity.Customer { return entity.Customer{ID: id} }
+
+	suite.duplicateRepo.On("GetByStatus", ctx, entity.DuplicateOpen).Return([]*entity.CustomerDuplicate{
+		{ID: 1, CustomerID: 2, MatchedCustomerID: 1, Score: 0.8, Customer: customer(2), MatchedCustomer: customer(1)},
+		{ID: 2, CustomerID: 5, MatchedCustomerID: 4, Score: 0.95, Customer: customer(5), MatchedCustomer: customer(4)},
+		{ID: 3, CustomerID: 3, MatchedCustomerID: 2, Score: 0.85, Customer: customer(3), MatchedCustomer: customer(2)},
+	}, nil)
+
+	clusters, err := suite.duplicateUseCase.GetClusters(ctx, entity.DuplicateOpen)
+
+	assert.NoError(suite.T(), err)
+	if assert.Len(suite.T(), clusters, 2) {
+		assert.Equal(suite.T(), 0.95, clusters[0].MaxScore)
+		assert.Len(suite.T(), clusters[0].Customers, 2)
+
+		// 3 matches 2, which matches 1
+		assert.Equal(suite.T(), 0.85, clusters[1].MaxScore)
+		assert.Len(suite.T(), clusters[1].Flags, 2)
+		var ids []uint64
+		for _, c := range clusters[1].Customers {
+			ids = append(ids, c.ID)
+		}
+		assert.Equal(suite.T(), []uint64{1, 2, 3}, ids)
+	}
+}
+
+func (suite *UseCaseTestSuite) TestTransactionUseCase_CreateTransactionRejectsAgeAtTenorEnd() {
+	ctx := context.Background()
+	product := defaultProduct()
+	product.MaxAgeAtTenorEnd = 60
+
+	// Turns 61 within the next four months
+	birthDate := time.Now().AddDate(-61, 2, 0)
+	customer := &entity.Customer{ID: 1, BirthDate: birthDate, KYCStatus: entity.KYCVerified}
+	transaction := &entity.Transaction{
+		CustomerID:        1,
+		TenorMonths:       4,
+		OTRAmount:         500000,
+		AssetName:         "Smartphone",
+		AssetType:         entity.AssetWhiteGoods,
+		TransactionSource: entity.SourceEcommerce,
+		Status:            entity.StatusPending,
+	}
+
+	suite.customerRepo.On("GetByID", mock.Anything, uint64(1)).Return(customer, nil)
+	suite.productRepo.On("GetActive", mock.Anything).Return([]*entity.Product{product}, nil)
+
+	err := suite.transactionUseCase.CreateTransaction(ctx, transaction)
+
+	var ageErr *entity.AgeIneligibleError
+	if assert.ErrorAs(suite.T(), err, &ageErr) {
+		assert.Equal(suite.T(), entity.EligibilityAboveMaxAgeAtTerm, ageErr.Code)
+		assert.Equal(suite.T(), 61, ageErr.Age)
+		assert.Equal(suite.T(), "DEFAULT", ageErr.ProductCode)
+	}
+	suite.limitRepo.AssertNotCalled(suite.T(), "GetByCustomerAndTenor", mock.Anything, mock.Anything, mock.Anything)
+	suite.transactionRepo.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (suite *UseCaseTestSuite) TestCustomerUseCase_CreateCustomerRejectsApplicantBelowMinAge() {
+	ctx := context.Background()
+	young, senior := defaultProduct(), defaultProduct()
+	young.MinAge = 18
+	senior.Code = "SENIOR"
+	senior.MinAge = 55
+
+	birthDate := time.Now().AddDate(-17, 0, 0)
+	customer := &entity.Customer{
+		NIK:        "317101" + birthDate.Format("020106") + "0001",
+		FullName:   "John Doe",
+		LegalName:  "John Doe",
+		BirthPlace: "Jakarta",
+		BirthDate:  birthDate,
+		Salary:     5000000,
+	}
+
+	suite.productRepo.On("GetActive", mock.Anything).Return([]*entity.Product{young, senior}, nil)
+
+	err := suite.customerUseCase.CreateCustomer(ctx, customer, nil)
+
+	var ageErr *entity.AgeIneligibleError
+	if assert.ErrorAs(suite.T(), err, &ageErr) {
+		assert.Equal(suite.T(), entity.EligibilityBelowMinAge, ageErr.Code)
+		assert.Equal(suite.T(), 17, ageErr.Age)
+		assert.Equal(suite.T(), 18, ageErr.Limit)
+	}
+	suite.customerRepo.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (suite *UseCaseTestSuite) TestTransactionUseCase_CreateTransactionRejectsUnaffordableInstallments() {
+	ctx := context.Background()
+
+	transaction := &entity.Transaction{
+		CustomerID:        1,
+		TenorMonths:       1,
+		OTRAmount:         500000,
+		AssetName:         "Smartphone",
+		AssetType:         entity.AssetWhiteGoods,
+		TransactionSource: entity.SourceEcommerce,
+		Status:            entity.StatusPending,
+	}
+
+	suite.customerRepo.On("GetByID", mock.Anything, uint64(1)).Return(&entity.Customer{ID: 1, Salary: 3000000, KYCStatus: entity.KYCVerified}, nil)
+	suite.transactionRepo.On("GetByCustomerID", mock.Anything, uint64(1)).Return([]*entity.Transaction{
+		{CustomerID: 1, Status: entity.StatusActive, InstallmentAmount: 300000},
+		{CustomerID: 1, Status: entity.StatusPending, InstallmentAmount: 100000},
+		{CustomerID: 1, Status: entity.StatusRejected, InstallmentAmount: 900000},
+		{CustomerID: 1, Status: entity.StatusCompleted, InstallmentAmount: 900000},
+	}, nil)
+	suite.productRepo.On("GetActive", mock.Anything).Return([]*entity.Product{defaultProduct()}, nil)
+	suite.limitRepo.On("GetByCustomerAndTenor", mock.Anything, uint64(1), 1).Return(&entity.CustomerLimit{ID: 1, CustomerID: 1, TenorMonths: 1, LimitAmount: 1000000}, nil)
+	suite.rateCardRepo.On("GetEffective", mock.Anything, uint64(1), 1, entity.AssetWhiteGoods, mock.AnythingOfType("time.Time")).Return(defaultRateCard(), nil)
+	suite.adminFeeRuleRepo.On("GetActive", mock.Anything).Return([]*entity.AdminFeeRule{}, nil)
+
+	err := suite.transactionUseCase.CreateTransaction(ctx, transaction)
+
+	// The ACTIVE and PENDING contracts count: (300,000 + 100,000 + 560,000) / 3,000,000
+	var affordabilityErr *entity.AffordabilityError
+	if assert.ErrorAs(suite.T(), err, &affordabilityErr) {
+		assert.Equal(suite.T(), float64(400000), affordabilityErr.ExistingInstallments)
+		assert.Equal(suite.T(), float64(560000), affordabilityErr.NewInstallment)
+		assert.Equal(suite.T(), 0.32, affordabilityErr.Ratio)
+		assert.Equal(suite.T(), 0.3, affordabilityErr.MaxRatio)
+	}
+	assert.Equal(suite.T(), 0.32, transaction.DebtToIncomeRatio)
+	suite.contractSeqRepo.AssertNotCalled(suite.T(), "Next", mock.Anything, mock.Anything)
+	suite.transactionRepo.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (suite *UseCaseTestSuite) TestPartnerUseCase_RotateCredentialsGivesOldKeysAGracePeriod() {
+	ctx := context.Background()
+	suite.partnerRepo.On("GetByID", ctx, uint64(4)).Return(&entity.Partner{ID: 4, Type: entity.PartnerDealer, Status: entity.PartnerActive}, nil)
+	suite.credentialRepo.On("ExpireActive", suite.inTransaction(), uint64(4), mock.MatchedBy(func(expiresAt time.Time) bool {
+		return expiresAt.After(time.Now().Add(23 * time.Hour))
+	})).Return(nil)
+	suite.credentialRepo.On("Create", suite.inTransaction(), mock.AnythingOfType("*entity.PartnerCredential")).Return(nil)
+
+	issued, err := suite.partnerUseCase.RotateCredentials(ctx, 4, 1)
+
+	suite.Require().NoError(err)
+	assert.True(suite.T(), strings.HasPrefix(issued.Credential.APIKey, "pk_"))
+	assert.True(suite.T(), strings.HasPrefix(issued.Secret, "sk_"))
+	assert.NotEqual(suite.T(), issued.Secret, issued.Credential.SecretHash)
+	assert.Len(suite.T(), issued.Credential.SecretHash, 64)
+	suite.credentialRepo.AssertExpectations(suite.T())
+}
+
+func (suite *UseCaseTestSuite) TestPartnerUseCase_AuthenticateChecksSecretExpiryAndStatus() {
+	ctx := context.Background()
+	suite.partnerRepo.On("GetByID", ctx, uint64(4)).Return(&entity.Partner{ID: 4, Type: entity.PartnerMerchant, Status: entity.PartnerActive}, nil)
+	suite.credentialRepo.On("ExpireActive", suite.inTransaction(), uint64(4), mock.Anything).Return(nil)
+	suite.credentialRepo.On("Create", suite.inTransaction(), mock.Anything).Return(nil)
+	issued, err := suite.partnerUseCase.RotateCredentials(ctx, 4, 1)
+	suite.Require().NoError(err)
+
+	credential := issued.Credential
+	credential.ID = 9
+	credential.Partner = entity.Partner{ID: 4, Type: entity.PartnerMerchant, Status: entity.PartnerActive}
+	suite.credentialRepo.On("GetByAPIKey", ctx, credential.APIKey).Return(credential, nil)
+	suite.credentialRepo.On("GetByAPIKey", ctx, "pk_unknown").Return(nil, nil)
+	suite.credentialRepo.On("TouchLastUsed", ctx, uint64(9), mock.Anything).Return(nil)
+
+	partner, err := suite.partnerUseCase.Authenticate(ctx, credential.APIKey, issued.Secret)
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), uint64(4), partner.ID)
+	assert.Equal(suite.T(), entity.SourceEcommerce, partner.Type.TransactionSource())
+
+	_, err = suite.partnerUseCase.Authenticate(ctx, credential.APIKey, "sk_wrong")
+	assert.ErrorIs(suite.T(), err, usecase.ErrInvalidPartnerCredentials)
+	_, err = suite.partnerUseCase.Authenticate(ctx, "pk_unknown", issued.Secret)
+	assert.ErrorIs(suite.T(), err, usecase.ErrInvalidPartnerCredentials)
+
+	expired := time.Now().Add(-time.Minute)
+	credential.ExpiresAt = &expired
+	_, err = suite.partnerUseCase.Authenticate(ctx, credential.APIKey, issued.Secret)
+	assert.ErrorIs(suite.T(), err, usecase.ErrInvalidPartnerCredentials)
+
+	credential.ExpiresAt = nil
+	credential.Partner.Status = entity.PartnerSuspended
+	_, err = suite.partnerUseCase.Authenticate(ctx, credential.APIKey, issued.Secret)
+	assert.ErrorIs(suite.T(), err, usecase.ErrPartnerNotActive)
+	suite.credentialRepo.AssertNumberOfCalls(suite.T(), "TouchLastUsed", 1)
+}
+
+func (suite *UseCaseTestSuite) TestPartnerUseCase_OnlyLinkedCustomersPassTheLinkCheck() {
+	ctx := context.Background()
+	suite.partnerRepo.On("GetByID", ctx, uint64(4)).Return(&entity.Partner{ID: 4, Type: entity.PartnerDealer, Status: entity.PartnerActive}, nil)
+	suite.partnerRepo.On("GetByID", ctx, uint64(5)).Return(&entity.Partner{ID: 5, Type: entity.PartnerDealer, Status: entity.PartnerSuspended}, nil)
+	suite.customerRepo.On("GetByID", ctx, uint64(1)).Return(&entity.Customer{ID: 1}, nil)
+	suite.partnerRepo.On("LinkCustomer", ctx, mock.MatchedBy(func(link *entity.PartnerCustomer) bool {
+		return link.PartnerID == 4 && link.CustomerID == 1 && link.LinkedBy == 10
+	})).Return(nil)
+	suite.partnerRepo.On("IsCustomerLinked", ctx, uint64(4), uint64(1)).Return(true, nil)
+	suite.partnerRepo.On("IsCustomerLinked", ctx, uint64(4), uint64(2)).Return(false, nil)
+
+	link, err := suite.partnerUseCase.LinkCustomer(ctx, 4, 1, 10)
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), entity.PartnerDealer, link.Partner.Type)
+
+	_, err = suite.partnerUseCase.LinkCustomer(ctx, 5, 1, 10)
+	assert.ErrorIs(suite.T(), err, usecase.ErrPartnerNotActive)
+
+	assert.NoError(suite.T(), suite.partnerUseCase.CheckCustomerLink(ctx, 4, 1))
+	assert.ErrorIs(suite.T(), suite.partnerUseCase.CheckCustomerLink(ctx, 4, 2), usecase.ErrCustomerNotLinked)
+	suite.partnerRepo.AssertNumberOfCalls(suite.T(), "LinkCustomer", 1)
+}
+
 func TestUseCaseTestSuite(t *testing.T) {
 	suite.Run(t, new(UseCaseTestSuite))
 }
@@ -68,6 +68,18 @@ func TestValidateTenorLimits(t *testing.T) {
 	err = utils.ValidateTenorLimits(invalidLimits)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "limit amount must be greater than 0")
+
+	// A subset is fine, the rest is recommended
+	err = utils.ValidateTenorLimits([]utils.TenorLimit{{Tenor: 2, LimitAmount: 200000}})
+	assert.NoError(t, err)
+
+	// Duplicate tenor
+	err = utils.ValidateTenorLimits([]utils.TenorLimit{
+		{Tenor: 2, LimitAmount: 200000},
+		{Tenor: 2, LimitAmount: 300000},
+	})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "duplicate tenor")
 }
 
 func TestIsValidNIK(t *testing.T) {